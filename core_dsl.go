@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -276,7 +277,12 @@ func RunSpecs(t GinkgoTestingT, description string, args ...any) bool {
 
 	var reporter reporters.Reporter
 	if suiteConfig.ParallelTotal == 1 {
-		reporter = reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut)
+		if reporterConfig.GoTestJSON {
+			info, _ := debug.ReadBuildInfo()
+			reporter = reporters.NewGoTestJSONReporter(formatter.ColorableStdOut, types.GoTestPackage(info))
+		} else {
+			reporter = reporters.NewConsoleReporter(reporterConfig, formatter.ColorableStdOut)
+		}
 		outputInterceptor = internal.NoopOutputInterceptor{}
 		client = nil
 	} else {
@@ -297,8 +303,22 @@ func RunSpecs(t GinkgoTestingT, description string, args ...any) bool {
 		defer client.Close()
 	}
 
+	if reporterConfig.JSONStream != "" {
+		// when running in parallel the Ginkgo CLI owns the stream and each process forwards its events through the server
+		var streamWriter io.Writer
+		if client != nil {
+			streamWriter = parallel_support.NewStreamEventWriter(client)
+		} else {
+			f, err := os.OpenFile(reporterConfig.JSONStream, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+			exitIfErr(err)
+			defer f.Close()
+			streamWriter = f
+		}
+		reporter = reporters.CompositeReporter{reporter, reporters.NewJSONStreamReporter(streamWriter, suiteConfig.ParallelProcess)}
+	}
+
 	writer := GinkgoWriter.(*internal.Writer)
-	if reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose) && suiteConfig.ParallelTotal == 1 {
+	if reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose) && suiteConfig.ParallelTotal == 1 && !reporterConfig.GoTestJSON {
 		writer.SetMode(internal.WriterModeStreamAndBuffer)
 	} else {
 		writer.SetMode(internal.WriterModeBufferOnly)
@@ -420,6 +440,20 @@ func GinkgoRecover() {
 	}
 }
 
+/*
+GinkgoGo runs body on a new goroutine and recovers from any failures, just like a goroutine that calls `defer GinkgoRecover()`.
+
+Use GinkgoGo to launch goroutines from specs marked Concurrent.  Ginkgo attributes calls to `Fail` (and, therefore, failed Gomega assertions), `GinkgoWriter`, `By`, and `CurrentSpecReport` made on the new goroutine to the spec that launched it.  Goroutines started directly from a spec with a bare `go` statement are attributed to the spec too, but goroutines started from those may not be - so prefer GinkgoGo.
+
+You can learn more here: https://onsi.github.io/ginkgo/#concurrent-specs
+*/
+func GinkgoGo(body func()) {
+	global.Suite.Go(func() {
+		defer GinkgoRecover()
+		body()
+	})
+}
+
 // pushNode is used by the various test construction DSL methods to push nodes onto the suite
 // it handles returned errors, emits a detailed error message to help the user learn what they may have done wrong, then exits
 func pushNode(node internal.Node, errors []error) bool {
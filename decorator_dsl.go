@@ -53,6 +53,18 @@ You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorat
 */
 const Serial = internal.Serial
 
+/*
+Concurrent is a decorator that allows you to mark a spec or container as concurrent.  Ginkgo runs concurrent specs alongside one another on separate goroutines within a single process.
+Use --concurrency to control how many concurrent specs each process runs at once.
+
+Concurrent specs must not share mutable state with one another - including variables closed over by their containers and set up in BeforeEach.  Specs that are also marked Serial or that are in Ordered containers are never run concurrently.
+Output written directly to stdout and stderr by concurrent specs is not captured - write to GinkgoWriter instead.
+
+You can learn more here: https://onsi.github.io/ginkgo/#concurrent-specs
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+const Concurrent = internal.Concurrent
+
 /*
 Ordered is a decorator that allows you to mark a container as ordered.  Specs in the container will always run in the order they appear.
 They will never be randomized and they will never run in parallel with one another, though they may run in parallel with other specs.
@@ -84,6 +96,7 @@ const OncePerOrdered = internal.OncePerOrdered
 
 /*
 Label decorates specs with Labels.  Multiple labels can be passed to Label and these can be arbitrary strings but must not include the following characters: "&|!,()/".
+Labels of the form "key: value" are key/value labels and can be queried as sets in label filters (e.g. "owner: containsAny {payments, billing}").
 Labels can be applied to container and subject nodes, but not setup nodes.  You can provide multiple Labels to a given node and a spec's labels is the union of all labels in its node hierarchy.
 
 You can learn more here: https://onsi.github.io/ginkgo/#spec-labels
@@ -99,6 +112,43 @@ You can learn more here: https://onsi.github.io/ginkgo/#spec-labels
 */
 type Labels = internal.Labels
 
+/*
+SemVerConstraint decorates specs with semantic version constraints (e.g. SemVerConstraint(">= 2.1.0, < 3.0")).  Constraints use the syntax supported by github.com/Masterminds/semver.
+When Ginkgo is run with --sem-ver-filter=VERSION, specs with a constraint that VERSION does not satisfy are skipped.  Specs without constraints always run.
+SemVerConstraints can be applied to container and subject nodes, but not setup nodes.  A spec's constraints are the union of all constraints in its node hierarchy and must all be satisfied.
+
+You can learn more here: https://onsi.github.io/ginkgo/#filtering-specs-by-semantic-version
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func SemVerConstraint(semVerConstraints ...string) SemVerConstraints {
+	return SemVerConstraints(semVerConstraints)
+}
+
+/*
+SemVerConstraints are the type for spec SemVerConstraint decorators.  Use SemVerConstraint(...) to construct SemVerConstraints.
+You can learn more here: https://onsi.github.io/ginkgo/#filtering-specs-by-semantic-version
+*/
+type SemVerConstraints = internal.SemVerConstraints
+
+/*
+Exclusive decorates specs with the names of shared resources (e.g. Exclusive("database")) that they require exclusive access to.  Specs that share a resource never run at the same time - whether they are running in parallel processes or as Concurrent specs within a process.  Specs that don't share a resource continue to run in parallel.
+Exclusive can be applied to container and subject nodes, but not setup nodes.  A spec's resources are the union of all resources in its node hierarchy.  The specs in an Ordered container hold the union of all their resources for as long as the container runs.
+
+Time spent waiting for resources is reported in the SpecReport and is not included in the spec's RunTime.
+
+You can learn more here: https://onsi.github.io/ginkgo/#exclusive-resources
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+func Exclusive(resources ...string) ExclusiveResources {
+	return ExclusiveResources(resources)
+}
+
+/*
+ExclusiveResources are the type for spec Exclusive decorators.  Use Exclusive(...) to construct ExclusiveResources.
+You can learn more here: https://onsi.github.io/ginkgo/#exclusive-resources
+*/
+type ExclusiveResources = internal.ExclusiveResources
+
 /*
 PollProgressAfter allows you to override the configured value for --poll-progress-after for a particular node.
 
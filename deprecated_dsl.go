@@ -114,7 +114,7 @@ func GinkgoParallelNode() int {
 /*
 Deprecated: Benchmarker has been removed from Ginkgo 2.0
 
-Use Gomega's gmeasure package instead.
+Use SampleDuration and SampleValue (or Gomega's gmeasure package) instead.
 You can learn more here: https://onsi.github.io/ginkgo/#benchmarking-code
 */
 type Benchmarker interface {
@@ -126,7 +126,7 @@ type Benchmarker interface {
 /*
 Deprecated: Measure() has been removed from Ginkgo 2.0
 
-Use Gomega's gmeasure package instead.
+Use SampleDuration and SampleValue (or Gomega's gmeasure package) instead.
 You can learn more here: https://onsi.github.io/ginkgo/#benchmarking-code
 */
 func Measure(_ ...any) bool {
@@ -13,6 +13,8 @@ This "core" package pulls in the core Ginkgo DSL - most test suites will only ne
 package core
 
 import (
+	"math/rand"
+
 	"github.com/onsi/ginkgo/v2"
 )
 
@@ -22,7 +24,10 @@ type GinkgoWriterInterface = ginkgo.GinkgoWriterInterface
 type GinkgoTestingT = ginkgo.GinkgoTestingT
 type GinkgoTInterface = ginkgo.GinkgoTInterface
 type FullGinkgoTInterface = ginkgo.FullGinkgoTInterface
+type GinkgoTBWrapper = ginkgo.GinkgoTBWrapper
 type SpecContext = ginkgo.SpecContext
+type Generator = ginkgo.Generator
+type PropertyCounterexample = ginkgo.PropertyCounterexample
 
 var GinkgoWriter = ginkgo.GinkgoWriter
 var GinkgoLogr = ginkgo.GinkgoLogr
@@ -38,6 +43,7 @@ var Skip = ginkgo.Skip
 var Fail = ginkgo.Fail
 var AbortSuite = ginkgo.AbortSuite
 var GinkgoRecover = ginkgo.GinkgoRecover
+var GinkgoGo = ginkgo.GinkgoGo
 var Describe = ginkgo.Describe
 var FDescribe = ginkgo.FDescribe
 var PDescribe = ginkgo.PDescribe
@@ -49,6 +55,15 @@ var FIt = ginkgo.FIt
 var PIt = ginkgo.PIt
 var XIt = PIt
 var Specify, FSpecify, PSpecify, XSpecify = It, FIt, PIt, XIt
+var ItForAll = ginkgo.ItForAll
+var FItForAll = ginkgo.FItForAll
+var PItForAll = ginkgo.PItForAll
+var XItForAll = PItForAll
+var GenInt = ginkgo.GenInt
+var GenFloat64 = ginkgo.GenFloat64
+var GenBool = ginkgo.GenBool
+var GenString = ginkgo.GenString
+var GenSliceOf = ginkgo.GenSliceOf
 var By = ginkgo.By
 var BeforeSuite = ginkgo.BeforeSuite
 var AfterSuite = ginkgo.AfterSuite
@@ -62,4 +77,13 @@ var BeforeAll = ginkgo.BeforeAll
 var AfterAll = ginkgo.AfterAll
 var DeferCleanup = ginkgo.DeferCleanup
 var GinkgoT = ginkgo.GinkgoT
+var GinkgoTB = ginkgo.GinkgoTB
 var AttachProgressReporter = ginkgo.AttachProgressReporter
+
+func NewGenerator[T any](generate func(r *rand.Rand) T, shrink func(value T) []T) Generator {
+	return ginkgo.NewGenerator(generate, shrink)
+}
+
+func GenOneOf[T any](values ...T) Generator {
+	return ginkgo.GenOneOf(values...)
+}
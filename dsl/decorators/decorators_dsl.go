@@ -19,7 +19,10 @@ import (
 type Offset = ginkgo.Offset
 type FlakeAttempts = ginkgo.FlakeAttempts
 type MustPassRepeatedly = ginkgo.MustPassRepeatedly
+type PropertyIterations = ginkgo.PropertyIterations
 type Labels = ginkgo.Labels
+type SemVerConstraints = ginkgo.SemVerConstraints
+type ExclusiveResources = ginkgo.ExclusiveResources
 type PollProgressAfter = ginkgo.PollProgressAfter
 type PollProgressInterval = ginkgo.PollProgressInterval
 type NodeTimeout = ginkgo.NodeTimeout
@@ -29,9 +32,12 @@ type GracePeriod = ginkgo.GracePeriod
 const Focus = ginkgo.Focus
 const Pending = ginkgo.Pending
 const Serial = ginkgo.Serial
+const Concurrent = ginkgo.Concurrent
 const Ordered = ginkgo.Ordered
 const ContinueOnFailure = ginkgo.ContinueOnFailure
 const OncePerOrdered = ginkgo.OncePerOrdered
 const SuppressProgressReporting = ginkgo.SuppressProgressReporting
 
 var Label = ginkgo.Label
+var SemVerConstraint = ginkgo.SemVerConstraint
+var Exclusive = ginkgo.Exclusive
@@ -19,11 +19,19 @@ import (
 type Report = ginkgo.Report
 type SpecReport = ginkgo.SpecReport
 type ReportEntryVisibility = ginkgo.ReportEntryVisibility
+type Measurement = ginkgo.Measurement
+type MeasurementStats = ginkgo.MeasurementStats
+type SamplingConfig = ginkgo.SamplingConfig
+type MeasurementUnits = ginkgo.MeasurementUnits
+
+const HigherIsBetter = ginkgo.HigherIsBetter
 
 const ReportEntryVisibilityAlways, ReportEntryVisibilityFailureOrVerbose, ReportEntryVisibilityNever = ginkgo.ReportEntryVisibilityAlways, ginkgo.ReportEntryVisibilityFailureOrVerbose, ginkgo.ReportEntryVisibilityNever
 
 var CurrentSpecReport = ginkgo.CurrentSpecReport
 var AddReportEntry = ginkgo.AddReportEntry
+var SampleDuration = ginkgo.SampleDuration
+var SampleValue = ginkgo.SampleValue
 
 var ReportBeforeEach = ginkgo.ReportBeforeEach
 var ReportAfterEach = ginkgo.ReportAfterEach
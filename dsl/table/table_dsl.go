@@ -29,3 +29,66 @@ var Entry = ginkgo.Entry
 var FEntry = ginkgo.FEntry
 var PEntry = ginkgo.PEntry
 var XEntry = ginkgo.XEntry
+
+type TableEntrySource = ginkgo.TableEntrySource
+
+var EntriesFromFile = ginkgo.EntriesFromFile
+
+type TableBodyT[P any] interface {
+	ginkgo.TableBodyT[P]
+}
+
+type TableDescriptionT = ginkgo.TableDescriptionT
+
+var TableT = ginkgo.TableT
+
+// TableEntryT mirrors ginkgo.TableEntryT (Go does not support aliases of generic types)
+type TableEntryT[P any] ginkgo.TableEntryT[P]
+
+func DescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	ginkgo.GinkgoHelper()
+	return ginkgo.DescribeTableT(description, body, unwrapEntriesT(entries)...)
+}
+
+func FDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	ginkgo.GinkgoHelper()
+	return ginkgo.FDescribeTableT(description, body, unwrapEntriesT(entries)...)
+}
+
+func PDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	ginkgo.GinkgoHelper()
+	return ginkgo.PDescribeTableT(description, body, unwrapEntriesT(entries)...)
+}
+
+func XDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	ginkgo.GinkgoHelper()
+	return ginkgo.XDescribeTableT(description, body, unwrapEntriesT(entries)...)
+}
+
+func EntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	ginkgo.GinkgoHelper()
+	return TableEntryT[P](ginkgo.EntryT(description, parameters, decorators...))
+}
+
+func FEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	ginkgo.GinkgoHelper()
+	return TableEntryT[P](ginkgo.FEntryT(description, parameters, decorators...))
+}
+
+func PEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	ginkgo.GinkgoHelper()
+	return TableEntryT[P](ginkgo.PEntryT(description, parameters, decorators...))
+}
+
+func XEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	ginkgo.GinkgoHelper()
+	return TableEntryT[P](ginkgo.XEntryT(description, parameters, decorators...))
+}
+
+func unwrapEntriesT[P any](entries []TableEntryT[P]) []ginkgo.TableEntryT[P] {
+	out := make([]ginkgo.TableEntryT[P], len(entries))
+	for i := range entries {
+		out[i] = ginkgo.TableEntryT[P](entries[i])
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package flakes
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+)
+
+// SpecHistory summarizes the outcomes of a single spec over the runs analyzed by ComputeSpecHistories
+type SpecHistory struct {
+	SuitePath  string
+	FullText   string
+	FileName   string
+	LineNumber int
+
+	Runs          int
+	Failures      int
+	Flakes        int
+	MeanRunTime   time.Duration
+	RunTimeStdDev time.Duration
+}
+
+// FailureRate is the fraction of runs in which the spec failed
+func (s SpecHistory) FailureRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Runs)
+}
+
+// FlakeRate is the fraction of runs in which the spec only passed after being retried
+func (s SpecHistory) FlakeRate() float64 {
+	if s.Runs == 0 {
+		return 0
+	}
+	return float64(s.Flakes) / float64(s.Runs)
+}
+
+/*
+ComputeSpecHistories summarizes the history of every spec over the last lastN runs of its suite (or all runs if lastN is zero).
+
+A run is identified by its suite and start time.  The returned histories are ranked by failure rate, then flake rate, then run-time variance - the most suspect specs come first.
+*/
+func ComputeSpecHistories(records []internal.HistoryRecord, lastN int) []SpecHistory {
+	// runs are keyed by their start time in nanoseconds as time.Time values that represent the same instant need not be ==
+	runsBySuite := map[string][]int64{}
+	seenRuns := map[string]map[int64]bool{}
+	for _, record := range records {
+		if seenRuns[record.SuitePath] == nil {
+			seenRuns[record.SuitePath] = map[int64]bool{}
+		}
+		run := record.RunStartTime.UnixNano()
+		if !seenRuns[record.SuitePath][run] {
+			seenRuns[record.SuitePath][run] = true
+			runsBySuite[record.SuitePath] = append(runsBySuite[record.SuitePath], run)
+		}
+	}
+	includedRuns := map[string]map[int64]bool{}
+	for suitePath, runs := range runsBySuite {
+		sort.Slice(runs, func(i, j int) bool { return runs[i] < runs[j] })
+		if lastN > 0 && len(runs) > lastN {
+			runs = runs[len(runs)-lastN:]
+		}
+		includedRuns[suitePath] = map[int64]bool{}
+		for _, run := range runs {
+			includedRuns[suitePath][run] = true
+		}
+	}
+
+	keys := []string{}
+	recordsBySpec := map[string][]internal.HistoryRecord{}
+	for _, record := range records {
+		if !includedRuns[record.SuitePath][record.RunStartTime.UnixNano()] {
+			continue
+		}
+		key := record.Key()
+		if _, ok := recordsBySpec[key]; !ok {
+			keys = append(keys, key)
+		}
+		recordsBySpec[key] = append(recordsBySpec[key], record)
+	}
+
+	histories := []SpecHistory{}
+	for _, key := range keys {
+		histories = append(histories, summarize(recordsBySpec[key]))
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		if histories[i].FailureRate() != histories[j].FailureRate() {
+			return histories[i].FailureRate() > histories[j].FailureRate()
+		}
+		if histories[i].FlakeRate() != histories[j].FlakeRate() {
+			return histories[i].FlakeRate() > histories[j].FlakeRate()
+		}
+		return histories[i].RunTimeStdDev > histories[j].RunTimeStdDev
+	})
+
+	return histories
+}
+
+func summarize(records []internal.HistoryRecord) SpecHistory {
+	history := SpecHistory{
+		SuitePath:  records[0].SuitePath,
+		FullText:   records[0].FullText,
+		FileName:   records[0].FileName,
+		LineNumber: records[0].LineNumber,
+		Runs:       len(records),
+	}
+
+	var total float64
+	for _, record := range records {
+		if record.Failed() {
+			history.Failures += 1
+		}
+		if record.Flaked {
+			history.Flakes += 1
+		}
+		total += float64(record.RunTime)
+	}
+	mean := total / float64(len(records))
+
+	var sumOfSquares float64
+	for _, record := range records {
+		sumOfSquares += (float64(record.RunTime) - mean) * (float64(record.RunTime) - mean)
+	}
+
+	history.MeanRunTime = time.Duration(mean)
+	history.RunTimeStdDev = time.Duration(math.Sqrt(sumOfSquares / float64(len(records))))
+	return history
+}
@@ -0,0 +1,90 @@
+package flakes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type flakesConfig struct {
+	OutputDir string
+	Last      int
+	Top       int
+}
+
+func BuildFlakesCommand() command.Command {
+	conf := flakesConfig{
+		Last: 20,
+		Top:  10,
+	}
+	flags, err := types.NewGinkgoFlagSet(
+		types.GinkgoFlags{
+			{Name: "output-dir", KeyPath: "OutputDir",
+				Usage:         "The directory containing the " + internal.HISTORY_FILE_NAME + " file written by ginkgo --history.  Defaults to the current directory.",
+				UsageArgument: "directory",
+			},
+			{Name: "last", KeyPath: "Last",
+				Usage:             "Only analyze the last N runs of each suite.  Set to 0 to analyze every recorded run.",
+				UsageArgument:     "N",
+				UsageDefaultValue: "20",
+			},
+			{Name: "top", KeyPath: "Top",
+				Usage:             "Only list the N most suspect specs.  Set to 0 to list every spec.",
+				UsageArgument:     "N",
+				UsageDefaultValue: "10",
+			},
+		},
+		&conf,
+		types.GinkgoFlagSections{},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "flakes",
+		Usage:         "ginkgo flakes <FLAGS>",
+		Flags:         flags,
+		ShortDoc:      "Rank specs by failure rate, flake rate, and run-time variance using the history recorded by ginkgo --history.",
+		Documentation: "Run your suites with ginkgo --history to record the outcome of every spec.  ginkgo flakes then analyzes the last N runs of each suite and lists the most suspect specs first.",
+		DocLink:       "tracking-flaky-specs-over-time",
+		Command: func(args []string, _ []string) {
+			listFlakes(conf)
+		},
+	}
+}
+
+func listFlakes(conf flakesConfig) {
+	path := internal.HistoryPath(conf.OutputDir)
+	records, err := internal.LoadHistory(path)
+	command.AbortIfError("Failed to load history:", err)
+
+	histories := ComputeSpecHistories(records, conf.Last)
+	if len(histories) == 0 {
+		command.AbortGracefullyWith("No specs found in %s", path)
+	}
+	if conf.Top > 0 && len(histories) > conf.Top {
+		histories = histories[:conf.Top]
+	}
+
+	fmt.Fprintln(formatter.ColorableStdOut, formatter.F("{{bold}}%-12s %-12s %-26s %-6s %s{{/}}", "Failure Rate", "Flake Rate", "Run Time (mean ± std dev)", "Runs", "Spec"))
+	for _, history := range histories {
+		color := "{{green}}"
+		if history.Failures > 0 {
+			color = "{{red}}"
+		} else if history.Flakes > 0 {
+			color = "{{light-yellow}}"
+		}
+		runTime := fmt.Sprintf("%s ± %s", history.MeanRunTime.Round(time.Millisecond), history.RunTimeStdDev.Round(time.Millisecond))
+		fmt.Fprintln(formatter.ColorableStdOut, formatter.F(color+"%-12s %-12s{{/}} %-26s %-6d %s", percentage(history.FailureRate()), percentage(history.FlakeRate()), runTime, history.Runs, history.FullText))
+		fmt.Fprintln(formatter.ColorableStdOut, formatter.F("{{gray}}%s:%d{{/}}", history.FileName, history.LineNumber))
+	}
+}
+
+func percentage(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
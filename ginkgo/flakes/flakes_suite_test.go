@@ -0,0 +1,13 @@
+package flakes_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFlakes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Flakes Suite")
+}
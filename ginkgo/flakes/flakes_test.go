@@ -0,0 +1,97 @@
+package flakes_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/ginkgo/flakes"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ComputeSpecHistories", func() {
+	var records []internal.HistoryRecord
+	var t0 time.Time
+
+	record := func(run int, text string, state types.SpecState, flaked bool, runTime time.Duration) internal.HistoryRecord {
+		return internal.HistoryRecord{
+			SuitePath:    "/path/to/suite",
+			RunStartTime: t0.Add(time.Duration(run) * time.Minute),
+			FullText:     text,
+			FileName:     "/path/to/suite/suite_test.go",
+			LineNumber:   len(text),
+			State:        state,
+			Flaked:       flaked,
+			RunTime:      runTime,
+		}
+	}
+
+	BeforeEach(func() {
+		t0 = time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+		records = []internal.HistoryRecord{}
+		for run := 0; run < 4; run++ {
+			records = append(records,
+				record(run, "stable", types.SpecStatePassed, false, time.Second),
+				record(run, "slow and steady", types.SpecStatePassed, false, time.Duration(run+1)*time.Second),
+			)
+		}
+		records = append(records,
+			record(0, "failing", types.SpecStateFailed, false, time.Second),
+			record(1, "failing", types.SpecStatePassed, false, time.Second),
+			record(2, "failing", types.SpecStateQuarantined, false, time.Second),
+			record(3, "failing", types.SpecStatePassed, false, time.Second),
+			record(0, "flaky", types.SpecStatePassed, true, time.Second),
+			record(1, "flaky", types.SpecStatePassed, false, time.Second),
+			record(2, "flaky", types.SpecStatePassed, false, time.Second),
+			record(3, "flaky", types.SpecStatePassed, false, time.Second),
+		)
+	})
+
+	It("ranks specs by failure rate, then flake rate, then run-time variance", func() {
+		histories := flakes.ComputeSpecHistories(records, 0)
+		Ω(histories).Should(HaveLen(4))
+
+		Ω(histories[0].FullText).Should(Equal("failing"))
+		Ω(histories[0].Runs).Should(Equal(4))
+		Ω(histories[0].Failures).Should(Equal(2))
+		Ω(histories[0].FailureRate()).Should(Equal(0.5))
+
+		Ω(histories[1].FullText).Should(Equal("flaky"))
+		Ω(histories[1].Flakes).Should(Equal(1))
+		Ω(histories[1].FlakeRate()).Should(Equal(0.25))
+
+		Ω(histories[2].FullText).Should(Equal("slow and steady"))
+		Ω(histories[2].MeanRunTime).Should(Equal(2500 * time.Millisecond))
+		Ω(histories[2].RunTimeStdDev).Should(BeNumerically("~", 1118*time.Millisecond, time.Millisecond))
+
+		Ω(histories[3].FullText).Should(Equal("stable"))
+		Ω(histories[3].RunTimeStdDev).Should(BeZero())
+	})
+
+	It("only analyzes the last N runs of each suite", func() {
+		histories := flakes.ComputeSpecHistories(records, 2)
+		Ω(histories[0].FullText).Should(Equal("failing"))
+		Ω(histories[0].Runs).Should(Equal(2))
+		Ω(histories[0].Failures).Should(Equal(1))
+
+		flaky := histories[len(histories)-1]
+		for _, history := range histories {
+			if history.FullText == "flaky" {
+				flaky = history
+			}
+		}
+		Ω(flaky.Flakes).Should(BeZero())
+	})
+
+	It("tracks runs separately for each suite", func() {
+		other := record(10, "other suite", types.SpecStateFailed, false, time.Second)
+		other.SuitePath = "/path/to/other"
+		records = append(records, other)
+
+		histories := flakes.ComputeSpecHistories(records, 1)
+		Ω(histories[0].FullText).Should(Equal("other suite"))
+		Ω(histories[0].SuitePath).Should(Equal("/path/to/other"))
+		Ω(histories).Should(HaveLen(5))
+	})
+})
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const HISTORY_FILE_NAME = "ginkgo-history.jsonl"
+
+// the JSON report Ginkgo asks suites to generate when --history is set but --json-report isn't
+const historyReportName = "ginkgo-history-report.json"
+
+// HistoryRecord captures the outcome of a single spec in a single run of its suite.  --history appends one HistoryRecord per line to the history file.
+type HistoryRecord struct {
+	SuitePath    string
+	RunStartTime time.Time
+	FullText     string
+	FileName     string
+	LineNumber   int
+	State        types.SpecState
+	NumAttempts  int
+	Flaked       bool
+	RunTime      time.Duration
+}
+
+// Failed returns true if the spec failed in this run.  Quarantined failures count as failures.
+func (r HistoryRecord) Failed() bool {
+	return r.State.Is(types.SpecStateFailureStates | types.SpecStateQuarantined)
+}
+
+// Key identifies the spec the record refers to
+func (r HistoryRecord) Key() string {
+	return fmt.Sprintf("%s %s:%d %s", r.SuitePath, r.FileName, r.LineNumber, r.FullText)
+}
+
+// HistoryPath returns the path to the history file in outputDir (or the current directory if outputDir is empty)
+func HistoryPath(outputDir string) string {
+	return filepath.Join(outputDir, HISTORY_FILE_NAME)
+}
+
+// HistoryRecordsForReport returns a HistoryRecord for every It in the report that actually ran
+func HistoryRecordsForReport(report types.Report) []HistoryRecord {
+	records := []HistoryRecord{}
+	for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+		if !spec.State.Is(types.SpecStatePassed | types.SpecStateFailureStates | types.SpecStateQuarantined) {
+			continue
+		}
+		records = append(records, HistoryRecord{
+			SuitePath:    report.SuitePath,
+			RunStartTime: report.StartTime,
+			FullText:     spec.FullText(),
+			FileName:     spec.FileName(),
+			LineNumber:   spec.LineNumber(),
+			State:        spec.State,
+			NumAttempts:  spec.NumAttempts,
+			Flaked:       spec.State.Is(types.SpecStatePassed) && spec.NumAttempts > 1 && spec.MaxFlakeAttempts > 1,
+			RunTime:      spec.RunTime,
+		})
+	}
+	return records
+}
+
+// AppendToHistory appends records to the history file at path, creating it if necessary
+func AppendToHistory(path string, records []HistoryRecord) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadHistory loads every record in the history file at path, in the order in which they were recorded
+func LoadHistory(path string) ([]HistoryRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := []HistoryRecord{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := HistoryRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d is malformed:\n%w", path, lineNumber, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func recordHistory(suite TestSuite, reportName string, cleanup bool, cliConfig types.CLIConfig) error {
+	reportPath := AbsPathForGeneratedAsset(reportName, suite, cliConfig, 0)
+	if cleanup {
+		defer os.Remove(reportPath)
+	}
+	reports, err := reporters.ReadJSONReports(reportPath)
+	if err != nil {
+		return err
+	}
+	records := []HistoryRecord{}
+	for _, report := range reports {
+		records = append(records, HistoryRecordsForReport(report)...)
+	}
+	return AppendToHistory(HistoryPath(cliConfig.OutputDir), records)
+}
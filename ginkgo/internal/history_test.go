@@ -0,0 +1,86 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("History", func() {
+	var report types.Report
+
+	BeforeEach(func() {
+		cl := func(line int) types.CodeLocation {
+			return types.CodeLocation{FileName: "/path/to/suite/suite_test.go", LineNumber: line}
+		}
+		report = types.Report{
+			SuitePath: "/path/to/suite",
+			StartTime: time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC),
+			SpecReports: types.SpecReports{
+				{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed},
+				{LeafNodeType: types.NodeTypeIt, ContainerHierarchyTexts: []string{"books"}, LeafNodeText: "passes", LeafNodeLocation: cl(10), State: types.SpecStatePassed, NumAttempts: 1, MaxFlakeAttempts: 1, RunTime: time.Second},
+				{LeafNodeType: types.NodeTypeIt, LeafNodeText: "flakes", LeafNodeLocation: cl(20), State: types.SpecStatePassed, NumAttempts: 2, MaxFlakeAttempts: 3, RunTime: 2 * time.Second},
+				{LeafNodeType: types.NodeTypeIt, LeafNodeText: "repeats", LeafNodeLocation: cl(25), State: types.SpecStatePassed, NumAttempts: 2, MaxMustPassRepeatedly: 2},
+				{LeafNodeType: types.NodeTypeIt, LeafNodeText: "fails", LeafNodeLocation: cl(30), State: types.SpecStateFailed, NumAttempts: 1},
+				{LeafNodeType: types.NodeTypeIt, LeafNodeText: "is skipped", LeafNodeLocation: cl(40), State: types.SpecStateSkipped},
+				{LeafNodeType: types.NodeTypeIt, LeafNodeText: "is pending", LeafNodeLocation: cl(50), State: types.SpecStatePending},
+			},
+		}
+	})
+
+	Describe("HistoryRecordsForReport", func() {
+		It("records the outcome of every It that ran", func() {
+			records := HistoryRecordsForReport(report)
+			Ω(records).Should(HaveLen(4))
+
+			Ω(records[0]).Should(Equal(HistoryRecord{
+				SuitePath:    "/path/to/suite",
+				RunStartTime: report.StartTime,
+				FullText:     "books passes",
+				FileName:     "/path/to/suite/suite_test.go",
+				LineNumber:   10,
+				State:        types.SpecStatePassed,
+				NumAttempts:  1,
+				RunTime:      time.Second,
+			}))
+			Ω(records[0].Failed()).Should(BeFalse())
+			Ω(records[1].Flaked).Should(BeTrue())
+			Ω(records[2].Flaked).Should(BeFalse())
+			Ω(records[3].Failed()).Should(BeTrue())
+		})
+	})
+
+	Describe("reading and writing the history file", func() {
+		It("appends records to the history file", func() {
+			path := HistoryPath(GinkgoT().TempDir())
+			Ω(filepath.Base(path)).Should(Equal(HISTORY_FILE_NAME))
+
+			records := HistoryRecordsForReport(report)
+			Ω(AppendToHistory(path, records[:2])).Should(Succeed())
+			Ω(AppendToHistory(path, records[2:])).Should(Succeed())
+
+			loaded, err := LoadHistory(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded).Should(HaveLen(4))
+			for i := range records {
+				Ω(loaded[i].Key()).Should(Equal(records[i].Key()))
+				Ω(loaded[i].RunStartTime).Should(BeTemporally("==", records[i].RunStartTime))
+				Ω(loaded[i].State).Should(Equal(records[i].State))
+				Ω(loaded[i].Flaked).Should(Equal(records[i].Flaked))
+				Ω(loaded[i].RunTime).Should(Equal(records[i].RunTime))
+			}
+		})
+
+		It("errors when the history file is malformed", func() {
+			path := HistoryPath(GinkgoT().TempDir())
+			Ω(os.WriteFile(path, []byte("{\"SuitePath\": \"/path\"}\n{bloop\n"), 0666)).Should(Succeed())
+			_, err := LoadHistory(path)
+			Ω(err).Should(MatchError(ContainSubstring(":2 is malformed")))
+		})
+	})
+})
@@ -96,6 +96,9 @@ func FinalizeProfilesAndReportsForSuites(suites TestSuites, cliConfig types.CLIC
 	if reporterConfig.TeamcityReport != "" {
 		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TeamcityReport, GenerateFunc: reporters.GenerateTeamcityReport, MergeFunc: reporters.MergeAndCleanupTeamcityReports})
 	}
+	if reporterConfig.TAPReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TAPReport, GenerateFunc: reporters.GenerateTAPReport, MergeFunc: reporters.MergeAndCleanupTAPReports})
+	}
 
 	// Generate reports for suites that failed to run
 	reportableSuites := suites.ThatAreGinkgoSuites()
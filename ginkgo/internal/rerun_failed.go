@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// RerunFailedFilters captures the suites and spec filters needed to rerun just the specs that failed in a previous run
+type RerunFailedFilters struct {
+	SuitePaths []string
+	// FocusSpecs maps the path of each suite to the --focus-spec filters that target its failed specs.  Suites that must rerun in their entirety have no entry.
+	FocusSpecs map[string][]string
+}
+
+/*
+ComputeRerunFailedFilters inspects the reports generated by a previous run (e.g. via --json-report) and computes the filters necessary to rerun only the specs that failed.
+
+Each failed spec is targeted via a --focus-spec filter that matches its file, line, and full text.  If a suite failed without any failed specs (e.g. it failed to compile or timed out) or a suite-level node (e.g. BeforeSuite) failed then the entire suite is rerun.
+*/
+func ComputeRerunFailedFilters(reports []types.Report) RerunFailedFilters {
+	filters := RerunFailedFilters{FocusSpecs: map[string][]string{}}
+
+	for _, report := range reports {
+		if report.SuiteSucceeded {
+			continue
+		}
+		failedSpecs := report.SpecReports.WithState(types.SpecStateFailureStates)
+		rerunEntireSuite := len(failedSpecs) == 0 || len(failedSpecs.WithLeafNodeType(types.NodeTypeIt)) < len(failedSpecs)
+
+		suitePath := filepath.Clean(report.SuitePath)
+		filters.SuitePaths = append(filters.SuitePaths, suitePath)
+		if rerunEntireSuite {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, spec := range failedSpecs {
+			focusSpec := types.SpecIdentityFor(spec).String()
+			if !seen[focusSpec] {
+				seen[focusSpec] = true
+				filters.FocusSpecs[suitePath] = append(filters.FocusSpecs[suitePath], focusSpec)
+			}
+		}
+	}
+
+	return filters
+}
+
+// FocusSpecsFor returns the --focus-spec filters that rerun just the failed specs in suite.  It returns nil if the entire suite should rerun.
+func (f RerunFailedFilters) FocusSpecsFor(suite TestSuite) []string {
+	return f.FocusSpecs[suite.AbsPath()]
+}
+
+// IsEmpty returns true if there is nothing to rerun
+func (f RerunFailedFilters) IsEmpty() bool {
+	return len(f.SuitePaths) == 0
+}
+
+// ApplyToSuites marks any suites that had no failures as TestSuiteStateSkippedByFilter
+func (f RerunFailedFilters) ApplyToSuites(suites TestSuites) TestSuites {
+	out := make(TestSuites, len(suites))
+	for i, suite := range suites {
+		out[i] = suite
+		if suite.State.Is(TestSuiteStateSkippedByFilter) {
+			continue
+		}
+		rerun := false
+		for _, suitePath := range f.SuitePaths {
+			if suite.AbsPath() == suitePath {
+				rerun = true
+				break
+			}
+		}
+		if !rerun {
+			out[i].State = TestSuiteStateSkippedByFilter
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package internal_test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RerunFailedFilters", func() {
+	var reports []types.Report
+
+	spec := func(nodeType types.NodeType, fileName string, line int, state types.SpecState) types.SpecReport {
+		return types.SpecReport{
+			ContainerHierarchyTexts: []string{"container"},
+			LeafNodeType:            nodeType,
+			LeafNodeLocation:        types.CodeLocation{FileName: fileName, LineNumber: line},
+			LeafNodeText:            fmt.Sprintf("spec at %d", line),
+			State:                   state,
+		}
+	}
+
+	BeforeEach(func() {
+		reports = []types.Report{
+			{
+				SuitePath:      "/path/to/passing",
+				SuiteSucceeded: true,
+				SpecReports: types.SpecReports{
+					spec(types.NodeTypeIt, "/path/to/passing/a_test.go", 10, types.SpecStatePassed),
+				},
+			},
+			{
+				SuitePath:      "/path/to/failing",
+				SuiteSucceeded: false,
+				SpecReports: types.SpecReports{
+					spec(types.NodeTypeIt, "/path/to/failing/a_test.go", 10, types.SpecStatePassed),
+					spec(types.NodeTypeIt, "/path/to/failing/a_test.go", 17, types.SpecStateFailed),
+					spec(types.NodeTypeIt, "/path/to/failing/b.test.go", 3, types.SpecStateTimedout),
+					spec(types.NodeTypeIt, "/path/to/failing/b.test.go", 3, types.SpecStatePanicked),
+					spec(types.NodeTypeIt, "/path/to/failing/b.test.go", 8, types.SpecStateSkipped),
+				},
+			},
+			{
+				SuitePath:      "/path/to/before-suite-failure",
+				SuiteSucceeded: false,
+				SpecReports: types.SpecReports{
+					spec(types.NodeTypeBeforeSuite, "/path/to/before-suite-failure/suite_test.go", 12, types.SpecStateFailed),
+				},
+			},
+			{
+				SuitePath:                  "/path/to/compilation-failure",
+				SuiteSucceeded:             false,
+				SpecialSuiteFailureReasons: []string{"Failed to compile"},
+			},
+		}
+	})
+
+	It("targets failed specs by file, line, and text, and reruns entire suites when no individual spec can be targeted", func() {
+		filters := ComputeRerunFailedFilters(reports)
+		Ω(filters.IsEmpty()).Should(BeFalse())
+		Ω(filters.SuitePaths).Should(Equal([]string{"/path/to/failing", "/path/to/before-suite-failure", "/path/to/compilation-failure"}))
+		Ω(filters.FocusSpecs).Should(Equal(map[string][]string{
+			"/path/to/failing": {
+				`{"FileName":"/path/to/failing/a_test.go","LineNumber":17,"FullText":"container spec at 17"}`,
+				`{"FileName":"/path/to/failing/b.test.go","LineNumber":3,"FullText":"container spec at 3"}`,
+			},
+		}))
+	})
+
+	It("generates valid spec identities, even for paths that contain a ':'", func() {
+		reports[1].SpecReports[1].LeafNodeLocation.FileName = `C:\path\to\failing\a_test.go`
+		focusSpecs, err := types.ParseSpecIdentities(ComputeRerunFailedFilters(reports).FocusSpecs["/path/to/failing"])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(focusSpecs.Matches(types.CodeLocation{FileName: `C:\path\to\failing\a_test.go`, LineNumber: 17}, "container spec at 17")).Should(BeTrue())
+		Ω(focusSpecs.Matches(types.CodeLocation{FileName: `C:\path\to\failing\a_test.go`, LineNumber: 17}, "container another spec")).Should(BeFalse())
+		Ω(focusSpecs.Matches(types.CodeLocation{FileName: `C:\path\to\failing\a_test.go`, LineNumber: 10}, "container spec at 17")).Should(BeFalse())
+		Ω(focusSpecs.Matches(types.CodeLocation{FileName: "/path/to/failing/b.test.go", LineNumber: 3}, "container spec at 3")).Should(BeTrue())
+	})
+
+	It("returns the focus specs for each suite, and none for suites that rerun in their entirety", func() {
+		filters := RerunFailedFilters{FocusSpecs: map[string][]string{}}
+		failing, err := filepath.Abs("failing")
+		Ω(err).ShouldNot(HaveOccurred())
+		filters.FocusSpecs[failing] = []string{"spec"}
+
+		Ω(filters.FocusSpecsFor(TS("./failing", "failing", true, TestSuiteStateUncompiled))).Should(Equal([]string{"spec"}))
+		Ω(filters.FocusSpecsFor(TS("./other", "other", true, TestSuiteStateUncompiled))).Should(BeNil())
+	})
+
+	It("is empty when there are no failures", func() {
+		Ω(ComputeRerunFailedFilters(reports[:1]).IsEmpty()).Should(BeTrue())
+	})
+
+	It("marks suites without failures as skipped by filter", func() {
+		failing, err := filepath.Abs("failing")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		filters := RerunFailedFilters{SuitePaths: []string{failing}}
+		suites := filters.ApplyToSuites(TestSuites{
+			TS("./failing", "failing", true, TestSuiteStateUncompiled),
+			TS("./passing", "passing", true, TestSuiteStateUncompiled),
+			TS("./failing/skipped", "skipped", true, TestSuiteStateSkippedByFilter),
+		})
+		Ω(suites.WithState(TestSuiteStateUncompiled)).Should(Equal(TestSuites{TS("./failing", "failing", true, TestSuiteStateUncompiled)}))
+		Ω(suites.WithState(TestSuiteStateSkippedByFilter)).Should(HaveLen(2))
+	})
+})
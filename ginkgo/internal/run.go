@@ -2,6 +2,8 @@ package internal
 
 import (
 	"bytes"
+	"debug/buildinfo"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -27,24 +30,51 @@ func RunCompiledSuite(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterC
 		return suite
 	}
 
-	if suite.IsGinkgo && cliConfig.ComputedProcs() > 1 {
+	ginkgoConfig = absPathsForSuiteConfig(ginkgoConfig)
+	reporterConfig = absPathsForReporterConfig(reporterConfig)
+
+	// --history reads the outcome of each spec out of the suite's JSON report, so we ask for one if the user hasn't
+	recordingHistory := cliConfig.History && suite.IsGinkgo
+	cleanupHistoryReport := false
+	if recordingHistory && reporterConfig.JSONReport == "" {
+		reporterConfig.JSONReport = historyReportName
+		cleanupHistoryReport = true
+	}
+
+	if suite.IsGinkgo && (cliConfig.ComputedProcs() > 1 || cliConfig.Serve != "") {
 		suite = runParallel(suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
 	} else if suite.IsGinkgo {
 		suite = runSerial(suite, ginkgoConfig, reporterConfig, cliConfig, goFlagsConfig, additionalArgs)
 	} else {
 		suite = runGoTest(suite, cliConfig, goFlagsConfig)
 	}
+
+	if recordingHistory {
+		err := recordHistory(suite, reporterConfig.JSONReport, cleanupHistoryReport, cliConfig)
+		if err != nil {
+			fmt.Fprintln(formatter.ColorableStdErr, formatter.F("{{red}}{{bold}}Failed to record history for %s:{{/}}\n%s", suite.PackageName, err.Error()))
+		}
+	}
 	runAfterRunHook(cliConfig.AfterRunHook, reporterConfig.NoColor, suite)
 	return suite
 }
 
-func buildAndStartCommand(suite TestSuite, args []string, pipeToStdout bool) (*exec.Cmd, *bytes.Buffer) {
+// CLIOutput is where the Ginkgo CLI writes its own messages.  With --go-test-json stdout is reserved for JSON events so they go to stderr instead.
+func CLIOutput(reporterConfig types.ReporterConfig) io.Writer {
+	if reporterConfig.GoTestJSON {
+		return formatter.ColorableStdErr
+	}
+	return formatter.ColorableStdOut
+}
+
+// buildAndStartCommand pipes the test's output to pipeTo, or only buffers it if pipeTo is nil
+func buildAndStartCommand(suite TestSuite, args []string, pipeTo io.Writer) (*exec.Cmd, *bytes.Buffer) {
 	buf := &bytes.Buffer{}
 	cmd := exec.Command(suite.PathToCompiledTest, args...)
 	cmd.Dir = suite.Path
-	if pipeToStdout {
-		cmd.Stderr = io.MultiWriter(os.Stdout, buf)
-		cmd.Stdout = os.Stdout
+	if pipeTo != nil {
+		cmd.Stderr = io.MultiWriter(pipeTo, buf)
+		cmd.Stdout = pipeTo
 	} else {
 		cmd.Stderr = buf
 		cmd.Stdout = buf
@@ -63,6 +93,86 @@ func checkForNoTestsWarning(buf *bytes.Buffer) bool {
 	return false
 }
 
+// test binaries run in the suite's directory, so any paths the user provided relative to the current directory must be made absolute
+func absPathsForSuiteConfig(ginkgoConfig types.SuiteConfig) types.SuiteConfig {
+	if ginkgoConfig.DurationReport != "" {
+		if path, err := filepath.Abs(ginkgoConfig.DurationReport); err == nil {
+			ginkgoConfig.DurationReport = path
+		}
+	}
+	if ginkgoConfig.QuarantineFile != "" {
+		if path, err := filepath.Abs(ginkgoConfig.QuarantineFile); err == nil {
+			ginkgoConfig.QuarantineFile = path
+		}
+	}
+	return ginkgoConfig
+}
+
+func absPathsForReporterConfig(reporterConfig types.ReporterConfig) types.ReporterConfig {
+	if reporterConfig.JSONStream != "" {
+		if path, err := filepath.Abs(reporterConfig.JSONStream); err == nil {
+			reporterConfig.JSONStream = path
+		}
+	}
+	return reporterConfig
+}
+
+/*
+jsonLineSplitter forwards the lines a test binary writes that are JSON to jsonOut and all other lines (e.g. the "PASS" the testing package prints once the suite ends) to otherOut.  It keeps --go-test-json output on stdout parseable.
+*/
+type jsonLineSplitter struct {
+	lock     *sync.Mutex
+	jsonOut  io.Writer
+	otherOut io.Writer
+	partial  []byte
+}
+
+func newJSONLineSplitter(jsonOut io.Writer, otherOut io.Writer) *jsonLineSplitter {
+	return &jsonLineSplitter{
+		lock:     &sync.Mutex{},
+		jsonOut:  jsonOut,
+		otherOut: otherOut,
+	}
+}
+
+func (s *jsonLineSplitter) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.partial = append(s.partial, p...)
+	for {
+		idx := bytes.IndexByte(s.partial, '\n')
+		if idx == -1 {
+			return len(p), nil
+		}
+		s.writeLine(s.partial[:idx+1])
+		s.partial = s.partial[idx+1:]
+	}
+}
+
+// Flush writes out any trailing line that wasn't terminated by a newline
+func (s *jsonLineSplitter) Flush() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.partial) > 0 {
+		s.writeLine(append(s.partial, '\n'))
+		s.partial = nil
+	}
+}
+
+func (s *jsonLineSplitter) writeLine(line []byte) {
+	if json.Valid(line) {
+		s.jsonOut.Write(line)
+	} else {
+		s.otherOut.Write(line)
+	}
+}
+
+// goTestPackage returns the import path of the suite's package, as recorded in the compiled test binary
+func goTestPackage(suite TestSuite) string {
+	info, _ := buildinfo.ReadFile(suite.PathToCompiledTest)
+	return types.GoTestPackage(info)
+}
+
 func runGoTest(suite TestSuite, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig) TestSuite {
 	// As we run the go test from the suite directory, make sure the cover profile is absolute
 	// and placed into the expected output directory when one is configured.
@@ -72,7 +182,7 @@ func runGoTest(suite TestSuite, cliConfig types.CLIConfig, goFlagsConfig types.G
 
 	args, err := types.GenerateGoTestRunArgs(goFlagsConfig)
 	command.AbortIfError("Failed to generate test run arguments", err)
-	cmd, buf := buildAndStartCommand(suite, args, true)
+	cmd, buf := buildAndStartCommand(suite, args, os.Stdout)
 
 	cmd.Wait()
 
@@ -113,15 +223,27 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 	if reporterConfig.TeamcityReport != "" {
 		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
 	}
+	if reporterConfig.TAPReport != "" {
+		reporterConfig.TAPReport = AbsPathForGeneratedAsset(reporterConfig.TAPReport, suite, cliConfig, 0)
+	}
 
 	args, err := types.GenerateGinkgoTestRunArgs(ginkgoConfig, reporterConfig, goFlagsConfig)
 	command.AbortIfError("Failed to generate test run arguments", err)
 	args = append([]string{"--test.timeout=0"}, args...)
 	args = append(args, additionalArgs...)
 
-	cmd, buf := buildAndStartCommand(suite, args, true)
+	var pipeTo io.Writer = os.Stdout
+	var jsonLines *jsonLineSplitter
+	if reporterConfig.GoTestJSON {
+		jsonLines = newJSONLineSplitter(os.Stdout, os.Stderr)
+		pipeTo = jsonLines
+	}
+	cmd, buf := buildAndStartCommand(suite, args, pipeTo)
 
 	cmd.Wait()
+	if jsonLines != nil {
+		jsonLines.Flush()
+	}
 
 	exitStatus := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
 	suite.HasProgrammaticFocus = (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
@@ -135,19 +257,19 @@ func runSerial(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig t
 
 	if suite.HasProgrammaticFocus {
 		if goFlagsConfig.Cover {
-			fmt.Fprintln(os.Stdout, "coverage: no coverfile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "coverage: no coverfile was generated because specs are programmatically focused")
 		}
 		if goFlagsConfig.BlockProfile != "" {
-			fmt.Fprintln(os.Stdout, "no block profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no block profile was generated because specs are programmatically focused")
 		}
 		if goFlagsConfig.CPUProfile != "" {
-			fmt.Fprintln(os.Stdout, "no cpu profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no cpu profile was generated because specs are programmatically focused")
 		}
 		if goFlagsConfig.MemProfile != "" {
-			fmt.Fprintln(os.Stdout, "no mem profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no mem profile was generated because specs are programmatically focused")
 		}
 		if goFlagsConfig.MutexProfile != "" {
-			fmt.Fprintln(os.Stdout, "no mutex profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no mutex profile was generated because specs are programmatically focused")
 		}
 	}
 
@@ -160,7 +282,8 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 		hasProgrammaticFocus bool
 	}
 
-	numProcs := cliConfig.ComputedProcs()
+	numLocalProcs := cliConfig.ComputedProcs()
+	numProcs := numLocalProcs + cliConfig.RemoteProcs
 	procOutput := make([]*bytes.Buffer, numProcs)
 	coverProfiles := []string{}
 
@@ -171,11 +294,30 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 	procResults := make(chan procResult)
 
-	server, err := parallel_support.NewServer(numProcs, reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut))
+	var reporter reporters.Reporter
+	if reporterConfig.GoTestJSON {
+		reporter = reporters.NewGoTestJSONReporter(formatter.ColorableStdOut, goTestPackage(suite))
+	} else {
+		reporter = reporters.NewConsoleReporter(reporterConfig, formatter.ColorableStdOut)
+	}
+	var server parallel_support.Server
+	var err error
+	if cliConfig.Serve != "" {
+		server, err = parallel_support.NewServerOnAddress(numProcs, reporter, cliConfig.Serve, os.Getenv(types.PARALLEL_TOKEN_ENV_VAR))
+	} else {
+		server, err = parallel_support.NewServerWithTransport(numProcs, reporter, cliConfig.ParallelTransport)
+	}
 	command.AbortIfError("Failed to start parallel spec server", err)
 	server.Start()
 	defer server.Close()
 
+	if reporterConfig.JSONStream != "" {
+		stream, err := os.OpenFile(reporterConfig.JSONStream, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+		command.AbortIfError("Failed to open JSON event stream", err)
+		defer stream.Close()
+		server.SetStreamDestination(stream)
+	}
+
 	if reporterConfig.JSONReport != "" {
 		reporterConfig.JSONReport = AbsPathForGeneratedAsset(reporterConfig.JSONReport, suite, cliConfig, 0)
 	}
@@ -185,8 +327,11 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	if reporterConfig.TeamcityReport != "" {
 		reporterConfig.TeamcityReport = AbsPathForGeneratedAsset(reporterConfig.TeamcityReport, suite, cliConfig, 0)
 	}
+	if reporterConfig.TAPReport != "" {
+		reporterConfig.TAPReport = AbsPathForGeneratedAsset(reporterConfig.TAPReport, suite, cliConfig, 0)
+	}
 
-	for proc := 1; proc <= numProcs; proc++ {
+	for proc := 1; proc <= numLocalProcs; proc++ {
 		procGinkgoConfig := ginkgoConfig
 		procGinkgoConfig.ParallelProcess, procGinkgoConfig.ParallelTotal, procGinkgoConfig.ParallelHost = proc, numProcs, server.Address()
 
@@ -217,7 +362,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 		args = append([]string{"--test.timeout=0"}, args...)
 		args = append(args, additionalArgs...)
 
-		cmd, buf := buildAndStartCommand(suite, args, false)
+		cmd, buf := buildAndStartCommand(suite, args, nil)
 		procOutput[proc-1] = buf
 		server.RegisterAlive(proc, func() bool { return cmd.ProcessState == nil || !cmd.ProcessState.Exited() })
 
@@ -231,8 +376,49 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 		}()
 	}
 
+	if cliConfig.RemoteProcs > 0 {
+		// remote procs are only passed the flags that are safe to run on another machine (see types.GenerateRemoteProcTestRunArgs) so they don't generate profiles or reports.  Reports and measurement baselines are handled by proc 1, which always runs locally.
+		// the quarantine file and duration report don't exist on the worker's machine, so their contents are sent along instead.
+		var quarantineFile, durationReport []byte
+		if ginkgoConfig.QuarantineFile != "" {
+			quarantineFile, err = os.ReadFile(ginkgoConfig.QuarantineFile)
+			command.AbortIfError("Failed to read the quarantine file", err)
+		}
+		if ginkgoConfig.DurationReport != "" {
+			durationReport, err = os.ReadFile(ginkgoConfig.DurationReport)
+			command.AbortIfError("Failed to read the duration report", err)
+		}
+
+		remoteProcs := []parallel_support.RemoteProc{}
+		for proc := numLocalProcs + 1; proc <= numProcs; proc++ {
+			procGinkgoConfig := ginkgoConfig
+			procGinkgoConfig.ParallelProcess, procGinkgoConfig.ParallelTotal = proc, numProcs
+
+			args, err := types.GenerateRemoteProcTestRunArgs(procGinkgoConfig, reporterConfig, goFlagsConfig)
+			command.AbortIfError("Failed to generate test run arguments", err)
+			args = append(args, additionalArgs...)
+			remoteProcs = append(remoteProcs, parallel_support.RemoteProc{Proc: proc, Args: args, QuarantineFile: quarantineFile, DurationReport: durationReport})
+		}
+		server.AcceptRemoteProcs(remoteProcs)
+		fmt.Fprintf(CLIOutput(reporterConfig), "Serving %s on %s - waiting for workers to run %d remote %s\n", suite.PackageName, cliConfig.Serve, len(remoteProcs), PluralizedWord("proc", "procs", len(remoteProcs)))
+
+		go func() {
+			for range remoteProcs {
+				status := <-server.GetRemoteProcResults()
+				procOutput[status.Proc-1] = bytes.NewBufferString(status.Output)
+				if status.Lost {
+					fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{red}}{{bold}}Lost contact with the worker running proc %d{{/}}\n", status.Proc))
+				}
+				procResults <- procResult{
+					passed:               status.Passed,
+					hasProgrammaticFocus: status.HasProgrammaticFocus,
+				}
+			}
+		}()
+	}
+
 	passed := true
-	for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
+	for proc := 1; proc <= numProcs; proc++ {
 		result := <-procResults
 		passed = passed && result.passed
 		suite.HasProgrammaticFocus = suite.HasProgrammaticFocus || result.hasProgrammaticFocus
@@ -245,21 +431,21 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 	select {
 	case <-server.GetSuiteDone():
-		fmt.Println("")
+		fmt.Fprintln(CLIOutput(reporterConfig), "")
 	case <-time.After(time.Second):
 		//one of the nodes never finished reporting to the server.  Something must have gone wrong.
 		fmt.Fprint(formatter.ColorableStdErr, formatter.F("\n{{bold}}{{red}}Ginkgo timed out waiting for all parallel procs to report back{{/}}\n"))
 		fmt.Fprint(formatter.ColorableStdErr, formatter.F("{{gray}}Test suite:{{/}} %s (%s)\n\n", suite.PackageName, suite.Path))
 		fmt.Fprint(formatter.ColorableStdErr, formatter.Fiw(0, formatter.COLS, "This occurs if a parallel process exits before it reports its results to the Ginkgo CLI.  The CLI will now print out all the stdout/stderr output it's collected from the running processes.  However you may not see anything useful in these logs because the individual test processes usually intercept output to stdout/stderr in order to capture it in the spec reports.\n\nYou may want to try rerunning your test suite with {{light-gray}}--output-interceptor-mode=none{{/}} to see additional output here and debug your suite.\n"))
 		fmt.Fprintln(formatter.ColorableStdErr, "  ")
-		for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
+		for proc := 1; proc <= numProcs; proc++ {
 			fmt.Fprintf(formatter.ColorableStdErr, formatter.F("{{bold}}Output from proc %d:{{/}}\n", proc))
 			fmt.Fprintln(os.Stderr, formatter.Fi(1, "%s", procOutput[proc-1].String()))
 		}
 		fmt.Fprintf(os.Stderr, "** End **")
 	}
 
-	for proc := 1; proc <= cliConfig.ComputedProcs(); proc++ {
+	for proc := 1; proc <= numProcs; proc++ {
 		output := procOutput[proc-1].String()
 		if proc == 1 && checkForNoTestsWarning(procOutput[0]) && cliConfig.RequireSuite {
 			suite.State = TestSuiteStateFailed
@@ -271,7 +457,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 
 	if len(coverProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
-			fmt.Fprintln(os.Stdout, "coverage: no coverfile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "coverage: no coverfile was generated because specs are programmatically focused")
 		} else {
 			coverProfile := AbsPathForGeneratedAsset(goFlagsConfig.CoverProfile, suite, cliConfig, 0)
 			err := MergeAndCleanupCoverProfiles(coverProfiles, coverProfile)
@@ -280,15 +466,15 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 			coverage, err := GetCoverageFromCoverProfile(coverProfile)
 			command.AbortIfError("Failed to compute coverage", err)
 			if coverage == 0 {
-				fmt.Fprintln(os.Stdout, "coverage: [no statements]")
+				fmt.Fprintln(CLIOutput(reporterConfig), "coverage: [no statements]")
 			} else {
-				fmt.Fprintf(os.Stdout, "coverage: %.1f%% of statements\n", coverage)
+				fmt.Fprintf(CLIOutput(reporterConfig), "coverage: %.1f%% of statements\n", coverage)
 			}
 		}
 	}
 	if len(blockProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
-			fmt.Fprintln(os.Stdout, "no block profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no block profile was generated because specs are programmatically focused")
 		} else {
 			blockProfile := AbsPathForGeneratedAsset(goFlagsConfig.BlockProfile, suite, cliConfig, 0)
 			err := MergeProfiles(blockProfiles, blockProfile)
@@ -297,7 +483,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	}
 	if len(cpuProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
-			fmt.Fprintln(os.Stdout, "no cpu profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no cpu profile was generated because specs are programmatically focused")
 		} else {
 			cpuProfile := AbsPathForGeneratedAsset(goFlagsConfig.CPUProfile, suite, cliConfig, 0)
 			err := MergeProfiles(cpuProfiles, cpuProfile)
@@ -306,7 +492,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	}
 	if len(memProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
-			fmt.Fprintln(os.Stdout, "no mem profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no mem profile was generated because specs are programmatically focused")
 		} else {
 			memProfile := AbsPathForGeneratedAsset(goFlagsConfig.MemProfile, suite, cliConfig, 0)
 			err := MergeProfiles(memProfiles, memProfile)
@@ -315,7 +501,7 @@ func runParallel(suite TestSuite, ginkgoConfig types.SuiteConfig, reporterConfig
 	}
 	if len(mutexProfiles) > 0 {
 		if suite.HasProgrammaticFocus {
-			fmt.Fprintln(os.Stdout, "no mutex profile was generated because specs are programmatically focused")
+			fmt.Fprintln(CLIOutput(reporterConfig), "no mutex profile was generated because specs are programmatically focused")
 		} else {
 			mutexProfile := AbsPathForGeneratedAsset(goFlagsConfig.MutexProfile, suite, cliConfig, 0)
 			err := MergeProfiles(mutexProfiles, mutexProfile)
@@ -44,10 +44,43 @@ func ListLabels(args []string, cliConfig types.CLIConfig) {
 		labels := fetchLabelsFromPackage(suite.Path)
 		if len(labels) == 0 {
 			fmt.Printf("%s: No labels found\n", suite.PackageName)
-		} else {
-			fmt.Printf("%s: [%s]\n", suite.PackageName, strings.Join(labels, ", "))
+			continue
 		}
+		quoted := make([]string, len(labels))
+		for i, label := range labels {
+			quoted[i] = strconv.Quote(label)
+		}
+		fmt.Printf("%s: [%s]\n", suite.PackageName, strings.Join(quoted, ", "))
+		for _, labelSet := range labelSets(labels) {
+			fmt.Printf("  %s: {%s}\n", labelSet.key, strings.Join(labelSet.values, ", "))
+		}
+	}
+}
+
+type labelSet struct {
+	key    string
+	values []string
+}
+
+// labelSets groups the key:value labels in labels by key
+func labelSets(labels []string) []labelSet {
+	out := []labelSet{}
+	indices := map[string]int{}
+	for _, label := range labels {
+		key, value, isKeyValue := types.ParseKeyValueLabel(label)
+		if !isKeyValue {
+			continue
+		}
+		idx, ok := indices[key]
+		if !ok {
+			idx = len(out)
+			indices[key] = idx
+			out = append(out, labelSet{key: key})
+		}
+		out[idx].values = append(out[idx].values, value)
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
 }
 
 func fetchLabelsFromPackage(packagePath string) []string {
@@ -81,7 +114,7 @@ func fetchLabelsFromPackage(packagePath string) []string {
 		for _, label := range potentialLabels {
 			if !seen[label] {
 				seen[label] = true
-				labels = append(labels, strconv.Quote(label))
+				labels = append(labels, label)
 			}
 		}
 	})
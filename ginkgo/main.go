@@ -6,12 +6,15 @@ import (
 
 	"github.com/onsi/ginkgo/v2/ginkgo/build"
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/flakes"
 	"github.com/onsi/ginkgo/v2/ginkgo/generators"
 	"github.com/onsi/ginkgo/v2/ginkgo/labels"
 	"github.com/onsi/ginkgo/v2/ginkgo/outline"
+	"github.com/onsi/ginkgo/v2/ginkgo/report"
 	"github.com/onsi/ginkgo/v2/ginkgo/run"
 	"github.com/onsi/ginkgo/v2/ginkgo/unfocus"
 	"github.com/onsi/ginkgo/v2/ginkgo/watch"
+	"github.com/onsi/ginkgo/v2/ginkgo/worker"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -21,10 +24,13 @@ func GenerateCommands() []command.Command {
 	return []command.Command{
 		watch.BuildWatchCommand(),
 		build.BuildBuildCommand(),
+		worker.BuildWorkerCommand(),
 		generators.BuildBootstrapCommand(),
 		generators.BuildGenerateCommand(),
+		flakes.BuildFlakesCommand(),
 		labels.BuildLabelsCommand(),
 		outline.BuildOutlineCommand(),
+		report.BuildReportCommand(),
 		unfocus.BuildUnfocusCommand(),
 		BuildVersionCommand(),
 	}
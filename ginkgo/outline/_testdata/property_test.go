@@ -0,0 +1,11 @@
+package example_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("PropertyFixture", func() {
+	ItForAll("normal", GenInt(0, 10), Label("fast"), func(i int) {})
+	FItForAll("focused", GenBool(), func(b bool) {})
+	PItForAll("pending", GenString(5), func(s string) {})
+})
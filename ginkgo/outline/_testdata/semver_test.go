@@ -0,0 +1,21 @@
+package example_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("SemVerFixture", SemVerConstraint(">= 2.0.0"), func() {
+	Describe("new api", SemVerConstraint(">= 2.1.0, < 3.0"), func() {
+		It("works", func() {
+
+		})
+	})
+
+	It("is removed", SemVerConstraint("< 2.4.0"), Label("legacy"), func() {
+
+	})
+
+	It("has no constraints", func() {
+
+	})
+})
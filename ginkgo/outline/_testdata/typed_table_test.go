@@ -0,0 +1,28 @@
+package example_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+type pair struct {
+	a, b int
+}
+
+var _ = Describe("TypedTableFixture", func() {
+	DescribeTableT("typed",
+		func(p pair) {},
+		EntryT("normal", pair{1, 1}, Label("fast")),
+		FEntryT("focused", pair{1, 2}),
+		PEntryT("pending", pair{2, 2}),
+	)
+
+	FDescribeTableT("focused typed",
+		func(p pair) {},
+		EntryT("normal", pair{1, 1}),
+	)
+
+	DescribeTableT(TableT("decorated typed", Label("math"), Pending, EntryDescription("%d vs %d")),
+		func(p pair) {},
+		EntryT(nil, pair{1, 1}),
+	)
+})
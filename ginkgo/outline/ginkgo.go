@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -30,6 +31,9 @@ type ginkgoMetadata struct {
 	Focused bool     `json:"focused"`
 	Pending bool     `json:"pending"`
 	Labels  []string `json:"labels"`
+
+	// SemVerConstraints are the constraints passed to any SemVerConstraint decorators
+	SemVerConstraints []string `json:"semVerConstraints,omitempty"`
 }
 
 // ginkgoNode is used to construct the outline as a tree
@@ -143,39 +147,49 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 	n.Name = identName
 	n.Start, n.End = absoluteOffsetsForNode(fset, ce)
 	n.Nodes = make([]*ginkgoNode, 0)
+	if tableT, ok := tableTFromCallExpr(ce); ok && strings.HasSuffix(identName, "DescribeTableT") {
+		// typed tables may carry their text and decorators in a TableT call
+		ce = tableT
+	}
 	switch identName {
-	case "It", "Specify", "Entry":
+	case "It", "Specify", "Entry", "EntryT", "ItForAll":
 		n.Spec = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		n.Pending = pendingFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "FIt", "FSpecify", "FEntry":
+	case "FIt", "FSpecify", "FEntry", "FEntryT", "FItForAll":
 		n.Spec = true
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "PIt", "PSpecify", "XIt", "XSpecify", "PEntry", "XEntry":
+	case "PIt", "PSpecify", "XIt", "XSpecify", "PEntry", "XEntry", "PEntryT", "XEntryT", "PItForAll", "XItForAll":
 		n.Spec = true
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "Context", "Describe", "When", "DescribeTable":
+	case "Context", "Describe", "When", "DescribeTable", "DescribeTableT":
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		n.Pending = pendingFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "FContext", "FDescribe", "FWhen", "FDescribeTable":
+	case "FContext", "FDescribe", "FWhen", "FDescribeTable", "FDescribeTableT":
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "PContext", "PDescribe", "PWhen", "XContext", "XDescribe", "XWhen", "PDescribeTable", "XDescribeTable":
+	case "PContext", "PDescribe", "PWhen", "XContext", "XDescribe", "XWhen", "PDescribeTable", "XDescribeTable", "PDescribeTableT", "XDescribeTableT":
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		n.Labels = labelFromCallExpr(ce)
+		n.SemVerConstraints = semVerConstraintsFromCallExpr(ce)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
 	case "By":
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
@@ -195,6 +209,23 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 
 // textOrAltFromCallExpr tries to derive the "text" of a Ginkgo spec or
 // container. If it cannot derive it, it returns the alt text.
+// tableTFromCallExpr returns the TableT call passed as the first argument to a
+// typed table, if there is one.
+func tableTFromCallExpr(ce *ast.CallExpr) (*ast.CallExpr, bool) {
+	if len(ce.Args) < 1 {
+		return nil, false
+	}
+	tableT, ok := ce.Args[0].(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	_, identName, ok := packageAndIdentNamesFromCallExpr(tableT)
+	if !ok || identName != "TableT" {
+		return nil, false
+	}
+	return tableT, true
+}
+
 func textOrAltFromCallExpr(ce *ast.CallExpr, alt string) string {
 	text, defined := textFromCallExpr(ce)
 	if !defined {
@@ -274,6 +305,49 @@ func extractLabels(expr *ast.CallExpr) []string {
 	return out
 }
 
+func semVerConstraintsFromCallExpr(ce *ast.CallExpr) []string {
+	constraints := []string{}
+	if len(ce.Args) < 2 {
+		return constraints
+	}
+
+	for _, arg := range ce.Args[1:] {
+		switch expr := arg.(type) {
+		case *ast.CallExpr:
+			id, ok := expr.Fun.(*ast.Ident)
+			if !ok {
+				// to skip over cases where the expr.Fun. is actually *ast.SelectorExpr
+				continue
+			}
+			if id.Name == "SemVerConstraint" {
+				constraints = append(constraints, extractSemVerConstraints(expr)...)
+			}
+		}
+	}
+	return constraints
+}
+
+func extractSemVerConstraints(expr *ast.CallExpr) []string {
+	out := []string{}
+	for _, arg := range expr.Args {
+		switch expr := arg.(type) {
+		case *ast.BasicLit:
+			if expr.Kind == token.STRING {
+				unquoted, err := strconv.Unquote(expr.Value)
+				if err != nil {
+					unquoted = expr.Value
+				}
+				validated, err := types.ValidateAndCleanupSemVerConstraint(unquoted, types.CodeLocation{})
+				if err == nil {
+					out = append(out, validated)
+				}
+			}
+		}
+	}
+
+	return out
+}
+
 func pendingFromCallExpr(ce *ast.CallExpr) bool {
 
 	pending := false
@@ -62,6 +62,9 @@ var _ = DescribeTable("Validate outline from file with",
 	Entry("core dsl import", "dsl_core_test.go", "dsl_core_test.go.json", "dsl_core_test.go.csv"),
 	Entry("labels decorator on containers and specs", "labels_test.go", "labels_test.go.json", "labels_test.go.csv"),
 	Entry("pending decorator on containers and specs", "pending_decorator_test.go", "pending_decorator_test.go.json", "pending_decorator_test.go.csv"),
+	Entry("semver constraint decorator on containers and specs", "semver_test.go", "semver_test.go.json", "semver_test.go.csv"),
+	Entry("typed tables and entries", "typed_table_test.go", "typed_table_test.go.json", "typed_table_test.go.csv"),
+	Entry("property-based specs", "property_test.go", "property_test.go.json", "property_test.go.csv"),
 )
 
 var _ = Describe("Validate position", func() {
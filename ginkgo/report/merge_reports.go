@@ -0,0 +1,127 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+MergeReports combines reports generated by separate runs of the same suites (e.g. on different shards, on different machines, or across reruns) into a single report per suite.
+
+Reports are grouped by SuitePath and combined via types.Report.Add.  Spec reports (i.e. reports for It nodes) that share a location and full text are then deduplicated and their states reconciled:
+
+  - a spec that was skipped or pending in some runs but ran in others is reported with the outcome of the runs in which it ran
+  - a spec that passed in some runs and failed in others is reported as flaked (i.e. as passed with multiple attempts)
+  - a spec that failed in every run in which it ran is reported with its most recent failure
+
+Reports for suite-level nodes (e.g. BeforeSuite, SynchronizedBeforeSuite, AfterSuite) and report nodes are not reconciled: each run executes them independently, so a failure in one run is never made up for by a success in another.  Every failure is preserved - and the merged report only collapses such reports into their most recent run when none of them failed.
+
+The merged suite succeeds if no spec failed after reconciliation and no run reported a special suite failure reason.
+*/
+func MergeReports(reports []types.Report) []types.Report {
+	suitePaths := []string{}
+	mergedReports := map[string]types.Report{}
+	for _, report := range reports {
+		mergedReport, ok := mergedReports[report.SuitePath]
+		if !ok {
+			suitePaths = append(suitePaths, report.SuitePath)
+			mergedReports[report.SuitePath] = report
+			continue
+		}
+		preRunStats := mergedReport.PreRunStats
+		mergedReport = mergedReport.Add(report)
+		if report.PreRunStats.TotalSpecs > preRunStats.TotalSpecs {
+			preRunStats.TotalSpecs = report.PreRunStats.TotalSpecs
+		}
+		mergedReport.PreRunStats = preRunStats
+		mergedReports[report.SuitePath] = mergedReport
+	}
+
+	out := []types.Report{}
+	for _, suitePath := range suitePaths {
+		report := mergedReports[suitePath]
+		report.SpecReports = deduplicateSpecReports(report.SpecReports)
+
+		specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+		report.PreRunStats.SpecsThatWillRun = len(specs) - specs.CountWithState(types.SpecStateSkipped|types.SpecStatePending)
+		report.PreRunStats.SpecsInOtherShards = 0
+		if report.PreRunStats.TotalSpecs > len(specs) {
+			report.PreRunStats.SpecsInOtherShards = report.PreRunStats.TotalSpecs - len(specs)
+		}
+		report.SuiteSucceeded = len(report.SpecialSuiteFailureReasons) == 0 && report.SpecReports.CountWithState(types.SpecStateFailureStates) == 0
+
+		out = append(out, report)
+	}
+	return out
+}
+
+func specReportKey(report types.SpecReport) string {
+	return fmt.Sprintf("%s:%d %s %s", report.FileName(), report.LineNumber(), report.LeafNodeType, report.FullText())
+}
+
+func deduplicateSpecReports(specReports types.SpecReports) types.SpecReports {
+	keys := []string{}
+	groups := map[string]types.SpecReports{}
+	for _, specReport := range specReports {
+		key := specReportKey(specReport)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], specReport)
+	}
+
+	out := types.SpecReports{}
+	for _, key := range keys {
+		group := groups[key]
+		if group[0].LeafNodeType != types.NodeTypeIt {
+			out = append(out, collapseSuiteNodeReports(group)...)
+			continue
+		}
+		out = append(out, reconcileSpecReports(group))
+	}
+	return out
+}
+
+// collapseSuiteNodeReports keeps every failed report for a suite-level node, or the most recent report if none of them failed
+func collapseSuiteNodeReports(specReports types.SpecReports) types.SpecReports {
+	failed := specReports.WithState(types.SpecStateFailureStates)
+	if len(failed) > 0 {
+		return failed
+	}
+	return specReports[len(specReports)-1:]
+}
+
+func reconcileSpecReports(specReports types.SpecReports) types.SpecReport {
+	if len(specReports) == 1 {
+		return specReports[0]
+	}
+
+	ran := specReports.WithState(types.SpecStatePassed | types.SpecStateFailureStates)
+	if len(ran) == 0 {
+		return specReports[len(specReports)-1]
+	}
+
+	passed, failed := ran.WithState(types.SpecStatePassed), ran.WithState(types.SpecStateFailureStates)
+	if len(failed) == 0 {
+		return passed[len(passed)-1]
+	}
+	if len(passed) == 0 {
+		return failed[len(failed)-1]
+	}
+
+	// the spec both passed and failed - we report it as flaked: a passing spec that took multiple attempts
+	out := passed[len(passed)-1]
+	out.NumAttempts = 0
+	for _, specReport := range ran {
+		if specReport.NumAttempts > 1 {
+			out.NumAttempts += specReport.NumAttempts
+		} else {
+			out.NumAttempts += 1
+		}
+	}
+	if out.NumAttempts > out.MaxFlakeAttempts {
+		out.MaxFlakeAttempts = out.NumAttempts
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package report_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/report"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("MergeReports", func() {
+	spec := func(text string, line int, state types.SpecState, options ...int) types.SpecReport {
+		specReport := types.SpecReport{
+			ContainerHierarchyTexts: []string{"container"},
+			LeafNodeType:            types.NodeTypeIt,
+			LeafNodeText:            text,
+			LeafNodeLocation:        types.CodeLocation{FileName: "/path/to/a_test.go", LineNumber: line},
+			State:                   state,
+			NumAttempts:             1,
+		}
+		if len(options) > 0 {
+			specReport.NumAttempts = options[0]
+		}
+		return specReport
+	}
+
+	names := func(specReports types.SpecReports) []string {
+		out := []string{}
+		for _, specReport := range specReports {
+			out = append(out, specReport.LeafNodeText)
+		}
+		return out
+	}
+
+	It("combines the reports for each suite, preserving the order in which suites appear", func() {
+		merged := report.MergeReports([]types.Report{
+			{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{spec("A", 1, types.SpecStatePassed)}, PreRunStats: types.PreRunStats{TotalSpecs: 3}},
+			{SuitePath: "/path/to/b", SuiteSucceeded: true, SpecReports: types.SpecReports{spec("B", 1, types.SpecStatePassed)}, PreRunStats: types.PreRunStats{TotalSpecs: 1}},
+			{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{spec("C", 2, types.SpecStatePassed)}, PreRunStats: types.PreRunStats{TotalSpecs: 3}},
+		})
+		Ω(merged).Should(HaveLen(2))
+		Ω(merged[0].SuitePath).Should(Equal("/path/to/a"))
+		Ω(names(merged[0].SpecReports)).Should(Equal([]string{"A", "C"}))
+		Ω(merged[0].PreRunStats).Should(Equal(types.PreRunStats{TotalSpecs: 3, SpecsThatWillRun: 2, SpecsInOtherShards: 1}))
+		Ω(merged[0].SuiteSucceeded).Should(BeTrue())
+		Ω(merged[1].SuitePath).Should(Equal("/path/to/b"))
+		Ω(names(merged[1].SpecReports)).Should(Equal([]string{"B"}))
+	})
+
+	It("deduplicates specs by location and text and reconciles their states", func() {
+		merged := report.MergeReports([]types.Report{
+			{SuitePath: "/path/to/a", SuiteSucceeded: false, SpecReports: types.SpecReports{
+				spec("passed", 1, types.SpecStatePassed),
+				spec("flaked", 2, types.SpecStateFailed),
+				spec("failed", 3, types.SpecStateFailed),
+				spec("skipped-then-passed", 4, types.SpecStateSkipped),
+				spec("pending", 5, types.SpecStatePending),
+				spec("same-line", 6, types.SpecStatePassed),
+			}},
+			{SuitePath: "/path/to/a", SuiteSucceeded: false, SpecReports: types.SpecReports{
+				spec("passed", 1, types.SpecStatePassed),
+				spec("flaked", 2, types.SpecStatePassed, 2),
+				spec("failed", 3, types.SpecStateTimedout),
+				spec("skipped-then-passed", 4, types.SpecStatePassed),
+				spec("pending", 5, types.SpecStatePending),
+				spec("different-text", 6, types.SpecStateFailed),
+			}},
+		})
+		Ω(merged).Should(HaveLen(1))
+		specs := merged[0].SpecReports
+		Ω(names(specs)).Should(Equal([]string{"passed", "flaked", "failed", "skipped-then-passed", "pending", "same-line", "different-text"}))
+
+		Ω(specs[0].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs[0].NumAttempts).Should(Equal(1))
+
+		Ω(specs[1].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs[1].NumAttempts).Should(Equal(3))
+		Ω(specs.CountOfFlakedSpecs()).Should(Equal(1))
+
+		Ω(specs[2].State).Should(Equal(types.SpecStateTimedout))
+		Ω(specs[3].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs[4].State).Should(Equal(types.SpecStatePending))
+		Ω(specs[5].State).Should(Equal(types.SpecStatePassed))
+		Ω(specs[6].State).Should(Equal(types.SpecStateFailed))
+
+		Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+	})
+
+	It("marks the suite as successful when every failure was resolved by another run", func() {
+		merged := report.MergeReports([]types.Report{
+			{SuitePath: "/path/to/a", SuiteSucceeded: false, SpecReports: types.SpecReports{spec("A", 1, types.SpecStateFailed)}},
+			{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{spec("A", 1, types.SpecStatePassed)}},
+		})
+		Ω(merged[0].SuiteSucceeded).Should(BeTrue())
+		Ω(merged[0].SpecReports.CountOfFlakedSpecs()).Should(Equal(1))
+	})
+
+	Describe("suite-level nodes", func() {
+		suiteNode := func(nodeType types.NodeType, state types.SpecState) types.SpecReport {
+			return types.SpecReport{
+				LeafNodeType:     nodeType,
+				LeafNodeLocation: types.CodeLocation{FileName: "/path/to/suite_test.go", LineNumber: 10},
+				State:            state,
+				NumAttempts:      1,
+			}
+		}
+
+		It("never reconciles a suite-level failure with a success from another run", func() {
+			merged := report.MergeReports([]types.Report{
+				{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{
+					suiteNode(types.NodeTypeBeforeSuite, types.SpecStatePassed),
+					spec("A", 1, types.SpecStatePassed),
+				}},
+				{SuitePath: "/path/to/a", SuiteSucceeded: false, SpecReports: types.SpecReports{
+					suiteNode(types.NodeTypeBeforeSuite, types.SpecStateFailed),
+					spec("B", 2, types.SpecStateSkipped),
+				}},
+			})
+			Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+			beforeSuites := merged[0].SpecReports.WithLeafNodeType(types.NodeTypeBeforeSuite)
+			Ω(beforeSuites).Should(HaveLen(1))
+			Ω(beforeSuites[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(merged[0].SpecReports.CountOfFlakedSpecs()).Should(BeZero())
+		})
+
+		It("keeps every failure", func() {
+			merged := report.MergeReports([]types.Report{
+				{SuitePath: "/path/to/a", SpecReports: types.SpecReports{suiteNode(types.NodeTypeAfterSuite, types.SpecStateFailed)}},
+				{SuitePath: "/path/to/a", SpecReports: types.SpecReports{suiteNode(types.NodeTypeAfterSuite, types.SpecStatePassed)}},
+				{SuitePath: "/path/to/a", SpecReports: types.SpecReports{suiteNode(types.NodeTypeAfterSuite, types.SpecStatePanicked)}},
+			})
+			Ω(merged[0].SpecReports).Should(HaveLen(2))
+			Ω(merged[0].SpecReports[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(merged[0].SpecReports[1].State).Should(Equal(types.SpecStatePanicked))
+			Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+		})
+
+		It("collapses suite-level nodes that passed in every run", func() {
+			merged := report.MergeReports([]types.Report{
+				{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{suiteNode(types.NodeTypeSynchronizedBeforeSuite, types.SpecStatePassed)}},
+				{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{suiteNode(types.NodeTypeSynchronizedBeforeSuite, types.SpecStatePassed)}},
+			})
+			Ω(merged[0].SpecReports).Should(HaveLen(1))
+			Ω(merged[0].SuiteSucceeded).Should(BeTrue())
+		})
+	})
+
+	It("preserves special suite failure reasons", func() {
+		merged := report.MergeReports([]types.Report{
+			{SuitePath: "/path/to/a", SuiteSucceeded: false, SpecialSuiteFailureReasons: []string{"Interrupted by User"}},
+			{SuitePath: "/path/to/a", SuiteSucceeded: true, SpecReports: types.SpecReports{spec("A", 1, types.SpecStatePassed)}},
+		})
+		Ω(merged[0].SuiteSucceeded).Should(BeFalse())
+		Ω(merged[0].SpecialSuiteFailureReasons).Should(ConsistOf("Interrupted by User"))
+	})
+})
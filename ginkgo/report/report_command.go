@@ -0,0 +1,109 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func BuildReportCommand() command.Command {
+	var reporterConfig = types.NewDefaultReporterConfig()
+
+	flags, err := types.BuildReportCommandFlagSet(&reporterConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "report",
+		Usage:         "ginkgo report merge <FLAGS> <JSON REPORTS>",
+		Flags:         flags,
+		ShortDoc:      "Merge JSON reports generated by separate runs (e.g. --shard or --repeat) into a single report.",
+		Documentation: "Specs that appear in multiple reports are deduplicated.  Specs that failed in one report and passed in another are reported as flaked.  The merged report can be emitted in any of the supported formats.",
+		DocLink:       "merging-reports-from-multiple-runs",
+		Command: func(args []string, _ []string) {
+			if len(args) == 0 || args[0] != "merge" {
+				command.AbortWithUsage("Please specify a report subcommand.  Only merge is supported.")
+			}
+			args, err := flags.Parse(args[1:])
+			if err != nil {
+				command.AbortWithUsage(err.Error())
+			}
+			mergeReports(args, reporterConfig)
+		},
+	}
+}
+
+func mergeReports(args []string, reporterConfig types.ReporterConfig) {
+	if len(args) == 0 {
+		command.AbortWithUsage("Please specify the JSON reports to merge.")
+	}
+	if !reporterConfig.WillGenerateReport() {
+		command.AbortWithUsage("Please specify at least one of --json-report, --junit-report, --teamcity-report, or --tap-report.")
+	}
+
+	reports := []types.Report{}
+	for _, source := range args {
+		loadedReports, err := reporters.ReadJSONReports(source)
+		command.AbortIfError("Failed to load report:", err)
+		reports = append(reports, loadedReports...)
+	}
+	mergedReports := MergeReports(reports)
+
+	type reportFormat struct {
+		ReportName   string
+		GenerateFunc func(types.Report, string) error
+		MergeFunc    func([]string, string) ([]string, error)
+	}
+	reportFormats := []reportFormat{}
+	if reporterConfig.JSONReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JSONReport, GenerateFunc: reporters.GenerateJSONReport, MergeFunc: reporters.MergeAndCleanupJSONReports})
+	}
+	if reporterConfig.JUnitReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.JUnitReport, GenerateFunc: reporters.GenerateJUnitReport, MergeFunc: reporters.MergeAndCleanupJUnitReports})
+	}
+	if reporterConfig.TeamcityReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TeamcityReport, GenerateFunc: reporters.GenerateTeamcityReport, MergeFunc: reporters.MergeAndCleanupTeamcityReports})
+	}
+	if reporterConfig.TAPReport != "" {
+		reportFormats = append(reportFormats, reportFormat{ReportName: reporterConfig.TAPReport, GenerateFunc: reporters.GenerateTAPReport, MergeFunc: reporters.MergeAndCleanupTAPReports})
+	}
+
+	// we generate a report for each suite and then lean on the existing merge functions to combine them into the requested destination
+	tmpDir, err := os.MkdirTemp("", "ginkgo-report-merge")
+	command.AbortIfError("Failed to create temporary directory:", err)
+	defer os.RemoveAll(tmpDir)
+
+	for _, format := range reportFormats {
+		suiteReports := []string{}
+		for i, report := range mergedReports {
+			suiteReport := filepath.Join(tmpDir, fmt.Sprintf("%d_%s", i, filepath.Base(format.ReportName)))
+			command.AbortIfError("Failed to generate report:", format.GenerateFunc(report, suiteReport))
+			suiteReports = append(suiteReports, suiteReport)
+		}
+		messages, err := format.MergeFunc(suiteReports, format.ReportName)
+		for _, message := range messages {
+			fmt.Println(message)
+		}
+		command.AbortIfError("Failed to write merged report:", err)
+	}
+
+	for _, report := range mergedReports {
+		specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+		status := "SUCCESS!"
+		if !report.SuiteSucceeded {
+			status = "FAIL!"
+		}
+		fmt.Printf("%s: %s %d Passed | %d Failed | %d Flaked | %d Pending | %d Skipped\n", report.SuitePath, status,
+			specs.CountWithState(types.SpecStatePassed),
+			specs.CountWithState(types.SpecStateFailureStates),
+			specs.CountOfFlakedSpecs(),
+			specs.CountWithState(types.SpecStatePending),
+			specs.CountWithState(types.SpecStateSkipped),
+		)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
 	"github.com/onsi/ginkgo/v2/ginkgo/internal"
 	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -61,20 +62,25 @@ type SpecRunner struct {
 	goFlagsConfig  types.GoFlagsConfig
 	flags          types.GinkgoFlagSet
 
-	interruptHandler *interrupt_handler.InterruptHandler
+	rerunFailedFilters internal.RerunFailedFilters
+	interruptHandler   *interrupt_handler.InterruptHandler
 }
 
 func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
+	out := internal.CLIOutput(r.reporterConfig)
 	suites := internal.FindSuites(args, r.cliConfig, true)
+	if r.cliConfig.RerunFailed != "" {
+		suites = r.applyRerunFailed(suites)
+	}
 	skippedSuites := suites.WithState(internal.TestSuiteStateSkippedByFilter)
 	suites = suites.WithoutState(internal.TestSuiteStateSkippedByFilter)
 
 	internal.VerifyCLIAndFrameworkVersion(suites)
 
 	if len(skippedSuites) > 0 {
-		fmt.Println("Will skip:")
+		fmt.Fprintln(out, "Will skip:")
 		for _, skippedSuite := range skippedSuites {
-			fmt.Println("  " + skippedSuite.Path)
+			fmt.Fprintln(out, "  "+skippedSuite.Path)
 		}
 	}
 
@@ -86,6 +92,10 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 		command.AbortWith("Found no test suites")
 	}
 
+	if r.cliConfig.Serve != "" && len(suites) > 1 {
+		command.AbortIfErrors("Ginkgo detected configuration issues:", []error{types.GinkgoErrors.ServeCanOnlyRunOneSuite(len(suites))})
+	}
+
 	if len(suites) > 1 && !r.flags.WasSet("succinct") && r.reporterConfig.Verbosity().LT(types.VerbosityLevelVerbose) {
 		r.reporterConfig.Succinct = true
 	}
@@ -123,12 +133,12 @@ OUTER_LOOP:
 			}
 
 			if suites[suiteIdx].State.Is(internal.TestSuiteStateSkippedDueToEmptyCompilation) {
-				fmt.Printf("Skipping %s (no test files)\n", suite.Path)
+				fmt.Fprintf(out, "Skipping %s (no test files)\n", suite.Path)
 				continue SUITE_LOOP
 			}
 
 			if suites[suiteIdx].State.Is(internal.TestSuiteStateFailedToCompile) {
-				fmt.Println(suites[suiteIdx].CompilationError.Error())
+				fmt.Fprintln(out, suites[suiteIdx].CompilationError.Error())
 				if !r.cliConfig.KeepGoing {
 					opc.StopAndDrain()
 				}
@@ -150,20 +160,24 @@ OUTER_LOOP:
 				}
 			}
 
-			suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], r.suiteConfig, r.reporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
+			suiteConfig := r.suiteConfig
+			if focusSpecs := r.rerunFailedFilters.FocusSpecsFor(suites[suiteIdx]); focusSpecs != nil {
+				suiteConfig.FocusSpecs = focusSpecs
+			}
+			suites[suiteIdx] = internal.RunCompiledSuite(suites[suiteIdx], suiteConfig, r.reporterConfig, r.cliConfig, r.goFlagsConfig, additionalArgs)
 		}
 
 		if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
 			if iteration > 0 {
-				fmt.Printf("\nTests failed on attempt #%d\n\n", iteration+1)
+				fmt.Fprintf(out, "\nTests failed on attempt #%d\n\n", iteration+1)
 			}
 			break OUTER_LOOP
 		}
 
 		if r.cliConfig.UntilItFails {
-			fmt.Printf("\nAll tests passed...\nWill keep running them until they fail.\nThis was attempt #%d\n%s\n", iteration+1, orcMessage(iteration+1))
+			fmt.Fprintf(out, "\nAll tests passed...\nWill keep running them until they fail.\nThis was attempt #%d\n%s\n", iteration+1, orcMessage(iteration+1))
 		} else if r.cliConfig.Repeat > 0 && iteration < r.cliConfig.Repeat {
-			fmt.Printf("\nAll tests passed...\nThis was attempt %d of %d.\n", iteration+1, r.cliConfig.Repeat+1)
+			fmt.Fprintf(out, "\nAll tests passed...\nThis was attempt %d of %d.\n", iteration+1, r.cliConfig.Repeat+1)
 		} else {
 			break OUTER_LOOP
 		}
@@ -175,31 +189,43 @@ OUTER_LOOP:
 	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, r.cliConfig, r.suiteConfig, r.reporterConfig, r.goFlagsConfig)
 	command.AbortIfError("could not finalize profiles:", err)
 	for _, message := range messages {
-		fmt.Println(message)
+		fmt.Fprintln(out, message)
 	}
 
-	fmt.Printf("\nGinkgo ran %d %s in %s\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), time.Since(t))
+	fmt.Fprintf(out, "\nGinkgo ran %d %s in %s\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), time.Since(t))
 
 	if suites.CountWithState(internal.TestSuiteStateFailureStates...) == 0 {
 		if suites.AnyHaveProgrammaticFocus() && strings.TrimSpace(os.Getenv("GINKGO_EDITOR_INTEGRATION")) == "" {
-			fmt.Printf("Test Suite Passed\n")
-			fmt.Printf("Detected Programmatic Focus - setting exit status to %d\n", types.GINKGO_FOCUS_EXIT_CODE)
+			fmt.Fprintf(out, "Test Suite Passed\n")
+			fmt.Fprintf(out, "Detected Programmatic Focus - setting exit status to %d\n", types.GINKGO_FOCUS_EXIT_CODE)
 			command.Abort(command.AbortDetails{ExitCode: types.GINKGO_FOCUS_EXIT_CODE})
 		} else {
-			fmt.Printf("Test Suite Passed\n")
+			fmt.Fprintf(out, "Test Suite Passed\n")
 			command.Abort(command.AbortDetails{})
 		}
 	} else {
-		fmt.Fprintln(formatter.ColorableStdOut, "")
+		fmt.Fprintln(out, "")
 		if len(suites) > 1 && suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
-			fmt.Fprintln(formatter.ColorableStdOut,
+			fmt.Fprintln(out,
 				internal.FailedSuitesReport(suites, formatter.NewWithNoColorBool(r.reporterConfig.NoColor)))
 		}
-		fmt.Printf("Test Suite Failed\n")
+		fmt.Fprintf(out, "Test Suite Failed\n")
 		command.Abort(command.AbortDetails{ExitCode: 1})
 	}
 }
 
+func (r *SpecRunner) applyRerunFailed(suites internal.TestSuites) internal.TestSuites {
+	reports, err := reporters.ReadJSONReports(r.cliConfig.RerunFailed)
+	command.AbortIfError("Failed to load the report passed to --rerun-failed:", err)
+
+	r.rerunFailedFilters = internal.ComputeRerunFailedFilters(reports)
+	if r.rerunFailedFilters.IsEmpty() {
+		command.AbortGracefullyWith("No failures found in %s.  Nothing to rerun!", r.cliConfig.RerunFailed)
+	}
+
+	return r.rerunFailedFilters.ApplyToSuites(suites)
+}
+
 func orcMessage(iteration int) string {
 	if iteration < 10 {
 		return ""
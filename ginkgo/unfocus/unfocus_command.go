@@ -178,7 +178,7 @@ func scanForFocus(file *ast.File) (eliminations [][]int64) {
 
 func isFocus(name string) bool {
 	switch name {
-	case "FDescribe", "FContext", "FIt", "FDescribeTable", "FEntry", "FSpecify", "FWhen":
+	case "FDescribe", "FContext", "FIt", "FDescribeTable", "FEntry", "FSpecify", "FWhen", "FDescribeTableT", "FEntryT", "FItForAll":
 		return true
 	default:
 		return false
@@ -0,0 +1,159 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// POLLING_INTERVAL is how often the polling ChangeSource asks the watcher to rescan for changes
+var POLLING_INTERVAL = time.Second
+
+// DEBOUNCE_INTERVAL is how long the filesystem must be quiet before a batch of changes is delivered.  Editors often touch several files (and temporary files) for a single save.
+var DEBOUNCE_INTERVAL = 100 * time.Millisecond
+
+// MAX_DEBOUNCE_LATENCY bounds how long a batch of changes can be held back by a steady stream of filesystem events
+var MAX_DEBOUNCE_LATENCY = time.Second
+
+/*
+ChangeSet describes a batch of filesystem changes.
+
+Dirs contains the absolute paths of the directories in which something changed - including directories that have been created or removed.  When RescanAll is set the ChangeSource could not tell what changed and the watcher should look for changes everywhere.
+*/
+type ChangeSet struct {
+	Dirs      []string
+	RescanAll bool
+}
+
+func (c ChangeSet) IsEmpty() bool {
+	return len(c.Dirs) == 0 && !c.RescanAll
+}
+
+func (c ChangeSet) Merge(other ChangeSet) ChangeSet {
+	seen := map[string]bool{}
+	out := ChangeSet{RescanAll: c.RescanAll || other.RescanAll}
+	for _, dir := range append(c.Dirs, other.Dirs...) {
+		if !seen[dir] {
+			seen[dir] = true
+			out.Dirs = append(out.Dirs, dir)
+		}
+	}
+	sort.Strings(out.Dirs)
+	return out
+}
+
+/*
+A ChangeSource tells the SpecWatcher when, and where, it should look for changes.
+
+WatchRecursively watches dir and all the directories beneath it that could contain a test suite (including directories created later on).  Watch watches individual directories - typically the dependencies of the suites being watched.  Both may be called repeatedly with directories that are already being watched.
+*/
+type ChangeSource interface {
+	Changes() <-chan ChangeSet
+	WatchRecursively(dir string) error
+	Watch(dirs ...string) error
+	Close()
+}
+
+/*
+NewChangeSource returns a ChangeSource backed by filesystem notifications where they are available.  Otherwise it returns a ChangeSource that polls.
+*/
+func NewChangeSource() (ChangeSource, error) {
+	source, err := newNotifyChangeSource()
+	if err != nil {
+		return NewPollingChangeSource(), err
+	}
+	return source, nil
+}
+
+// pollingChangeSource asks the watcher to rescan everything every POLLING_INTERVAL
+type pollingChangeSource struct {
+	changes chan ChangeSet
+	done    chan any
+}
+
+func NewPollingChangeSource() ChangeSource {
+	p := &pollingChangeSource{
+		changes: make(chan ChangeSet),
+		done:    make(chan any),
+	}
+	go func() {
+		ticker := time.NewTicker(POLLING_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case p.changes <- ChangeSet{RescanAll: true}:
+				case <-p.done:
+					return
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return p
+}
+
+func (p *pollingChangeSource) Changes() <-chan ChangeSet     { return p.changes }
+func (p *pollingChangeSource) WatchRecursively(string) error { return nil }
+func (p *pollingChangeSource) Watch(...string) error         { return nil }
+func (p *pollingChangeSource) Close()                        { close(p.done) }
+
+/*
+debounceChanges merges the ChangeSets arriving on in and delivers them on out once no new changes have arrived for DEBOUNCE_INTERVAL (or MAX_DEBOUNCE_LATENCY has elapsed since the first of them).
+
+Changes keep accumulating while the receiver is busy (e.g. running suites) so that nothing is dropped and the receiver sees a single ChangeSet when it is ready.  out is closed once in is closed.
+*/
+func debounceChanges(in <-chan ChangeSet, out chan<- ChangeSet) {
+	defer close(out)
+	var pending ChangeSet
+	var quiet, deadline <-chan time.Time
+	var ready chan<- ChangeSet
+	for {
+		select {
+		case changes, ok := <-in:
+			if !ok {
+				return
+			}
+			if changes.IsEmpty() {
+				continue
+			}
+			pending = pending.Merge(changes)
+			if ready == nil {
+				quiet = time.After(DEBOUNCE_INTERVAL)
+				if deadline == nil {
+					deadline = time.After(MAX_DEBOUNCE_LATENCY)
+				}
+			}
+		case <-quiet:
+			quiet, deadline, ready = nil, nil, out
+		case <-deadline:
+			quiet, deadline, ready = nil, nil, out
+		case ready <- pending:
+			pending, ready = ChangeSet{}, nil
+		}
+	}
+}
+
+// isSuiteDirectory mirrors the rules Ginkgo uses when recursing for suites: it skips vendor directories and directories that begin with . or _
+func isSuiteDirectory(name string) bool {
+	return name != "vendor" && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "_")
+}
+
+// suiteDirectoriesIn returns dir along with every directory beneath it that could contain a suite
+func suiteDirectoriesIn(dir string) []string {
+	dirs := []string{dir}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return dirs
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && isSuiteDirectory(entry.Name()) {
+			dirs = append(dirs, suiteDirectoriesIn(filepath.Join(dir, entry.Name()))...)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,198 @@
+//go:build linux
+
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const notifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_ATTRIB | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF | unix.IN_ONLYDIR
+
+/*
+notifyChangeSource uses inotify to learn about changes as they happen.
+
+inotify watches are not recursive so notifyChangeSource adds a watch for each directory.  When a directory is created beneath a directory that is being watched recursively, it (and everything in it) is watched too.
+*/
+type notifyChangeSource struct {
+	file *os.File
+	fd   int
+
+	lock      *sync.Mutex
+	dirs      map[int]string
+	watches   map[string]int
+	recursive map[string]bool
+
+	events  chan ChangeSet
+	changes chan ChangeSet
+}
+
+func newNotifyChangeSource() (ChangeSource, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	n := &notifyChangeSource{
+		// a non-blocking file descriptor lets the runtime poll for events and allows Close to interrupt a pending Read
+		file:      os.NewFile(uintptr(fd), "inotify"),
+		fd:        fd,
+		lock:      &sync.Mutex{},
+		dirs:      map[int]string{},
+		watches:   map[string]int{},
+		recursive: map[string]bool{},
+		events:    make(chan ChangeSet),
+		changes:   make(chan ChangeSet),
+	}
+	go n.readEvents()
+	go debounceChanges(n.events, n.changes)
+	return n, nil
+}
+
+func (n *notifyChangeSource) Changes() <-chan ChangeSet {
+	return n.changes
+}
+
+func (n *notifyChangeSource) WatchRecursively(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	_, err = n.watchRecursively(dir)
+	return err
+}
+
+func (n *notifyChangeSource) Watch(dirs ...string) error {
+	for _, dir := range dirs {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		if err := n.watch(dir, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *notifyChangeSource) Close() {
+	n.file.Close()
+}
+
+// watchRecursively watches dir and the suite directories beneath it.  It returns the directories it found.
+func (n *notifyChangeSource) watchRecursively(dir string) ([]string, error) {
+	dirs := suiteDirectoriesIn(dir)
+	for _, dir := range dirs {
+		if err := n.watch(dir, true); err != nil {
+			return dirs, err
+		}
+	}
+	return dirs, nil
+}
+
+func (n *notifyChangeSource) watch(dir string, recursive bool) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if recursive {
+		n.recursive[dir] = true
+	}
+	if _, ok := n.watches[dir]; ok {
+		return nil
+	}
+	wd, err := unix.InotifyAddWatch(n.fd, dir, notifyMask)
+	if errors.Is(err, unix.ENOENT) || errors.Is(err, unix.ENOTDIR) {
+		// the directory is gone - there's nothing to watch
+		return nil
+	}
+	if err != nil {
+		return os.NewSyscallError("inotify_add_watch", err)
+	}
+	n.dirs[wd] = dir
+	n.watches[dir] = wd
+	return nil
+}
+
+func (n *notifyChangeSource) forget(wd int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	dir, ok := n.dirs[wd]
+	if !ok {
+		return
+	}
+	delete(n.dirs, wd)
+	delete(n.watches, dir)
+	delete(n.recursive, dir)
+}
+
+func (n *notifyChangeSource) lookup(wd int) (string, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	dir, ok := n.dirs[wd]
+	return dir, ok && n.recursive[dir]
+}
+
+func (n *notifyChangeSource) readEvents() {
+	defer close(n.events)
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+		n.events <- n.changeSetForEvents(buf[:count])
+	}
+}
+
+func (n *notifyChangeSource) changeSetForEvents(buf []byte) ChangeSet {
+	changes := ChangeSet{}
+	for offset := 0; offset+unix.SizeofInotifyEvent <= len(buf); {
+		event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+		nameStart := offset + unix.SizeofInotifyEvent
+		nameEnd := nameStart + int(event.Len)
+		offset = nameEnd
+		if nameEnd > len(buf) {
+			break
+		}
+		name := string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00"))
+
+		if event.Mask&unix.IN_Q_OVERFLOW != 0 {
+			// the kernel dropped events - we no longer know what has changed
+			changes.RescanAll = true
+			continue
+		}
+		if event.Mask&unix.IN_IGNORED != 0 {
+			n.forget(int(event.Wd))
+			continue
+		}
+
+		dir, recursive := n.lookup(int(event.Wd))
+		if dir == "" {
+			continue
+		}
+		changes.Dirs = append(changes.Dirs, dir)
+
+		if event.Mask&unix.IN_ISDIR == 0 || name == "" {
+			continue
+		}
+		child := filepath.Join(dir, name)
+		switch {
+		case event.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+			if recursive && isSuiteDirectory(name) {
+				// files may have landed in the new directory before we started watching it, so we report all of it as changed
+				newDirs, err := n.watchRecursively(child)
+				changes.Dirs = append(changes.Dirs, newDirs...)
+				if err != nil {
+					changes.RescanAll = true
+				}
+			}
+		case event.Mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0:
+			changes.Dirs = append(changes.Dirs, child)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+func newNotifyChangeSource() (ChangeSource, error) {
+	return nil, errors.New("filesystem notifications are not supported on this platform")
+}
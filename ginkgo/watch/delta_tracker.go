@@ -27,8 +27,22 @@ func NewDeltaTracker(maxDepth int, watchRegExp *regexp.Regexp) *DeltaTracker {
 }
 
 func (d *DeltaTracker) Delta(suites internal.TestSuites) (delta Delta, errors SuiteErrors) {
+	return d.delta(suites, d.packageHashes.CheckForChanges())
+}
+
+// DeltaForChangedDirectories is like Delta but only looks for changes in the packages in dirs (absolute paths) - it's used when a ChangeSource has told us where to look
+func (d *DeltaTracker) DeltaForChangedDirectories(suites internal.TestSuites, dirs []string) (delta Delta, errors SuiteErrors) {
+	return d.delta(suites, d.packageHashes.CheckForChangesIn(dirs))
+}
+
+// WatchedPaths returns the absolute paths of the suites and dependencies being tracked
+func (d *DeltaTracker) WatchedPaths() []string {
+	return d.packageHashes.Paths()
+}
+
+func (d *DeltaTracker) delta(suites internal.TestSuites, modifiedPackages []string) (delta Delta, errors SuiteErrors) {
 	errors = SuiteErrors{}
-	delta.ModifiedPackages = d.packageHashes.CheckForChanges()
+	delta.ModifiedPackages = modifiedPackages
 
 	providedSuitePaths := map[string]bool{}
 	for _, suite := range suites {
@@ -44,6 +58,7 @@ func (d *DeltaTracker) Delta(suites internal.TestSuites) (delta Delta, errors Su
 			}
 		} else {
 			delta.RemovedSuites = append(delta.RemovedSuites, suite)
+			delete(d.suites, suite.Suite.Path)
 		}
 	}
 
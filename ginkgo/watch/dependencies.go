@@ -6,7 +6,7 @@ import (
 )
 
 var ginkgoAndGomegaFilter = regexp.MustCompile(`github\.com/onsi/ginkgo|github\.com/onsi/gomega`)
-var ginkgoIntegrationTestFilter = regexp.MustCompile(`github\.com/onsi/ginkgo/integration`) //allow us to integration test this thing
+var ginkgoIntegrationTestFilter = regexp.MustCompile(`github\.com/onsi/ginkgo(/v2)?/integration`) //allow us to integration test this thing
 
 type Dependencies struct {
 	deps map[string]int
@@ -0,0 +1,419 @@
+package watch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+	"golang.org/x/tools/cover"
+)
+
+// each spec's coverage is recorded in a separate run of the suite that writes this cover profile
+const impactCoverProfileName = "ginkgo-watch-impact.coverprofile"
+
+/*
+An impactMap records the functions each spec in a suite covered when it last ran, along with a snapshot of the source files in the packages the suite was instrumented to cover.
+
+When a suite's packages change, the current source is compared against the snapshot to find the functions that changed.  Only the specs that covered those functions need to rerun.  Changes the map can't attribute to functions (changes to the suite's tests, to package-level declarations, or to packages that weren't instrumented) leave the map stale and the entire suite reruns.
+*/
+type impactMap struct {
+	filters     string
+	watchRegExp *regexp.Regexp
+	packages    map[string]string
+	files       map[string]sourceSnapshot
+	specs       map[string]specImpact
+}
+
+// specImpact captures the functions a spec covered.  Specs whose coverage could not be recorded are always impacted.
+type specImpact struct {
+	functions map[coveredFunction]bool
+	unknown   bool
+}
+
+type coveredFunction struct {
+	file     string
+	function string
+}
+
+// sourceSnapshot captures hashes of each function in a source file and of everything else in the file.  Watched files that aren't Go source only have declarations.
+type sourceSnapshot struct {
+	isGo         bool
+	isTest       bool
+	declarations string
+	functions    map[string]string
+	lines        []functionLines
+}
+
+type functionLines struct {
+	function   string
+	start, end int
+}
+
+// impactPlan describes how a modified suite should be rerun
+type impactPlan struct {
+	stale      string
+	focusSpecs []string
+	numSpecs   int
+}
+
+func (p impactPlan) description() string {
+	if p.stale != "" {
+		return fmt.Sprintf("all specs (%s)", p.stale)
+	}
+	return fmt.Sprintf("%d of %d %s impacted", len(p.focusSpecs), p.numSpecs, internal.PluralizedWord("spec", "specs", p.numSpecs))
+}
+
+func filtersFingerprint(suiteConfig types.SuiteConfig, additionalArgs []string) string {
+	return fmt.Sprintf("%q %q %q %q %q %q %q %q %q", suiteConfig.FocusStrings, suiteConfig.SkipStrings, suiteConfig.FocusFiles, suiteConfig.SkipFiles, suiteConfig.FocusSpecs, suiteConfig.LabelFilter, suiteConfig.SemVerFilter, suiteConfig.Shard, additionalArgs)
+}
+
+/*
+coveredPackages returns the packages in the main module that the suite depends on, keyed by import path.  These are the packages the suite is instrumented to cover.  As with Dependencies, Ginkgo and Gomega are left out.
+*/
+func coveredPackages(suite internal.TestSuite) (map[string]string, error) {
+	cmd := exec.Command("go", "list", "-e", "-deps", "-test", "-f", "{{if and .Module .Module.Main (not .Error)}}{{.ImportPath}}\t{{.Name}}\t{{.Dir}}{{end}}", ".")
+	cmd.Dir = suite.Path
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list the packages %s depends on: %w", suite.PackageName, err)
+	}
+	packages := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		components := strings.Split(line, "\t")
+		if len(components) != 3 {
+			continue
+		}
+		importPath, name, dir := strings.Split(components[0], " ")[0], components[1], components[2]
+		if name == "main" || strings.HasSuffix(name, "_test") || strings.HasSuffix(importPath, ".test") {
+			continue
+		}
+		if ginkgoAndGomegaFilter.MatchString(importPath) && !ginkgoIntegrationTestFilter.MatchString(importPath) {
+			continue
+		}
+		packages[importPath] = dir
+	}
+	return packages, nil
+}
+
+// coverPkg returns the -coverpkg flag value that instruments the passed-in packages
+func coverPkg(packages map[string]string) string {
+	importPaths := []string{}
+	for importPath := range packages {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	return strings.Join(importPaths, ",")
+}
+
+/*
+newImpactMap snapshots the watched files in the suite's directory and in each of the covered packages.  Spec coverage is added with recordSpecs.
+*/
+func newImpactMap(suite internal.TestSuite, packages map[string]string, filters string, watchRegExp *regexp.Regexp) *impactMap {
+	m := &impactMap{
+		filters:     filters,
+		watchRegExp: watchRegExp,
+		packages:    packages,
+		specs:       map[string]specImpact{},
+	}
+	m.files = m.snapshotSources(suite)
+	return m
+}
+
+func (m *impactMap) dirs(suite internal.TestSuite) []string {
+	dirs := []string{suite.AbsPath()}
+	for _, dir := range m.packages {
+		if dir != suite.AbsPath() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func (m *impactMap) snapshotSources(suite internal.TestSuite) map[string]sourceSnapshot {
+	files := map[string]sourceSnapshot{}
+	for _, dir := range m.dirs(suite) {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if entry.IsDir() || !m.watchRegExp.MatchString(path) {
+				continue
+			}
+			// tests in other packages don't affect the suite
+			if strings.HasSuffix(path, "_test.go") && dir != suite.AbsPath() {
+				continue
+			}
+			var snapshot sourceSnapshot
+			var err error
+			if strings.HasSuffix(path, ".go") {
+				snapshot, err = snapshotSource(path)
+			} else {
+				var content []byte
+				content, err = os.ReadFile(path)
+				snapshot.declarations = hash(content)
+			}
+			if err != nil {
+				// we record a snapshot that won't match anything so that the map is stale until the file can be read
+				snapshot = sourceSnapshot{declarations: err.Error()}
+			}
+			files[path] = snapshot
+		}
+	}
+	return files
+}
+
+func snapshotSource(path string) (sourceSnapshot, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return sourceSnapshot{}, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, 0)
+	if err != nil {
+		return sourceSnapshot{}, err
+	}
+
+	snapshot := sourceSnapshot{
+		isGo:      true,
+		isTest:    strings.HasSuffix(path, "_test.go"),
+		functions: map[string]string{},
+	}
+	declarations := &bytes.Buffer{}
+	fmt.Fprintln(declarations, "package", file.Name.Name)
+	occurrences := map[string]int{}
+	for _, decl := range file.Decls {
+		funcDecl, isFunc := decl.(*ast.FuncDecl)
+		if !isFunc {
+			printer.Fprint(declarations, fset, decl)
+			fmt.Fprintln(declarations)
+			continue
+		}
+		function := functionName(funcDecl)
+		occurrences[function] += 1
+		if function == "init" || function == "_" {
+			function = fmt.Sprintf("%s#%d", function, occurrences[function])
+		}
+		start, end := fset.Position(funcDecl.Pos()), fset.Position(funcDecl.End())
+		snapshot.functions[function] = hash(src[start.Offset:end.Offset])
+		snapshot.lines = append(snapshot.lines, functionLines{function: function, start: start.Line, end: end.Line})
+	}
+	snapshot.declarations = hash(declarations.Bytes())
+	return snapshot, nil
+}
+
+func functionName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	recv := funcDecl.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + funcDecl.Name.Name
+		}
+		return funcDecl.Name.Name
+	}
+}
+
+func hash(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// function returns the function in the snapshot that contains line.  Lines outside of any function belong to the file's declarations, which are identified by the empty string.
+func (s sourceSnapshot) function(line int) string {
+	for _, lines := range s.lines {
+		if lines.start <= line && line <= lines.end {
+			return lines.function
+		}
+	}
+	return ""
+}
+
+/*
+recordSpecs runs each of the passed-in specs on its own, with coverage enabled, and records the functions it covered.  The suite must have been compiled with coverage for the map's packages.
+
+Since each spec runs in a separate process, the suite's BeforeSuite and AfterSuite run once per spec.  Specs whose run fails (or fails to start) are marked unknown as their coverage may be incomplete - they rerun after any change.
+*/
+func (m *impactMap) recordSpecs(suite internal.TestSuite, focusSpecs []string, suiteConfig types.SuiteConfig, goFlagsConfig types.GoFlagsConfig, cliConfig types.CLIConfig, additionalArgs []string) {
+	coverProfile := internal.AbsPathForGeneratedAsset(impactCoverProfileName, suite, cliConfig, 0)
+	defer os.Remove(coverProfile)
+
+	for _, focusSpec := range focusSpecs {
+		os.Remove(coverProfile)
+		specConfig := suiteConfig
+		specConfig.FocusSpecs = []string{focusSpec}
+		specConfig.ParallelProcess, specConfig.ParallelTotal, specConfig.ParallelHost = 1, 1, ""
+		specGoFlagsConfig := types.GoFlagsConfig{Cover: true, CoverProfile: coverProfile}
+		args, err := types.GenerateGinkgoTestRunArgs(specConfig, types.ReporterConfig{NoColor: true, Succinct: true}, specGoFlagsConfig)
+		if err != nil {
+			m.specs[focusSpec] = specImpact{unknown: true}
+			continue
+		}
+		args = append([]string{"--test.timeout=0"}, args...)
+		args = append(args, additionalArgs...)
+
+		cmd := exec.Command(suite.PathToCompiledTest, args...)
+		cmd.Dir = suite.Path
+		if err := cmd.Run(); err != nil {
+			m.specs[focusSpec] = specImpact{unknown: true}
+			continue
+		}
+
+		m.specs[focusSpec] = m.specImpactFromCoverProfile(coverProfile)
+	}
+}
+
+func (m *impactMap) specImpactFromCoverProfile(coverProfile string) specImpact {
+	profiles, err := cover.ParseProfiles(coverProfile)
+	if err != nil {
+		return specImpact{unknown: true}
+	}
+	impact := specImpact{functions: map[coveredFunction]bool{}}
+	for _, profile := range profiles {
+		dir, ok := m.packages[path.Dir(profile.FileName)]
+		if !ok {
+			continue
+		}
+		file := filepath.Join(dir, path.Base(profile.FileName))
+		snapshot := m.files[file]
+		for _, block := range profile.Blocks {
+			if block.Count > 0 {
+				impact.functions[coveredFunction{file: file, function: snapshot.function(block.StartLine)}] = true
+			}
+		}
+	}
+	return impact
+}
+
+/*
+plan compares the current source against the map's snapshot and computes the --focus-spec filters that will rerun just the impacted specs.  If the changes can't be attributed to functions the plan is stale and the entire suite should rerun.
+*/
+func (m *impactMap) plan(suite *Suite, modifiedPackages []string, filters string) impactPlan {
+	plan := impactPlan{numSpecs: len(m.specs)}
+	if m.filters != filters {
+		plan.stale = "filters have changed"
+		return plan
+	}
+	dependencies := suite.Dependencies.Dependencies()
+	for _, dir := range modifiedPackages {
+		if _, isDependency := dependencies[dir]; isDependency && !m.covers(suite.Suite, dir) {
+			plan.stale = fmt.Sprintf("%s is not instrumented", dir)
+			return plan
+		}
+	}
+
+	current := m.snapshotSources(suite.Suite)
+	changed := map[coveredFunction]bool{}
+	for file, snapshot := range current {
+		previous, ok := m.files[file]
+		if !ok {
+			plan.stale = fmt.Sprintf("%s was added", filepath.Base(file))
+			return plan
+		}
+		// the suite's own tests aren't instrumented, so any change to them leaves the map stale
+		if snapshot.declarations != previous.declarations && snapshot.isGo && !snapshot.isTest {
+			plan.stale = fmt.Sprintf("%s changed outside of a function", filepath.Base(file))
+			return plan
+		}
+		if snapshot.declarations != previous.declarations || (snapshot.isTest && !snapshot.sameFunctionsAs(previous)) {
+			plan.stale = fmt.Sprintf("%s changed", filepath.Base(file))
+			return plan
+		}
+		for function, h := range snapshot.functions {
+			if previous.functions[function] != h {
+				changed[coveredFunction{file: file, function: function}] = true
+			}
+		}
+		for function := range previous.functions {
+			if _, ok := snapshot.functions[function]; !ok {
+				changed[coveredFunction{file: file, function: function}] = true
+			}
+		}
+	}
+	for file := range m.files {
+		if _, ok := current[file]; !ok {
+			plan.stale = fmt.Sprintf("%s was removed", filepath.Base(file))
+			return plan
+		}
+	}
+
+	for focusSpec, impact := range m.specs {
+		if impact.unknown {
+			plan.focusSpecs = append(plan.focusSpecs, focusSpec)
+			continue
+		}
+		for function := range impact.functions {
+			if changed[function] {
+				plan.focusSpecs = append(plan.focusSpecs, focusSpec)
+				break
+			}
+		}
+	}
+	sort.Strings(plan.focusSpecs)
+	return plan
+}
+
+func (s sourceSnapshot) sameFunctionsAs(other sourceSnapshot) bool {
+	if len(s.functions) != len(other.functions) {
+		return false
+	}
+	for function, h := range s.functions {
+		if other.functions[function] != h {
+			return false
+		}
+	}
+	return true
+}
+
+// covers returns true if dir is one of the directories the map snapshots
+func (m *impactMap) covers(suite internal.TestSuite, dir string) bool {
+	for _, d := range m.dirs(suite) {
+		if d == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// update refreshes the snapshot after a run
+func (m *impactMap) update(suite internal.TestSuite) {
+	m.files = m.snapshotSources(suite)
+}
+
+// focusSpecsForSpecs returns a --focus-spec filter for each spec in the report that ran
+func focusSpecsForSpecs(report types.Report) []string {
+	seen := map[string]bool{}
+	focusSpecs := []string{}
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt || spec.State.Is(types.SpecStateSkipped|types.SpecStatePending) {
+			continue
+		}
+		focusSpec := types.SpecIdentityFor(spec).String()
+		if !seen[focusSpec] {
+			seen[focusSpec] = true
+			focusSpecs = append(focusSpecs, focusSpec)
+		}
+	}
+	return focusSpecs
+}
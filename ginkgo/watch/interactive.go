@@ -0,0 +1,247 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type keyAction uint
+
+const (
+	keyActionNone keyAction = iota
+	keyActionRunAll
+	keyActionRunFailed
+	keyActionQuit
+)
+
+/*
+keyboard delivers the keys typed at stdin, one at a time.
+
+When stdin is a terminal it is placed in cbreak mode so keys arrive as soon as they are typed.  Otherwise (e.g. when stdin is a pipe) keys are delivered as they arrive.  The keys channel is closed when stdin is closed.
+*/
+type keyboard struct {
+	keys    chan byte
+	restore func()
+}
+
+func newKeyboard(in *os.File) *keyboard {
+	restore, err := enableCbreakMode(int(in.Fd()))
+	if err != nil {
+		restore = func() {}
+	}
+	k := &keyboard{
+		keys:    make(chan byte),
+		restore: restore,
+	}
+	go func() {
+		defer close(k.keys)
+		buf := make([]byte, 1)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			if n == 1 {
+				k.keys <- buf[0]
+			}
+		}
+	}()
+	return k
+}
+
+/*
+readLine prompts for a line of input, echoing what is typed.  It returns false if the user cancels by pressing escape or if Ginkgo is interrupted.
+*/
+func (k *keyboard) readLine(prompt string, interrupted chan any) (string, bool) {
+	fmt.Print(prompt)
+	line := []byte{}
+	for {
+		select {
+		case key, ok := <-k.keys:
+			if !ok {
+				fmt.Println("")
+				return "", false
+			}
+			switch key {
+			case '\r', '\n':
+				fmt.Println("")
+				return string(line), true
+			case 0x1b:
+				fmt.Println("")
+				return "", false
+			case 0x7f, '\b':
+				if len(line) > 0 {
+					_, size := utf8.DecodeLastRune(line)
+					line = line[:len(line)-size]
+					fmt.Print("\b \b")
+				}
+			default:
+				line = append(line, key)
+				os.Stdout.Write([]byte{key})
+			}
+		case <-interrupted:
+			fmt.Println("")
+			return "", false
+		}
+	}
+}
+
+func (w *SpecWatcher) handleKey(key byte, keyboard *keyboard) keyAction {
+	interrupted := w.interruptHandler.Status().Channel
+	switch key {
+	case 'a':
+		return keyActionRunAll
+	case 'f':
+		return keyActionRunFailed
+	case '/':
+		focus, ok := keyboard.readLine(w.formatter().F("{{bold}}Focus{{/}} (a regular expression - leave empty to clear): "), interrupted)
+		if !ok {
+			break
+		}
+		if focus == "" {
+			w.suiteConfig.FocusStrings = []string{}
+		} else if _, err := regexp.Compile(focus); err != nil {
+			fmt.Fprintln(formatter.ColorableStdOut, w.formatter().F("{{red}}Invalid focus: %s{{/}}", err.Error()))
+			break
+		} else {
+			w.suiteConfig.FocusStrings = []string{focus}
+		}
+		w.printStatus()
+	case 'l':
+		labelFilter, ok := keyboard.readLine(w.formatter().F("{{bold}}Label Filter{{/}} (leave empty to clear): "), interrupted)
+		if !ok {
+			break
+		}
+		if _, err := types.ParseLabelFilter(labelFilter); err != nil {
+			fmt.Fprintln(formatter.ColorableStdOut, w.formatter().F("{{red}}Invalid label filter: %s{{/}}", err.Error()))
+			break
+		}
+		w.suiteConfig.LabelFilter = labelFilter
+		w.printStatus()
+	case 'v':
+		switch {
+		case w.reporterConfig.VeryVerbose:
+			w.reporterConfig.Verbose, w.reporterConfig.VeryVerbose, w.reporterConfig.Succinct = false, false, w.succinct
+		case w.reporterConfig.Verbose:
+			w.reporterConfig.Verbose, w.reporterConfig.VeryVerbose, w.reporterConfig.Succinct = false, true, false
+		default:
+			w.reporterConfig.Verbose, w.reporterConfig.VeryVerbose, w.reporterConfig.Succinct = true, false, false
+		}
+		w.printStatus()
+	case 's':
+		seed, ok := keyboard.readLine(w.formatter().F("{{bold}}Seed{{/}} (leave empty to pick a new seed for every run): "), interrupted)
+		if !ok {
+			break
+		}
+		if seed == "" {
+			w.seedPinned = false
+		} else if parsed, err := strconv.ParseInt(seed, 10, 64); err != nil {
+			fmt.Fprintln(formatter.ColorableStdOut, w.formatter().F("{{red}}Invalid seed: %s{{/}}", seed))
+			break
+		} else {
+			w.suiteConfig.RandomSeed = parsed
+			w.seedPinned = true
+		}
+		w.printStatus()
+	case 'q':
+		return keyActionQuit
+	case '?', 'h':
+		w.printHelp()
+	}
+	return keyActionNone
+}
+
+func (w *SpecWatcher) formatter() formatter.Formatter {
+	return formatter.NewWithNoColorBool(w.reporterConfig.NoColor)
+}
+
+func (w *SpecWatcher) printHelp() {
+	coloredStream := formatter.ColorableStdOut
+	fmt.Fprintln(coloredStream, w.formatter().F("{{bold}}Ginkgo Watch Commands:{{/}}"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}a{{/}} rerun all watched suites"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}f{{/}} rerun only the specs that failed"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}/{{/}} change the focus (--focus)"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}l{{/}} change the label filter (--label-filter)"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}v{{/}} toggle verbosity (default, -v, -vv)"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}s{{/}} pick a new seed (--seed)"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}q{{/}} quit"))
+	fmt.Fprintln(coloredStream, w.formatter().Fi(1, "{{bold}}?{{/}} show this help"))
+}
+
+// printStatus prints a status line summarizing the current filters and settings
+func (w *SpecWatcher) printStatus() {
+	focus := "none"
+	if len(w.suiteConfig.FocusStrings) > 0 {
+		focus = strings.Join(w.suiteConfig.FocusStrings, ", ")
+	}
+	labelFilter := "none"
+	if w.suiteConfig.LabelFilter != "" {
+		labelFilter = w.suiteConfig.LabelFilter
+	}
+	verbosity := "default"
+	if w.reporterConfig.VeryVerbose {
+		verbosity = "very verbose"
+	} else if w.reporterConfig.Verbose {
+		verbosity = "verbose"
+	}
+	seed := "new seed for every run"
+	if w.seedPinned {
+		seed = fmt.Sprintf("%d", w.suiteConfig.RandomSeed)
+	}
+	fmt.Fprintln(formatter.ColorableStdOut, w.formatter().F("{{gray}}Focus: {{/}}%s {{gray}}| Label Filter: {{/}}%s {{gray}}| Verbosity: {{/}}%s {{gray}}| Seed: {{/}}%s {{gray}}| Press {{/}}{{bold}}?{{/}}{{gray}} for help{{/}}", focus, labelFilter, verbosity, seed))
+}
+
+/*
+recordFailures remembers the specs that failed when suite last ran so that they can be rerun with `f`.  Suites that did not produce a report (e.g. because they failed to compile or are not Ginkgo suites) are rerun in their entirety.
+*/
+func (w *SpecWatcher) recordFailures(suite internal.TestSuite, report *types.Report) {
+	delete(w.failures, suite.Path)
+	if !suite.State.Is(internal.TestSuiteStateFailureStates...) {
+		return
+	}
+
+	failure := watchFailure{
+		suite:  internal.TestSuite{Path: suite.Path, PackageName: suite.PackageName, IsGinkgo: suite.IsGinkgo, State: internal.TestSuiteStateUncompiled},
+		report: types.Report{SuitePath: suite.AbsPath()},
+	}
+	if report != nil {
+		failure.report = *report
+	}
+	w.failures[suite.Path] = failure
+}
+
+type watchFailure struct {
+	suite  internal.TestSuite
+	report types.Report
+}
+
+// failedSuitesAndFilters returns the suites with failures along with the --focus-spec filters, keyed by suite path, that will rerun just the failed specs
+func (w *SpecWatcher) failedSuitesAndFilters() (internal.TestSuites, map[string][]string) {
+	paths := []string{}
+	for path := range w.failures {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	suites := internal.TestSuites{}
+	reports := []types.Report{}
+	for _, path := range paths {
+		suites = append(suites, w.failures[path].suite)
+		reports = append(reports, w.failures[path].report)
+	}
+	filters := internal.ComputeRerunFailedFilters(reports)
+	focusSpecs := map[string][]string{}
+	for _, suite := range suites {
+		focusSpecs[suite.Path] = filters.FocusSpecsFor(suite)
+	}
+	return suites, focusSpecs
+}
@@ -37,6 +37,34 @@ func (p *PackageHashes) CheckForChanges() []string {
 	return modified
 }
 
+// CheckForChangesIn only checks the packages at the passed-in absolute paths
+func (p *PackageHashes) CheckForChangesIn(paths []string) []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	modified := []string{}
+
+	for _, path := range paths {
+		packageHash, ok := p.PackageHashes[path]
+		if ok && packageHash.CheckForChanges() {
+			modified = append(modified, packageHash.path)
+		}
+	}
+
+	return modified
+}
+
+func (p *PackageHashes) Paths() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	paths := []string{}
+	for path := range p.PackageHashes {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
 func (p *PackageHashes) Add(path string) *PackageHash {
 	p.lock.Lock()
 	defer p.lock.Unlock()
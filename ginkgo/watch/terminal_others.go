@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package watch
+
+import "errors"
+
+func enableCbreakMode(fd int) (func(), error) {
+	return nil, errors.New("interactive terminals are not supported on this platform")
+}
@@ -0,0 +1,29 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package watch
+
+import "golang.org/x/sys/unix"
+
+/*
+enableCbreakMode configures the terminal at fd to deliver keystrokes as soon as they are typed, without echoing them.  Signals are left alone so ^C still interrupts Ginkgo.
+
+The returned function restores the terminal's original settings.  An error is returned if fd is not a terminal.
+*/
+func enableCbreakMode(fd int) (func(), error) {
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	original := *termios
+
+	termios.Lflag &^= unix.ICANON | unix.ECHO
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, termios); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		unix.IoctlSetTermios(fd, ioctlSetTermios, &original)
+	}, nil
+}
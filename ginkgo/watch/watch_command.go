@@ -2,16 +2,23 @@ package watch
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/onsi/ginkgo/v2/ginkgo/command"
 	"github.com/onsi/ginkgo/v2/ginkgo/internal"
 	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
+// in interactive mode, and when analyzing impact, ginkgo watch asks each suite for a JSON report (unless the user has asked for one) so that it can tell which specs ran and which failed
+const watchReportName = "ginkgo-watch-report.json"
+
 func BuildWatchCommand() command.Command {
 	var suiteConfig = types.NewDefaultSuiteConfig()
 	var reporterConfig = types.NewDefaultReporterConfig()
@@ -60,9 +67,21 @@ type SpecWatcher struct {
 	flags          types.GinkgoFlagSet
 
 	interruptHandler *interrupt_handler.InterruptHandler
+
+	seedPinned  bool
+	succinct    bool
+	failures    map[string]watchFailure
+	watchRegExp *regexp.Regexp
+	impactMaps  map[string]*impactMap
 }
 
 func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
+	w.seedPinned = w.flags.WasSet("seed")
+	w.succinct = w.reporterConfig.Succinct
+	w.failures = map[string]watchFailure{}
+	w.watchRegExp = regexp.MustCompile(w.cliConfig.WatchRegExp)
+	w.impactMaps = map[string]*impactMap{}
+
 	suites := internal.FindSuites(args, w.cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
 
 	internal.VerifyCLIAndFrameworkVersion(suites)
@@ -72,7 +91,7 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 	}
 
 	fmt.Printf("Identified %d test %s.  Locating dependencies to a depth of %d (this may take a while)...\n", len(suites), internal.PluralizedWord("suite", "suites", len(suites)), w.cliConfig.Depth)
-	deltaTracker := NewDeltaTracker(w.cliConfig.Depth, regexp.MustCompile(w.cliConfig.WatchRegExp))
+	deltaTracker := NewDeltaTracker(w.cliConfig.Depth, w.watchRegExp)
 	delta, errors := deltaTracker.Delta(suites)
 
 	fmt.Printf("Watching %d %s:\n", len(delta.NewSuites), internal.PluralizedWord("suite", "suites", len(delta.NewSuites)))
@@ -84,88 +103,294 @@ func (w *SpecWatcher) WatchSpecs(args []string, additionalArgs []string) {
 		fmt.Printf("Failed to watch %s: %s\n", suite.PackageName, err)
 	}
 
+	changeSource, err := NewChangeSource()
+	if err != nil {
+		fmt.Printf("Could not watch for filesystem notifications (%s).  Polling for changes instead.\n", err)
+	}
+	defer func() { changeSource.Close() }()
+
+	roots := watchRootsFor(args, w.cliConfig)
+	for _, root := range roots {
+		if root.recursive {
+			err = changeSource.WatchRecursively(root.dir)
+		} else {
+			err = changeSource.Watch(root.dir)
+		}
+		if err != nil {
+			changeSource = fallBackToPolling(changeSource, err)
+			break
+		}
+	}
+	changeSource = watchTrackedPaths(changeSource, deltaTracker)
+
+	var input *keyboard
+	var keys <-chan byte
+	if w.cliConfig.Interactive {
+		input = newKeyboard(os.Stdin)
+		defer input.restore()
+		keys = input.keys
+	}
+
 	if len(suites) == 1 {
 		w.updateSeed()
-		w.compileAndRun(suites[0], additionalArgs)
+		w.compileAndRun(suites[0], w.suiteConfig, additionalArgs)
+	}
+	if w.cliConfig.Interactive {
+		w.printStatus()
 	}
-
-	ticker := time.NewTicker(time.Second)
 
 	for {
 		select {
-		case <-ticker.C:
-			suites := internal.FindSuites(args, w.cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
-			delta, _ := deltaTracker.Delta(suites)
+		case changes, ok := <-changeSource.Changes():
+			if !ok {
+				changeSource = fallBackToPolling(changeSource, fmt.Errorf("stopped receiving filesystem notifications"))
+				break
+			}
+			if changes.RescanAll {
+				suites = internal.FindSuites(args, w.cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)
+				delta, _ = deltaTracker.Delta(suites)
+			} else {
+				suites = w.updateSuites(suites, roots, changes.Dirs)
+				delta, _ = deltaTracker.DeltaForChangedDirectories(suites, changes.Dirs)
+			}
+			changeSource = watchTrackedPaths(changeSource, deltaTracker)
 			coloredStream := formatter.ColorableStdOut
 
-			suites = internal.TestSuites{}
+			suitesToRun := internal.TestSuites{}
+
+			if len(delta.RemovedSuites) > 0 {
+				fmt.Fprintln(coloredStream, formatter.F("{{orange}}Stopped watching %d removed %s:{{/}}", len(delta.RemovedSuites), internal.PluralizedWord("suite", "suites", len(delta.RemovedSuites))))
+				for _, suite := range delta.RemovedSuites {
+					fmt.Fprintln(coloredStream, formatter.Fi(1, "%s", suite.Suite.Path))
+				}
+			}
 
 			if len(delta.NewSuites) > 0 {
 				fmt.Fprintln(coloredStream, formatter.F("{{green}}Detected %d new %s:{{/}}", len(delta.NewSuites), internal.PluralizedWord("suite", "suites", len(delta.NewSuites))))
 				for _, suite := range delta.NewSuites {
-					suites = append(suites, suite.Suite)
+					suitesToRun = append(suitesToRun, suite.Suite)
 					fmt.Fprintln(coloredStream, formatter.Fi(1, "%s", suite.Description()))
 				}
 			}
 
 			modifiedSuites := delta.ModifiedSuites()
+			impactFocusSpecs := map[string][]string{}
 			if len(modifiedSuites) > 0 {
 				fmt.Fprintln(coloredStream, formatter.F("{{green}}Detected changes in:{{/}}"))
 				for _, pkg := range delta.ModifiedPackages {
 					fmt.Fprintln(coloredStream, formatter.Fi(1, "%s", pkg))
 				}
-				fmt.Fprintln(coloredStream, formatter.F("{{green}}Will run %d %s:{{/}}", len(modifiedSuites), internal.PluralizedWord("suite", "suites", len(modifiedSuites))))
+
+				descriptions := map[string]string{}
+				suitesWithImpact := []*Suite{}
 				for _, suite := range modifiedSuites {
-					suites = append(suites, suite.Suite)
-					fmt.Fprintln(coloredStream, formatter.Fi(1, "%s", suite.Description()))
+					descriptions[suite.Suite.Path] = suite.Description()
+					plan, ok := w.impactPlan(suite, delta.ModifiedPackages, additionalArgs)
+					if !ok {
+						suitesWithImpact = append(suitesWithImpact, suite)
+						continue
+					}
+					if plan.stale == "" && len(plan.focusSpecs) == 0 {
+						fmt.Fprintln(coloredStream, formatter.F("{{gray}}No specs in %s are impacted by these changes{{/}}", suite.Suite.Path))
+						deltaTracker.WillRun(suite.Suite)
+						w.impactMaps[suite.Suite.Path].update(suite.Suite)
+						continue
+					}
+					if plan.stale == "" {
+						impactFocusSpecs[suite.Suite.Path] = plan.focusSpecs
+					}
+					descriptions[suite.Suite.Path] += " - " + plan.description()
+					suitesWithImpact = append(suitesWithImpact, suite)
+				}
+
+				if len(suitesWithImpact) > 0 {
+					fmt.Fprintln(coloredStream, formatter.F("{{green}}Will run %d %s:{{/}}", len(suitesWithImpact), internal.PluralizedWord("suite", "suites", len(suitesWithImpact))))
+					for _, suite := range suitesWithImpact {
+						suitesToRun = append(suitesToRun, suite.Suite)
+						fmt.Fprintln(coloredStream, formatter.Fi(1, "%s", descriptions[suite.Suite.Path]))
+					}
+					fmt.Fprintln(coloredStream, "")
 				}
-				fmt.Fprintln(coloredStream, "")
 			}
 
-			if len(suites) == 0 {
+			if len(suitesToRun) == 0 {
 				break
 			}
 
-			w.updateSeed()
-			w.computeSuccinctMode(len(suites))
-			for idx := range suites {
-				if w.interruptHandler.Status().Interrupted() {
-					return
+			w.runSuites(suitesToRun, w.suiteConfig, impactFocusSpecs, deltaTracker, additionalArgs)
+			if w.interruptHandler.Status().Interrupted() {
+				return
+			}
+			changeSource = watchTrackedPaths(changeSource, deltaTracker)
+		case key, ok := <-keys:
+			if !ok {
+				keys = nil
+				break
+			}
+			suiteConfig := w.suiteConfig
+			var suitesToRun internal.TestSuites
+			var focusSpecs map[string][]string
+			switch w.handleKey(key, input) {
+			case keyActionRunAll:
+				suitesToRun = append(suitesToRun, suites...)
+			case keyActionRunFailed:
+				suitesToRun, focusSpecs = w.failedSuitesAndFilters()
+				if len(suitesToRun) == 0 {
+					fmt.Println("No failures to rerun.")
 				}
-				deltaTracker.WillRun(suites[idx])
-				suites[idx] = w.compileAndRun(suites[idx], additionalArgs)
+			case keyActionQuit:
+				return
+			}
+			if len(suitesToRun) == 0 {
+				break
 			}
-			color := "{{green}}"
-			if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
-				color = "{{red}}"
+			fmt.Fprintln(formatter.ColorableStdOut, formatter.F("{{green}}Will run %d %s:{{/}}", len(suitesToRun), internal.PluralizedWord("suite", "suites", len(suitesToRun))))
+			for _, suite := range suitesToRun {
+				fmt.Fprintln(formatter.ColorableStdOut, formatter.Fi(1, "%s", suite.Path))
 			}
-			fmt.Fprintln(coloredStream, formatter.F(color+"\nDone.  Resuming watch...{{/}}"))
-
-			messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, w.cliConfig, w.suiteConfig, w.reporterConfig, w.goFlagsConfig)
-			command.AbortIfError("could not finalize profiles:", err)
-			for _, message := range messages {
-				fmt.Println(message)
+			fmt.Fprintln(formatter.ColorableStdOut, "")
+			w.runSuites(suitesToRun, suiteConfig, focusSpecs, deltaTracker, additionalArgs)
+			if w.interruptHandler.Status().Interrupted() {
+				return
 			}
+			changeSource = watchTrackedPaths(changeSource, deltaTracker)
 		case <-w.interruptHandler.Status().Channel:
 			return
 		}
 	}
 }
 
-func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, additionalArgs []string) internal.TestSuite {
-	suite = internal.CompileSuite(suite, w.goFlagsConfig)
+/*
+runSuites compiles and runs suites with the passed-in suiteConfig and then finalizes their profiles and reports.
+
+Suites with an entry in focusSpecs only run the specs that match those --focus-spec filters (and the user's filters).
+*/
+func (w *SpecWatcher) runSuites(suites internal.TestSuites, suiteConfig types.SuiteConfig, focusSpecs map[string][]string, deltaTracker *DeltaTracker, additionalArgs []string) {
+	coloredStream := formatter.ColorableStdOut
+	w.updateSeed()
+	suiteConfig.RandomSeed = w.suiteConfig.RandomSeed
+	w.computeSuccinctMode(len(suites))
+	for idx := range suites {
+		if w.interruptHandler.Status().Interrupted() {
+			return
+		}
+		deltaTracker.WillRun(suites[idx])
+		suiteConfig := suiteConfig
+		if focusSpecs := focusSpecs[suites[idx].Path]; focusSpecs != nil {
+			suiteConfig.FocusSpecs = focusSpecs
+		}
+		suites[idx] = w.compileAndRun(suites[idx], suiteConfig, additionalArgs)
+	}
+	color := "{{green}}"
+	if suites.CountWithState(internal.TestSuiteStateFailureStates...) > 0 {
+		color = "{{red}}"
+	}
+	fmt.Fprintln(coloredStream, formatter.F(color+"\nDone.  Resuming watch...{{/}}"))
+
+	messages, err := internal.FinalizeProfilesAndReportsForSuites(suites, w.cliConfig, suiteConfig, w.reporterConfig, w.goFlagsConfig)
+	command.AbortIfError("could not finalize profiles:", err)
+	for _, message := range messages {
+		fmt.Println(message)
+	}
+	if w.cliConfig.Interactive {
+		w.printStatus()
+	}
+}
+
+func (w *SpecWatcher) compileAndRun(suite internal.TestSuite, suiteConfig types.SuiteConfig, additionalArgs []string) internal.TestSuite {
+	goFlagsConfig := w.goFlagsConfig
+	var impact *impactMap
+	if w.cliConfig.ImpactAnalysis && suite.IsGinkgo {
+		packages, err := coveredPackages(suite)
+		if err != nil {
+			fmt.Fprintln(formatter.ColorableStdErr, formatter.F("{{orange}}Not analyzing the impact of changes on %s:{{/}} %s", suite.PackageName, err.Error()))
+		} else {
+			// we snapshot the source before compiling so that changes made during the run are picked up by the next one
+			impact = newImpactMap(suite, packages, filtersFingerprint(w.suiteConfig, additionalArgs), w.watchRegExp)
+			goFlagsConfig.Cover = true
+			if goFlagsConfig.CoverPkg == "" {
+				goFlagsConfig.CoverPkg = coverPkg(packages)
+			} else {
+				goFlagsConfig.CoverPkg += "," + coverPkg(packages)
+			}
+		}
+	}
+
+	suite = internal.CompileSuite(suite, goFlagsConfig)
 	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
 		fmt.Println(suite.CompilationError.Error())
+		if w.cliConfig.Interactive {
+			w.recordFailures(suite, nil)
+		}
 		return suite
 	}
 	if w.interruptHandler.Status().Interrupted() {
 		return suite
 	}
-	suite = internal.RunCompiledSuite(suite, w.suiteConfig, w.reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+	reporterConfig := w.reporterConfig
+	cleanupReport := false
+	if (w.cliConfig.Interactive || impact != nil) && reporterConfig.JSONReport == "" {
+		reporterConfig.JSONReport = watchReportName
+		cleanupReport = true
+	}
+	suite = internal.RunCompiledSuite(suite, suiteConfig, reporterConfig, w.cliConfig, w.goFlagsConfig, additionalArgs)
+	report := w.readReport(suite, reporterConfig.JSONReport, cleanupReport)
+	if w.cliConfig.Interactive {
+		w.recordFailures(suite, report)
+	}
+	if impact != nil && report != nil && !w.interruptHandler.Status().Interrupted() {
+		w.recordImpact(suite, impact, *report, suiteConfig, goFlagsConfig, additionalArgs)
+	}
 	internal.Cleanup(w.goFlagsConfig, suite)
 	return suite
 }
 
+// readReport reads the JSON report generated by the suite's most recent run, returning nil if there is no report
+func (w *SpecWatcher) readReport(suite internal.TestSuite, reportName string, cleanup bool) *types.Report {
+	if reportName == "" {
+		return nil
+	}
+	reportPath := internal.AbsPathForGeneratedAsset(reportName, suite, w.cliConfig, 0)
+	if cleanup {
+		defer os.Remove(reportPath)
+	}
+	reports, err := reporters.ReadJSONReports(reportPath)
+	if err != nil || len(reports) != 1 {
+		return nil
+	}
+	return &reports[0]
+}
+
+/*
+recordImpact records the code covered by each of the specs that ran.  A run with the user's filters replaces the suite's impact map.  Runs that only ran some specs (e.g. just the impacted specs) update the existing map.
+*/
+func (w *SpecWatcher) recordImpact(suite internal.TestSuite, impact *impactMap, report types.Report, suiteConfig types.SuiteConfig, goFlagsConfig types.GoFlagsConfig, additionalArgs []string) {
+	if filtersFingerprint(suiteConfig, additionalArgs) != impact.filters {
+		previous, ok := w.impactMaps[suite.Path]
+		if !ok || previous.filters != impact.filters {
+			return
+		}
+		impact.specs = previous.specs
+	}
+
+	focusSpecs := focusSpecsForSpecs(report)
+	fmt.Fprintln(formatter.ColorableStdOut, formatter.F("{{gray}}Recording the code covered by %d %s in %s...{{/}}", len(focusSpecs), internal.PluralizedWord("spec", "specs", len(focusSpecs)), suite.PackageName))
+	impact.recordSpecs(suite, focusSpecs, suiteConfig, goFlagsConfig, w.cliConfig, additionalArgs)
+	w.impactMaps[suite.Path] = impact
+}
+
+// impactPlan returns the plan for rerunning a modified suite.  It returns false if impact analysis doesn't apply to the suite.
+func (w *SpecWatcher) impactPlan(suite *Suite, modifiedPackages []string, additionalArgs []string) (impactPlan, bool) {
+	if !w.cliConfig.ImpactAnalysis || !suite.Suite.IsGinkgo {
+		return impactPlan{}, false
+	}
+	impact, ok := w.impactMaps[suite.Suite.Path]
+	if !ok {
+		return impactPlan{stale: "no spec coverage has been recorded yet"}, true
+	}
+	return impact.plan(suite, modifiedPackages, filtersFingerprint(w.suiteConfig, additionalArgs)), true
+}
+
 func (w *SpecWatcher) computeSuccinctMode(numSuites int) {
 	if w.reporterConfig.Verbosity().GTE(types.VerbosityLevelVerbose) {
 		w.reporterConfig.Succinct = false
@@ -186,7 +411,115 @@ func (w *SpecWatcher) computeSuccinctMode(numSuites int) {
 }
 
 func (w *SpecWatcher) updateSeed() {
-	if !w.flags.WasSet("seed") {
+	if !w.seedPinned {
 		w.suiteConfig.RandomSeed = time.Now().Unix()
 	}
 }
+
+// a watchRoot is a directory passed to ginkgo watch
+type watchRoot struct {
+	dir       string
+	recursive bool
+}
+
+type watchRoots []watchRoot
+
+func watchRootsFor(args []string, cliConfig types.CLIConfig) watchRoots {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	roots := watchRoots{}
+	for _, arg := range args {
+		recursive := cliConfig.Recurse
+		if strings.HasSuffix(arg, "/...") && arg != "/..." {
+			arg = arg[:len(arg)-4]
+			recursive = true
+		}
+		dir, err := filepath.Abs(arg)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, watchRoot{dir: dir, recursive: recursive})
+	}
+	return roots
+}
+
+// couldContainSuite returns true if ginkgo would look for a suite in dir, given the roots it was asked to watch
+func (roots watchRoots) couldContainSuite(dir string) bool {
+	for _, root := range roots {
+		if dir == root.dir {
+			return true
+		}
+		if !root.recursive || !isWithin(dir, root.dir) {
+			continue
+		}
+		rel, _ := filepath.Rel(root.dir, dir)
+		eligible := true
+		for _, component := range strings.Split(rel, string(filepath.Separator)) {
+			eligible = eligible && isSuiteDirectory(component)
+		}
+		if eligible {
+			return true
+		}
+	}
+	return false
+}
+
+func isWithin(dir string, parent string) bool {
+	rel, err := filepath.Rel(parent, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+/*
+updateSuites updates the set of watched suites without rescanning every root.  Suites in dirs are dropped (along with any suites beneath dirs that no longer exist) and then we look for suites in the dirs that still exist.
+*/
+func (w *SpecWatcher) updateSuites(suites internal.TestSuites, roots watchRoots, dirs []string) internal.TestSuites {
+	changed := map[string]bool{}
+	removed := []string{}
+	for _, dir := range dirs {
+		changed[dir] = true
+		if _, err := os.Stat(dir); err != nil {
+			removed = append(removed, dir)
+		}
+	}
+
+	updatedSuites := internal.TestSuites{}
+	for _, suite := range suites {
+		path := suite.AbsPath()
+		if changed[path] {
+			continue
+		}
+		wasRemoved := false
+		for _, dir := range removed {
+			wasRemoved = wasRemoved || isWithin(path, dir)
+		}
+		if !wasRemoved {
+			updatedSuites = append(updatedSuites, suite)
+		}
+	}
+
+	cliConfig := w.cliConfig
+	cliConfig.Recurse = false
+	for _, dir := range dirs {
+		if changed[dir] && roots.couldContainSuite(dir) {
+			updatedSuites = append(updatedSuites, internal.FindSuites([]string{dir}, cliConfig, false).WithoutState(internal.TestSuiteStateSkippedByFilter)...)
+		}
+	}
+
+	return updatedSuites
+}
+
+// watchTrackedPaths makes sure changeSource is watching every suite and dependency the deltaTracker is tracking
+func watchTrackedPaths(changeSource ChangeSource, deltaTracker *DeltaTracker) ChangeSource {
+	err := changeSource.Watch(deltaTracker.WatchedPaths()...)
+	if err != nil {
+		return fallBackToPolling(changeSource, err)
+	}
+	return changeSource
+}
+
+func fallBackToPolling(changeSource ChangeSource, err error) ChangeSource {
+	fmt.Printf("Could not watch for filesystem notifications (%s).  Polling for changes instead.\n", err)
+	changeSource.Close()
+	return NewPollingChangeSource()
+}
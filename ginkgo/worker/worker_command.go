@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/ginkgo/command"
+	"github.com/onsi/ginkgo/v2/ginkgo/internal"
+	"github.com/onsi/ginkgo/v2/internal/parallel_support"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// workers are often started alongside the Ginkgo CLI they connect to, so they wait this long for it to come up
+const connectTimeout = time.Minute
+
+func BuildWorkerCommand() command.Command {
+	var cliConfig = types.NewDefaultCLIConfig()
+	var goFlagsConfig = types.NewDefaultGoFlagsConfig()
+
+	flags, err := types.BuildWorkerCommandFlagSet(&cliConfig, &goFlagsConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return command.Command{
+		Name:          "worker",
+		Flags:         flags,
+		Usage:         "ginkgo worker --connect=<ADDRESS> <FLAGS> <PACKAGE>",
+		ShortDoc:      "Run parallel processes on behalf of a ginkgo run --serve on another machine",
+		Documentation: "Compiles the passed in <PACKAGE> (or the package in the current directory if left blank) and runs up to -procs of the remote processes the Ginkgo CLI at <ADDRESS> is waiting for.  The worker must run against the same code as the Ginkgo CLI it connects to.  <PACKAGE> can also be a precompiled test binary.  The worker authenticates with the token in the GINKGO_PARALLEL_TOKEN environment variable, which must match the one the Ginkgo CLI was started with.",
+		DocLink:       "running-specs-across-multiple-machines",
+		Command: func(args []string, _ []string) {
+			var errors []error
+			cliConfig, goFlagsConfig, errors = types.VetAndInitializeCLIAndGoConfig(cliConfig, goFlagsConfig)
+			command.AbortIfErrors("Ginkgo detected configuration issues:", errors)
+			if cliConfig.Connect == "" {
+				command.AbortWithUsage("ginkgo worker needs the address of a ginkgo run --serve process.  Please set --connect.")
+			}
+
+			runWorker(args, cliConfig, goFlagsConfig)
+		},
+	}
+}
+
+func runWorker(args []string, cliConfig types.CLIConfig, goFlagsConfig types.GoFlagsConfig) {
+	suites := internal.FindSuites(args, cliConfig, true).WithoutState(internal.TestSuiteStateSkippedByFilter)
+	if len(suites) == 0 {
+		command.AbortWith("Found no test suites")
+	}
+	if len(suites) > 1 {
+		command.AbortWith("ginkgo worker runs a single suite but found %d.  Please pass in the package the Ginkgo CLI is serving.", len(suites))
+	}
+	internal.VerifyCLIAndFrameworkVersion(suites)
+
+	client := parallel_support.NewClient(cliConfig.Connect)
+	if !connect(client) {
+		command.AbortWith("Could not connect to the Ginkgo CLI at %s", cliConfig.Connect)
+	}
+	defer client.Close()
+
+	suite := internal.CompileSuite(suites[0], goFlagsConfig)
+	defer internal.Cleanup(goFlagsConfig, suite)
+	if suite.State.Is(internal.TestSuiteStateFailedToCompile) {
+		fmt.Println(suite.CompilationError.Error())
+		command.AbortWith("Failed to compile %s", suite.PackageName)
+	}
+
+	procs, err := client.FetchRemoteProcs(cliConfig.ComputedProcs())
+	if err == parallel_support.ErrorGone {
+		command.AbortGracefullyWith("The Ginkgo CLI at %s isn't waiting for any more procs", cliConfig.Connect)
+	}
+	command.AbortIfError("Failed to fetch procs from the Ginkgo CLI:", err)
+
+	results := make(chan parallel_support.RemoteProcStatus)
+	for _, proc := range procs {
+		go func(proc parallel_support.RemoteProc) {
+			results <- runRemoteProc(suite, proc, cliConfig.Connect, client)
+		}(proc)
+	}
+
+	passed := true
+	for range procs {
+		status := <-results
+		passed = passed && status.Passed
+		if status.Passed {
+			fmt.Printf("Proc %d passed\n", status.Proc)
+		} else {
+			fmt.Printf("Proc %d failed\n", status.Proc)
+		}
+	}
+
+	if !passed {
+		command.Abort(command.AbortDetails{ExitCode: 1})
+	}
+}
+
+func connect(client parallel_support.Client) bool {
+	deadline := time.Now().Add(connectTimeout)
+	for !client.Connect() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Second)
+	}
+	return true
+}
+
+// remoteProcArgs returns the arguments to pass to the test binary for proc.  The files the Ginkgo CLI sent along with proc are written to dir.
+func remoteProcArgs(proc parallel_support.RemoteProc, serverHost string, dir string) ([]string, error) {
+	// the worker's arguments go first as the Ginkgo CLI's pass-through arguments come last
+	args := []string{"--ginkgo.parallel.host=" + serverHost}
+	if len(proc.QuarantineFile) > 0 {
+		path := filepath.Join(dir, "quarantine.json")
+		if err := os.WriteFile(path, proc.QuarantineFile, 0666); err != nil {
+			return nil, err
+		}
+		args = append(args, "--ginkgo.quarantine-file="+path)
+	}
+	if len(proc.DurationReport) > 0 {
+		path := filepath.Join(dir, "durations.json")
+		if err := os.WriteFile(path, proc.DurationReport, 0666); err != nil {
+			return nil, err
+		}
+		args = append(args, "--ginkgo.order-by-duration="+path)
+	}
+	return append(args, proc.Args...), nil
+}
+
+// runRemoteProc runs the test binary as proc, keeping the Ginkgo CLI informed that the process is still running until it exits
+func runRemoteProc(suite internal.TestSuite, proc parallel_support.RemoteProc, serverHost string, client parallel_support.Client) parallel_support.RemoteProcStatus {
+	status := parallel_support.RemoteProcStatus{Proc: proc.Proc}
+	if err := types.ValidateRemoteProcTestRunArgs(proc.Args); err != nil {
+		status.Exited, status.Output = true, err.Error()
+		client.PostRemoteProcStatus(status)
+		return status
+	}
+
+	dir, err := os.MkdirTemp("", "ginkgo-worker")
+	if err != nil {
+		status.Exited, status.Output = true, err.Error()
+		client.PostRemoteProcStatus(status)
+		return status
+	}
+	defer os.RemoveAll(dir)
+	args, err := remoteProcArgs(proc, serverHost, dir)
+	if err != nil {
+		status.Exited, status.Output = true, err.Error()
+		client.PostRemoteProcStatus(status)
+		return status
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := exec.Command(suite.PathToCompiledTest, args...)
+	cmd.Dir = suite.Path
+	cmd.Stdout = buf
+	cmd.Stderr = buf
+
+	if err := cmd.Start(); err != nil {
+		status.Exited, status.Output = true, err.Error()
+		client.PostRemoteProcStatus(status)
+		return status
+	}
+
+	exited := make(chan any)
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+
+	ticker := time.NewTicker(parallel_support.REMOTE_PROC_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			client.PostRemoteProcStatus(status)
+		case <-exited:
+			exitStatus := cmd.ProcessState.ExitCode()
+			status.Exited = true
+			status.Passed = (exitStatus == 0) || (exitStatus == types.GINKGO_FOCUS_EXIT_CODE)
+			status.HasProgrammaticFocus = exitStatus == types.GINKGO_FOCUS_EXIT_CODE
+			status.Output = buf.String()
+			client.PostRemoteProcStatus(status)
+			return status
+		}
+	}
+}
@@ -1,7 +1,18 @@
 package ginkgo
 
 import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+
 	"github.com/onsi/ginkgo/v2/internal/testingtproxy"
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 /*
@@ -92,3 +103,164 @@ type FullGinkgoTInterface interface {
 
 	AttachProgressReporter(func() string) func()
 }
+
+/*
+GinkgoTB() returns a wrapper that satisfies the testing.TB interface exactly.
+
+testing.TB includes an unexported method so GinkgoT() cannot be passed to third party helpers (e.g. httptest-based fixtures or goleak) that accept a testing.TB.  GinkgoTB() embeds testing.TB (to satisfy the unexported method) and implements every testing.TB method.  Methods implemented by GinkgoT() are routed through GinkgoT() so that failures, skips, cleanup, and logging behave exactly as they do with GinkgoT().  The remaining methods are implemented in terms of Ginkgo:
+
+  - Output() returns the GinkgoWriter
+  - Context() returns a context that is cancelled when the spec's cleanup runs
+  - Chdir() changes the working directory and restores it via DeferCleanup - since this affects the whole process don't call it from specs that run in parallel with other specs in the same process (i.e. Concurrent specs)
+  - Attr() records the attribute as a ReportEntry with ReportEntryVisibilityFailureOrVerbose
+  - ArtifactDir() returns a temporary directory (via TempDir()) - repeated calls on the same wrapper return the same directory
+
+Like GinkgoT(), GinkgoTB() takes an optional offset argument that can be used to get the correct line number associated with the failure - though you do not need to use this if you call GinkgoHelper() or GinkgoTB().Helper() appropriately.
+
+You can learn more here: https://onsi.github.io/ginkgo/#using-third-party-libraries
+*/
+func GinkgoTB(optionalOffset ...int) *GinkgoTBWrapper {
+	offset := 2
+	if len(optionalOffset) > 0 {
+		offset = optionalOffset[0]
+	}
+	return &GinkgoTBWrapper{GinkgoT: GinkgoT(offset)}
+}
+
+/*
+GinkgoTBWrapper is the testing.TB returned by GinkgoTB().  GinkgoT provides access to the underlying FullGinkgoTInterface.
+*/
+type GinkgoTBWrapper struct {
+	testing.TB
+	GinkgoT FullGinkgoTInterface
+
+	lock        sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+	artifactDir string
+}
+
+// Cleanup registers f via GinkgoT().Cleanup.  As with testing.T, the wrapper's Context() is cancelled before f is called.
+func (g *GinkgoTBWrapper) Cleanup(f func()) {
+	g.GinkgoT.Cleanup(func() {
+		g.cancelContext()
+		f()
+	})
+}
+
+// Context returns a context that is cancelled when the spec's cleanup runs - just before any functions registered via Cleanup are called
+func (g *GinkgoTBWrapper) Context() context.Context {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.ctx == nil {
+		g.ctx, g.cancel = context.WithCancel(context.Background())
+		g.GinkgoT.DeferCleanup(g.cancelContext)
+	}
+	return g.ctx
+}
+
+func (g *GinkgoTBWrapper) cancelContext() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// Output returns the GinkgoWriter
+func (g *GinkgoTBWrapper) Output() io.Writer {
+	return GinkgoWriter
+}
+
+// Chdir changes the current working directory to dir and restores it via DeferCleanup.  On Unix it also sets the PWD environment variable for the duration of the spec.
+func (g *GinkgoTBWrapper) Chdir(dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		g.GinkgoT.Fatalf("Chdir: %s", err.Error())
+	}
+	abs := dir
+	if !filepath.IsAbs(dir) {
+		abs = filepath.Join(wd, dir)
+	}
+	if err := os.Chdir(dir); err != nil {
+		g.GinkgoT.Fatalf("Chdir: %s", err.Error())
+	}
+	if runtime.GOOS != "windows" && runtime.GOOS != "plan9" {
+		g.GinkgoT.Setenv("PWD", abs)
+	}
+	g.GinkgoT.DeferCleanup(os.Chdir, wd)
+}
+
+// Attr records the attribute as a ReportEntry named key.  Like testing.T, the key must not contain whitespace and the value must not contain newlines.
+func (g *GinkgoTBWrapper) Attr(key, value string) {
+	if strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+		g.GinkgoT.Errorf("Attr: key %q contains whitespace", key)
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		g.GinkgoT.Errorf("Attr: value %q contains a newline", value)
+		return
+	}
+	g.GinkgoT.AddReportEntryVisibilityFailureOrVerbose(key, value)
+}
+
+// ArtifactDir returns a temporary directory (via TempDir()) in which the spec can store output files.  Repeated calls return the same directory.
+func (g *GinkgoTBWrapper) ArtifactDir() string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.artifactDir == "" {
+		g.artifactDir = g.GinkgoT.TempDir()
+	}
+	return g.artifactDir
+}
+func (g *GinkgoTBWrapper) Error(args ...any) {
+	g.GinkgoT.Error(args...)
+}
+func (g *GinkgoTBWrapper) Errorf(format string, args ...any) {
+	g.GinkgoT.Errorf(format, args...)
+}
+func (g *GinkgoTBWrapper) Fail() {
+	g.GinkgoT.Fail()
+}
+func (g *GinkgoTBWrapper) FailNow() {
+	g.GinkgoT.FailNow()
+}
+func (g *GinkgoTBWrapper) Failed() bool {
+	return g.GinkgoT.Failed()
+}
+func (g *GinkgoTBWrapper) Fatal(args ...any) {
+	g.GinkgoT.Fatal(args...)
+}
+func (g *GinkgoTBWrapper) Fatalf(format string, args ...any) {
+	g.GinkgoT.Fatalf(format, args...)
+}
+func (g *GinkgoTBWrapper) Helper() {
+	types.MarkAsHelper(1)
+}
+func (g *GinkgoTBWrapper) Log(args ...any) {
+	g.GinkgoT.Log(args...)
+}
+func (g *GinkgoTBWrapper) Logf(format string, args ...any) {
+	g.GinkgoT.Logf(format, args...)
+}
+func (g *GinkgoTBWrapper) Name() string {
+	return g.GinkgoT.Name()
+}
+func (g *GinkgoTBWrapper) Setenv(key, value string) {
+	g.GinkgoT.Setenv(key, value)
+}
+func (g *GinkgoTBWrapper) Skip(args ...any) {
+	g.GinkgoT.Skip(args...)
+}
+func (g *GinkgoTBWrapper) SkipNow() {
+	g.GinkgoT.SkipNow()
+}
+func (g *GinkgoTBWrapper) Skipf(format string, args ...any) {
+	g.GinkgoT.Skipf(format, args...)
+}
+func (g *GinkgoTBWrapper) Skipped() bool {
+	return g.GinkgoT.Skipped()
+}
+func (g *GinkgoTBWrapper) TempDir() string {
+	return g.GinkgoT.TempDir()
+}
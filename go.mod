@@ -3,6 +3,7 @@ module github.com/onsi/ginkgo/v2
 go 1.20
 
 require (
+	github.com/Masterminds/semver/v3 v3.2.1
 	github.com/go-logr/logr v1.2.4
 	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572
 	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38
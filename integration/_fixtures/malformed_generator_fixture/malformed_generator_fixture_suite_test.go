@@ -0,0 +1,13 @@
+package malformed_generator_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMalformedGeneratorFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MalformedGeneratorFixture Suite")
+}
@@ -0,0 +1,10 @@
+package malformed_generator_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("MalformedGeneratorFixture", func() {
+	ItForAll("has nothing to pick from", GenOneOf[string](), func(s string) {
+	})
+})
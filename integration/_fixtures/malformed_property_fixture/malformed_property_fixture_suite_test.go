@@ -0,0 +1,13 @@
+package malformed_property_fixture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMalformedPropertyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MalformedPropertyFixture Suite")
+}
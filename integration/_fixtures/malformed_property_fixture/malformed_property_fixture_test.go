@@ -0,0 +1,11 @@
+package malformed_property_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("MalformedPropertyFixture", func() {
+	ItForAll("registers cleanup on every run", GenInt(0, 10), func(i int) {
+		DeferCleanup(func() {})
+	})
+})
@@ -0,0 +1,39 @@
+package measurement_fixture_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMeasurementFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MeasurementFixture Suite")
+}
+
+var scale = 1.0
+
+var _ = BeforeSuite(func() {
+	if s := os.Getenv("MEASUREMENT_SCALE"); s != "" {
+		var err error
+		scale, err = strconv.ParseFloat(s, 64)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+})
+
+var _ = Describe("measurements", func() {
+	It("records memory", func() {
+		SampleValue("memory", func(idx int) float64 { return 10 * scale }, SamplingConfig{N: 5}, MeasurementUnits("MB"))
+	})
+
+	It("records connections", func() {
+		SampleValue("connections", func(idx int) float64 { return 4 }, SamplingConfig{N: 5})
+	})
+
+	It("records requests", func() {
+		SampleValue("requests", func(idx int) float64 { return 100 }, SamplingConfig{N: 5})
+	})
+})
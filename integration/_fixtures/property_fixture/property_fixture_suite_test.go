@@ -0,0 +1,24 @@
+package property_fixture_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPropertyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PropertyFixture Suite")
+}
+
+var _ = Describe("properties", func() {
+	ItForAll("counts iterations", GenInt(0, 1000000), PropertyIterations(3), func(i int) {
+		fmt.Printf("input: %d\n", i)
+	})
+
+	ItForAll("fails for big numbers", GenInt(0, 1000), func(i int) {
+		Ω(i).Should(BeNumerically("<", 100))
+	})
+})
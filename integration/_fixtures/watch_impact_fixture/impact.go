@@ -0,0 +1,9 @@
+package impact
+
+func Alpha() string {
+	return "alpha"
+}
+
+func Beta() string {
+	return "beta"
+}
@@ -0,0 +1,13 @@
+package impact_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestImpact(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Impact Suite")
+}
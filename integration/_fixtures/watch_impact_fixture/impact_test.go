@@ -0,0 +1,18 @@
+package impact_test
+
+import (
+	. "github.com/onsi/ginkgo/v2/integration/_fixtures/watch_impact_fixture"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Impact", func() {
+	It("covers alpha", func() {
+		Ω(Alpha()).Should(Equal("alpha"))
+	})
+
+	It("covers beta", func() {
+		Ω(Beta()).Should(Equal("beta"))
+	})
+})
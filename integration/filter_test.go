@@ -94,6 +94,30 @@ var _ = Describe("Filter", func() {
 		Ω(session).Should(gbytes.Say("Invalid File Filter"))
 	})
 
+	Describe("Rerunning failed specs", func() {
+		BeforeEach(func() {
+			fm.MountFixture("failing_ginkgo_tests")
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "--json-report=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+		})
+
+		It("only reruns the specs that failed", func() {
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "--rerun-failed=report.json", "--json-report=rerun.json")
+			Eventually(session).Should(gexec.Exit(1))
+			specs := Reports(fm.LoadJSONReports("failing_ginkgo_tests", "rerun.json")[0].SpecReports)
+			Ω(specs.FindByFullText("FailingGinkgoTests should fail")).Should(HaveFailed())
+			Ω(specs.FindByFullText("FailingGinkgoTests should pass")).Should(HaveBeenSkipped())
+		})
+
+		It("narrows, rather than widens, the other filters", func() {
+			session := startGinkgo(fm.PathTo("failing_ginkgo_tests"), "--rerun-failed=report.json", "--focus=should pass", "--json-report=rerun.json")
+			Eventually(session).Should(gexec.Exit(0))
+			specs := Reports(fm.LoadJSONReports("failing_ginkgo_tests", "rerun.json")[0].SpecReports)
+			Ω(specs.FindByFullText("FailingGinkgoTests should fail")).Should(HaveBeenSkipped())
+			Ω(specs.FindByFullText("FailingGinkgoTests should pass")).Should(HaveBeenSkipped())
+		})
+	})
+
 	Describe("Listing labels", func() {
 		BeforeEach(func() {
 			fm.MountFixture("labels")
@@ -0,0 +1,37 @@
+package integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Measurement baselines", func() {
+	BeforeEach(func() {
+		fm.MountFixture("measurement")
+	})
+
+	It("saves a baseline and fails the suite when a measurement regresses", func() {
+		session := startGinkgo(fm.PathTo("measurement"), "--no-color", "--save-measurement-baseline=baseline.json")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(fm.PathTo("measurement", "baseline.json")).Should(BeAnExistingFile())
+
+		session = startGinkgo(fm.PathTo("measurement"), "--no-color", "--measurement-baseline=baseline.json")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("Comparing Measurements to Baseline:"))
+
+		os.Setenv("MEASUREMENT_SCALE", "1.5")
+		defer os.Unsetenv("MEASUREMENT_SCALE")
+		session = startGinkgo(fm.PathTo("measurement"), "--no-color", "--measurement-baseline=baseline.json", "--procs=2")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Comparing Measurements to Baseline - 1 Regressed:`))
+		Ω(session).Should(gbytes.Say(`measurements records memory\s+memory\s+10 MB\s+15 MB\s+\+50.0%\s+REGRESSED`))
+		Ω(session).Should(gbytes.Say(`FAIL! - Measurements regressed relative to the baseline`))
+
+		session = startGinkgo(fm.PathTo("measurement"), "--no-color", "--measurement-baseline=baseline.json", "--measurement-tolerance=0.6", "--procs=2")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+})
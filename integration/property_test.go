@@ -0,0 +1,74 @@
+package integration_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Property-based specs", func() {
+	BeforeEach(func() {
+		fm.MountFixture("property")
+	})
+
+	inputs := func(output string) []string {
+		return regexp.MustCompile(`input: \d+`).FindAllString(output, -1)
+	}
+
+	It("reports the minimal counterexample", func() {
+		session := startGinkgo(fm.PathTo("property"), "--no-color")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Counterexample"))
+		Ω(output).Should(MatchRegexp(`failed on iteration \d+, shrunk \d+ times:\s+#1: 100`))
+		Ω(output).Should(ContainSubstring("<int>: 100"))
+	})
+
+	It("honors --property-iterations and reproduces inputs with --seed", func() {
+		session := startGinkgo(fm.PathTo("property"), "--no-color", "-v", "--seed=17", "--property-iterations=5")
+		Eventually(session).Should(gexec.Exit(1))
+		first := inputs(string(session.Out.Contents()))
+		Ω(first).Should(HaveLen(5))
+
+		session = startGinkgo(fm.PathTo("property"), "--no-color", "-v", "--seed=17", "--property-iterations=5")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(inputs(string(session.Out.Contents()))).Should(Equal(first))
+
+		session = startGinkgo(fm.PathTo("property"), "--no-color", "-v", "--seed=18", "--property-iterations=5")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(inputs(string(session.Out.Contents()))).ShouldNot(Equal(first))
+	})
+
+	Describe("when a generator is passed invalid arguments", func() {
+		BeforeEach(func() {
+			fm.MountFixture("malformed_generator")
+		})
+
+		It("exits early with a helpful error message", func() {
+			session := startGinkgo(fm.PathTo("malformed_generator"), "--no-color")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents()) + string(session.Err.Contents())
+
+			Ω(output).Should(ContainSubstring("GenOneOf passed invalid arguments"))
+			Ω(output).Should(ContainSubstring("malformed_generator_fixture_test.go:8"))
+		})
+	})
+
+	Describe("when the body calls DeferCleanup", func() {
+		BeforeEach(func() {
+			fm.MountFixture("malformed_property")
+		})
+
+		It("exits early with a helpful error message", func() {
+			session := startGinkgo(fm.PathTo("malformed_property"), "--no-color")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents()) + string(session.Err.Contents())
+
+			Ω(output).Should(ContainSubstring("DeferCleanup cannot be called in an ItForAll body"))
+			Ω(output).Should(ContainSubstring("malformed_property_fixture_test.go:9"))
+		})
+	})
+})
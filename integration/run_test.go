@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
@@ -292,6 +293,29 @@ var _ = Describe("Running Specs", func() {
 		})
 	})
 
+	Context("when emitting go test -json output", func() {
+		BeforeEach(func() {
+			fm.MountFixture("passing_ginkgo_tests")
+		})
+
+		DescribeTable("only writes JSON events to stdout",
+			func(args ...string) {
+				session := startGinkgo(fm.PathTo("passing_ginkgo_tests"), append([]string{"--go-test-json"}, args...)...)
+				Eventually(session).Should(gexec.Exit(0))
+
+				lines := strings.Split(strings.TrimSpace(string(session.Out.Contents())), "\n")
+				Ω(lines).ShouldNot(BeEmpty())
+				for _, line := range lines {
+					Ω(json.Valid([]byte(line))).Should(BeTrue(), "stdout line is not JSON: %q", line)
+				}
+				Ω(session.Out.Contents()).Should(ContainSubstring(`"Action":"pass"`))
+				Ω(session.Err).Should(gbytes.Say("Test Suite Passed"))
+			},
+			Entry("when running serially"),
+			Entry("when running in parallel", "--procs=2"),
+		)
+	})
+
 	Context("when running in parallel and there are specs marked Serial", Label("slow"), func() {
 		BeforeEach(func() {
 			fm.MountFixture("serial")
@@ -1,10 +1,13 @@
 package integration_test
 
 import (
+	"net"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 )
 
@@ -66,6 +69,88 @@ var _ = Describe("SuiteSetup", func() {
 				Ω(output).ShouldNot(ContainSubstring("AFTER_B_3"))
 			})
 		})
+
+		Context("when run across a coordinator and a worker", func() {
+			BeforeEach(func() {
+				GinkgoT().Setenv(types.PARALLEL_TOKEN_ENV_VAR, "secret")
+			})
+
+			It("should refuse to serve without a token", func() {
+				GinkgoT().Setenv(types.PARALLEL_TOKEN_ENV_VAR, "")
+				coordinator := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--serve=127.0.0.1:0", "--remote-procs=1")
+				Eventually(coordinator).Should(gexec.Exit(1))
+				Ω(coordinator.Err).Should(gbytes.Say("--serve and --connect require a token"))
+			})
+
+			It("should share the SynchronizedBeforeSuite data with the worker's procs and produce a single report", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Ω(err).ShouldNot(HaveOccurred())
+				address := listener.Addr().String()
+				listener.Close()
+
+				coordinator := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--serve="+address, "--remote-procs=2", "--json-report=out.json")
+				Eventually(coordinator).Should(gbytes.Say("waiting for workers to run 2 remote procs"))
+				worker := startGinkgo(fm.PathTo("synchronized_setup_tests"), "worker", "--connect="+address, "--procs=2")
+
+				Eventually(worker).Should(gexec.Exit(0))
+				Eventually(coordinator).Should(gexec.Exit(0))
+				output := string(coordinator.Out.Contents())
+
+				Ω(output).Should(ContainSubstring("BEFORE_A_1"))
+				Ω(output).Should(ContainSubstring("BEFORE_B_1: DATA"))
+				Ω(output).Should(ContainSubstring("BEFORE_B_2: DATA"))
+				Ω(output).Should(ContainSubstring("BEFORE_B_3: DATA"))
+				Ω(output).ShouldNot(ContainSubstring("BEFORE_A_2"))
+				Ω(output).Should(ContainSubstring("AFTER_B_1"))
+				Ω(output).ShouldNot(ContainSubstring("AFTER_B_2"))
+
+				Ω(worker.Out).Should(gbytes.Say(`Proc \d passed`))
+				Ω(worker.Out).Should(gbytes.Say(`Proc \d passed`))
+
+				report := fm.LoadJSONReports("synchronized_setup_tests", "out.json")[0]
+				Ω(report.SuiteSucceeded).Should(BeTrue())
+				Ω(report.SuiteConfig.ParallelTotal).Should(Equal(3))
+				Ω(report.SpecReports.WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(2))
+			})
+
+			It("should send the contents of the quarantine file and duration report to the worker", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Ω(err).ShouldNot(HaveOccurred())
+				address := listener.Addr().String()
+				listener.Close()
+
+				fm.WriteFile("synchronized_setup_tests", "quarantine.json", `[{"Spec": "Synchronized Setup should run the before suite once", "FlakeAttempts": 3}]`)
+				fm.WriteFile("synchronized_setup_tests", "durations.json", `[]`)
+				coordinator := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--serve="+address, "--remote-procs=2", "--quarantine-file=quarantine.json", "--order-by-duration=durations.json", "--json-report=out.json")
+				Eventually(coordinator).Should(gbytes.Say("waiting for workers to run 2 remote procs"))
+				worker := startGinkgo(fm.PathTo("synchronized_setup_tests"), "worker", "--connect="+address, "--procs=2")
+
+				Eventually(worker).Should(gexec.Exit(0))
+				Eventually(coordinator).Should(gexec.Exit(0))
+
+				report := fm.LoadJSONReports("synchronized_setup_tests", "out.json")[0]
+				Ω(report.SuiteSucceeded).Should(BeTrue())
+				for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+					Ω(spec.MaxFlakeAttempts).Should(Equal(3))
+				}
+			})
+
+			It("should only hand the worker as many procs as the coordinator is waiting for", func() {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				Ω(err).ShouldNot(HaveOccurred())
+				address := listener.Addr().String()
+				listener.Close()
+
+				coordinator := startGinkgo(fm.PathTo("synchronized_setup_tests"), "--no-color", "--serve="+address, "--remote-procs=1", "--procs=2")
+				Eventually(coordinator).Should(gbytes.Say("waiting for workers to run 1 remote proc"))
+				worker := startGinkgo(fm.PathTo("synchronized_setup_tests"), "worker", "--connect="+address, "--procs=3")
+
+				Eventually(worker).Should(gexec.Exit(0))
+				Eventually(coordinator).Should(gexec.Exit(0))
+				Ω(string(worker.Out.Contents())).Should(Equal("Proc 3 passed\n"))
+				Ω(string(coordinator.Out.Contents())).Should(ContainSubstring("BEFORE_B_3: DATA"))
+			})
+		})
 	})
 
 	Context("With a failing synchronized before suite", func() {
@@ -1,8 +1,10 @@
 package integration_test
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -29,6 +31,14 @@ var _ = Describe("Watch", Label("SLOW"), func() {
 		Ω(err).ShouldNot(HaveOccurred())
 	}
 
+	appendTo := func(path string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0666)
+		Ω(err).ShouldNot(HaveOccurred())
+		_, err = f.WriteString("//")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(f.Close()).Should(Succeed())
+	}
+
 	modifyCode := func(pkgToModify string) {
 		path := filepath.Join(pkgToModify, pkgToModify+".go")
 		modifyFile(fm.PathTo("watch", path))
@@ -242,4 +252,177 @@ var _ = Describe("Watch", Label("SLOW"), func() {
 			Eventually(session).Should(gbytes.Say("D Suite"))
 		})
 	})
+	Describe("when a burst of changes is saved at once", func() {
+		It("should wait for the burst to settle and run the affected suites once", func() {
+			session = startGinkgo(fm.PathTo("watch"), "watch", "-succinct", "-r", "-depth=1")
+			Eventually(session).Should(gbytes.Say("Watching 3 suites"))
+
+			modifyCode("A")
+			appendTo(fm.PathTo("watch", "A/A_test.go"))
+			appendTo(fm.PathTo("watch", "A/A.go"))
+			appendTo(fm.PathTo("watch", "A/A_suite_test.go"))
+
+			Eventually(session).Should(gbytes.Say("Detected changes in"))
+			Eventually(session).Should(gbytes.Say("Will run 1 suite"))
+			Eventually(session).Should(gbytes.Say("A Suite"))
+			Consistently(session).ShouldNot(gbytes.Say("Detected changes in"))
+		})
+	})
+
+	Describe("when a test suite is removed", func() {
+		It("should stop monitoring that test suite", func() {
+			session = startGinkgo(fm.PathTo("watch"), "watch", "-succinct", "-r", "-depth=1")
+			Eventually(session).Should(gbytes.Say("Watching 3 suites"))
+
+			Ω(os.RemoveAll(fm.PathTo("watch", "A"))).Should(Succeed())
+
+			Eventually(session).Should(gbytes.Say("Stopped watching 1 removed suite"))
+			Eventually(session).Should(gbytes.Say(`A\n`))
+
+			modifyCode("B")
+
+			Eventually(session).Should(gbytes.Say("Detected changes in"))
+			Eventually(session).Should(gbytes.Say("B Suite"))
+			Consistently(session).ShouldNot(gbytes.Say("A Suite"))
+		})
+	})
+	Describe("interactive mode", func() {
+		var stdin io.WriteCloser
+
+		BeforeEach(func() {
+			cmd := ginkgoCommand(fm.PathTo("watch"), "watch", "-succinct", "-r", "-depth=1", "--interactive", "--no-color")
+			var err error
+			stdin, err = cmd.StdinPipe()
+			Ω(err).ShouldNot(HaveOccurred())
+			session, err = gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(session).Should(gbytes.Say("Watching 3 suites"))
+			Eventually(session).Should(gbytes.Say(`Focus: none \| Label Filter: none \| Verbosity: default \| Seed: new seed for every run`))
+		})
+
+		press := func(keys string) {
+			_, err := stdin.Write([]byte(keys))
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		It("lists the available commands", func() {
+			press("?")
+			Eventually(session).Should(gbytes.Say("Ginkgo Watch Commands:"))
+			Eventually(session).Should(gbytes.Say("a rerun all watched suites"))
+		})
+
+		It("reruns all the watched suites", func() {
+			press("a")
+			Eventually(session).Should(gbytes.Say("Will run 3 suites"))
+			Eventually(session).Should(gbytes.Say("Done.  Resuming watch..."))
+			Ω(session.Out.Contents()).Should(ContainSubstring("A Suite"))
+			Ω(session.Out.Contents()).Should(ContainSubstring("B Suite"))
+			Ω(session.Out.Contents()).Should(ContainSubstring("C Suite"))
+			Eventually(session).Should(gbytes.Say(`Focus: none`))
+		})
+
+		It("changes the focus, label filter, verbosity, and seed on the fly", func() {
+			press("/A\n")
+			Eventually(session).Should(gbytes.Say(`Focus: A \| Label Filter: none \| Verbosity: default`))
+			press("l(\n")
+			Eventually(session).Should(gbytes.Say("Invalid label filter"))
+			press("lfast\n")
+			Eventually(session).Should(gbytes.Say(`Focus: A \| Label Filter: fast \| Verbosity: default`))
+			press("l\n")
+			Eventually(session).Should(gbytes.Say(`Focus: A \| Label Filter: none \| Verbosity: default`))
+			press("v")
+			Eventually(session).Should(gbytes.Say(`Verbosity: verbose \|`))
+			press("s1138\n")
+			Eventually(session).Should(gbytes.Say(`Seed: 1138 \|`))
+
+			press("a")
+			Eventually(session).Should(gbytes.Say("Will run 3 suites"))
+			Eventually(session).Should(gbytes.Say("Random Seed: 1138"))
+			Eventually(session).Should(gbytes.Say("Done.  Resuming watch..."))
+			Ω(session.Out.Contents()).Should(ContainSubstring("Ran 1 of 1 Specs"))
+			Ω(session.Out.Contents()).Should(ContainSubstring("Ran 0 of 1 Specs"))
+		})
+
+		It("reruns only the specs that failed", func() {
+			press("f")
+			Eventually(session).Should(gbytes.Say("No failures to rerun."))
+
+			Ω(os.WriteFile(fm.PathTo("watch", "A/failing_test.go"), []byte(`package A_test
+
+import . "github.com/onsi/ginkgo/v2"
+
+var _ = It("fails", func() { Fail("boom") })
+`), 0666)).Should(Succeed())
+			Eventually(session).Should(gbytes.Say("Detected changes in"))
+			Eventually(session).Should(gbytes.Say(`A Suite - 2/2 specs`))
+			Eventually(session).Should(gbytes.Say("Done.  Resuming watch..."))
+
+			press("f")
+			Eventually(session).Should(gbytes.Say("Will run 1 suite"))
+			Eventually(session).Should(gbytes.Say(`A Suite - 1/2 specs`))
+			Eventually(session).Should(gbytes.Say("Done.  Resuming watch..."))
+		})
+
+		It("quits", func() {
+			press("q")
+			Eventually(session).Should(gexec.Exit(0))
+		})
+	})
+
+	Describe("impact analysis", func() {
+		BeforeEach(func() {
+			fm.MountFixture("watch_impact")
+			session = startGinkgo(fm.PathTo("watch_impact"), "watch", "--impact-analysis", "--no-color")
+			Eventually(session).Should(gbytes.Say("Ran 2 of 2 Specs"))
+			Eventually(session).Should(gbytes.Say("Recording the code covered by 2 specs"))
+		})
+
+		replace := func(file string, old string, new string) {
+			time.Sleep(time.Second)
+			path := fm.PathTo("watch_impact", file)
+			content, err := os.ReadFile(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(ContainSubstring(old))
+			Ω(os.WriteFile(path, []byte(strings.Replace(string(content), old, new, 1)), 0666)).Should(Succeed())
+		}
+
+		It("reruns only the specs that covered the functions that changed", func() {
+			replace("impact.go", `return "alpha"`, `return "al" + "pha"`)
+			Eventually(session).Should(gbytes.Say("Detected changes in"))
+			Eventually(session).Should(gbytes.Say("1 of 2 specs impacted"))
+			Eventually(session).Should(gbytes.Say("Ran 1 of 2 Specs"))
+			Eventually(session).Should(gbytes.Say("Recording the code covered by 1 spec"))
+
+			replace("impact.go", `return "beta"`, `return "be" + "ta"`)
+			Eventually(session).Should(gbytes.Say("1 of 2 specs impacted"))
+			Eventually(session).Should(gbytes.Say("Ran 1 of 2 Specs"))
+		})
+
+		It("always reruns specs that failed while their coverage was recorded", func() {
+			replace("impact.go", `return "alpha"`, `return "ALPHA"`)
+			Eventually(session).Should(gbytes.Say("1 of 2 specs impacted"))
+			Eventually(session).Should(gbytes.Say("Ran 1 of 2 Specs"))
+			Eventually(session).Should(gbytes.Say("Recording the code covered by 1 spec"))
+
+			replace("impact.go", `return "beta"`, `return "be" + "ta"`)
+			Eventually(session).Should(gbytes.Say("2 of 2 specs impacted"))
+			Eventually(session).Should(gbytes.Say("Ran 2 of 2 Specs"))
+		})
+
+		It("skips the suite when no covered functions changed", func() {
+			appendTo(fm.PathTo("watch_impact", "impact.go"))
+			Eventually(session).Should(gbytes.Say("No specs in .* are impacted by these changes"))
+			Consistently(session).ShouldNot(gbytes.Say("Ran"))
+		})
+
+		It("reruns the entire suite when the changes can't be attributed to functions", func() {
+			replace("impact.go", "func Beta", "var Gamma = 3\n\nfunc Beta")
+			Eventually(session).Should(gbytes.Say(`all specs \(impact.go changed outside of a function\)`))
+			Eventually(session).Should(gbytes.Say("Ran 2 of 2 Specs"))
+
+			replace("impact_test.go", `"covers beta"`, `"still covers beta"`)
+			Eventually(session).Should(gbytes.Say(`all specs \(impact_test.go changed\)`))
+			Eventually(session).Should(gbytes.Say("Ran 2 of 2 Specs"))
+		})
+	})
 })
@@ -0,0 +1,319 @@
+package internal
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+Specs marked Concurrent run alongside one another on separate goroutines within a single process.
+
+Each Concurrent spec runs on its own short-lived copy of the primary Suite.  The copy has its own Failer, Writer, and current spec state - so groups and runNode can run the spec exactly as they would on the primary suite.  The DSL, however, only knows about the global Suite, Failer, and GinkgoWriter.  So concurrentSpecs tracks which goroutines belong to which running spec and the globals route calls made on those goroutines to the spec's copy.
+
+Goroutines are attributed to a spec explicitly where possible: the goroutines Ginkgo runs the spec's nodes on claim themselves and goroutines launched with Suite.Go (i.e. GinkgoGo) claim themselves on behalf of the spec that launched them.  Goroutines launched with a bare go statement are attributed to the spec that owns the goroutine that launched them the first time they call into Ginkgo (see suiteForCurrentGoroutine).
+
+Output written directly to stdout and stderr is not captured for Concurrent specs.
+*/
+type concurrentSpecs struct {
+	primary *Suite
+	slots   chan bool
+	wg      *sync.WaitGroup
+
+	ownersLock *sync.Mutex
+	owners     map[uint64]*Suite
+	// adopted tracks the bare goroutines attributed to each running spec so they can be released when the spec finishes
+	adopted map[*Suite][]uint64
+
+	// reportLock guards the primary suite's report and skipAll state which are updated as each Concurrent spec finishes
+	reportLock *sync.Mutex
+	// reportEachLock ensures ReportBeforeEach and ReportAfterEach nodes never run concurrently with one another
+	reportEachLock *sync.Mutex
+
+	reporter reporters.Reporter
+}
+
+func newConcurrentSpecs(primary *Suite) *concurrentSpecs {
+	concurrency := primary.config.Concurrency
+	if concurrency <= 0 {
+		// Concurrent specs are typically I/O-bound so we don't limit them to GOMAXPROCS on small machines
+		concurrency = max(runtime.GOMAXPROCS(0), 4)
+	}
+	c := &concurrentSpecs{
+		primary:        primary,
+		slots:          make(chan bool, concurrency),
+		wg:             &sync.WaitGroup{},
+		ownersLock:     &sync.Mutex{},
+		owners:         map[uint64]*Suite{},
+		adopted:        map[*Suite][]uint64{},
+		reportLock:     &sync.Mutex{},
+		reportEachLock: &sync.Mutex{},
+	}
+
+	reporterLock := &sync.Mutex{}
+	c.reporter = synchronizedReporter{reporter: primary.reporter, lock: reporterLock, dropLiveEvents: true}
+	primary.reporter = synchronizedReporter{reporter: primary.reporter, lock: reporterLock}
+
+	primary.failer.routeTo(func() *Failer {
+		if suite := c.suiteForCurrentGoroutine(); suite != nil {
+			return suite.failer
+		}
+		return nil
+	})
+	if writer, ok := primary.writer.(*Writer); ok {
+		writer.routeTo(func() *Writer {
+			if suite := c.suiteForCurrentGoroutine(); suite != nil {
+				return suite.writer.(*Writer)
+			}
+			return nil
+		})
+	}
+	return c
+}
+
+func (c *concurrentSpecs) canRun(specs Specs) bool {
+	if len(specs) != 1 {
+		return false
+	}
+	nodes := specs[0].Nodes
+	return nodes.HasNodeMarkedConcurrent() && !nodes.HasNodeMarkedSerial() && nodes.FirstNodeMarkedOrdered().IsZero()
+}
+
+// run blocks until a slot is available and then runs spec on a new goroutine
+func (c *concurrentSpecs) run(spec Spec) {
+	c.slots <- true
+	c.wg.Add(1)
+	suite := c.newSuite()
+	go func() {
+		defer func() {
+			<-c.slots
+			c.wg.Done()
+		}()
+		defer c.releaseAdoptedGoroutines(suite)
+		defer c.claimCurrentGoroutine(suite)()
+		newGroup(suite).run(Specs{spec})
+	}()
+}
+
+func (c *concurrentSpecs) wait() {
+	c.wg.Wait()
+}
+
+// stop waits for the running specs and then stops routing calls made to the global Failer and Writer
+func (c *concurrentSpecs) stop() {
+	c.wait()
+	c.primary.failer.routeTo(nil)
+	if writer, ok := c.primary.writer.(*Writer); ok {
+		writer.routeTo(nil)
+	}
+}
+
+func (c *concurrentSpecs) newSuite() *Suite {
+	primary := c.primary
+	writer := NewWriter(nil)
+	writer.SetMode(WriterModeBufferOnly)
+	if primaryWriter, ok := primary.writer.(*Writer); ok {
+		writer = primaryWriter.newConcurrentSpecWriter()
+	}
+	// stdout and stderr are shared by every goroutine in the process so there is no way to attribute them to a particular Concurrent spec.  Concurrent specs don't intercept them - only GinkgoWriter output is captured.
+	return &Suite{
+		tree:                    primary.tree,
+		ProgressReporterManager: primary.ProgressReporterManager,
+		phase:                   primary.phase,
+		failer:                  NewFailer(),
+		reporter:                c.reporter,
+		writer:                  writer,
+		outputInterceptor:       NoopOutputInterceptor{},
+		interruptHandler:        primary.interruptHandler,
+		config:                  primary.config,
+		deadline:                primary.deadline,
+		selectiveLock:           &sync.Mutex{},
+		client:                  primary.client,
+		exclusiveResourceLocks:  primary.exclusiveResourceLocks,
+		concurrentSpecs:         c,
+		primary:                 primary,
+	}
+}
+
+// claimCurrentGoroutine attributes calls made on the current goroutine (and the goroutines it launches) to suite.  It returns a function that releases the claim.
+func (c *concurrentSpecs) claimCurrentGoroutine(suite *Suite) func() {
+	id := currentGoroutineID()
+	c.ownersLock.Lock()
+	c.owners[id] = suite
+	c.ownersLock.Unlock()
+	return func() {
+		c.ownersLock.Lock()
+		delete(c.owners, id)
+		c.ownersLock.Unlock()
+	}
+}
+
+// releaseAdoptedGoroutines forgets the bare goroutines that were attributed to suite - it is called when suite's spec finishes
+func (c *concurrentSpecs) releaseAdoptedGoroutines(suite *Suite) {
+	c.ownersLock.Lock()
+	defer c.ownersLock.Unlock()
+	for _, id := range c.adopted[suite] {
+		if c.owners[id] == suite {
+			delete(c.owners, id)
+		}
+	}
+	delete(c.adopted, suite)
+}
+
+/*
+suiteForCurrentGoroutine returns the suite of the Concurrent spec the current goroutine belongs to, or nil if it doesn't belong to one.
+
+The goroutine ID lookup is only performed while Concurrent specs are running.  A goroutine that hasn't been claimed is attributed to the spec that owns the goroutine that launched it (if any) - this covers goroutines launched with a bare go statement.  Such goroutines are adopted by the spec so that subsequent calls (and the goroutines they, in turn, launch) are attributed without walking the stack again.
+*/
+func (c *concurrentSpecs) suiteForCurrentGoroutine() *Suite {
+	c.ownersLock.Lock()
+	defer c.ownersLock.Unlock()
+	if len(c.owners) == 0 {
+		return nil
+	}
+	id := currentGoroutineID()
+	if suite, ok := c.owners[id]; ok {
+		return suite
+	}
+	suite, ok := c.owners[currentGoroutineParentID()]
+	if !ok {
+		return nil
+	}
+	c.owners[id] = suite
+	c.adopted[suite] = append(c.adopted[suite], id)
+	return suite
+}
+
+func (c *concurrentSpecs) runningSuites() []*Suite {
+	c.ownersLock.Lock()
+	defer c.ownersLock.Unlock()
+	suites, seen := []*Suite{}, map[*Suite]bool{}
+	for _, suite := range c.owners {
+		if !seen[suite] {
+			seen[suite] = true
+			suites = append(suites, suite)
+		}
+	}
+	return suites
+}
+
+/*
+Go does not expose goroutine IDs so we read them from the header of the current goroutine's stack trace (e.g. "goroutine 7 [running]:").  The ID of the goroutine that launched the current goroutine comes from the trailing "created by ... in goroutine N" line.
+
+Both are zero if the format ever changes.  Claimed goroutines then stop being attributed to their specs and calls made on them fall back to the primary suite - which is the same behavior you get when Concurrent specs aren't in play.
+*/
+var goroutinePrefix = []byte("goroutine ")
+var createdByPrefix = []byte("\ncreated by ")
+var parentGoroutinePrefix = []byte(" in goroutine ")
+
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return parseGoroutineID(bytes.TrimPrefix(buf, goroutinePrefix))
+}
+
+func currentGoroutineParentID() uint64 {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	createdBy := bytes.LastIndex(buf, createdByPrefix)
+	if createdBy == -1 {
+		return 0
+	}
+	line := buf[createdBy+len(createdByPrefix):]
+	if end := bytes.IndexByte(line, '\n'); end != -1 {
+		line = line[:end]
+	}
+	parent := bytes.Index(line, parentGoroutinePrefix)
+	if parent == -1 {
+		return 0
+	}
+	return parseGoroutineID(line[parent+len(parentGoroutinePrefix):])
+}
+
+func parseGoroutineID(b []byte) uint64 {
+	end := 0
+	for end < len(b) && b[end] >= '0' && b[end] <= '9' {
+		end++
+	}
+	id, _ := strconv.ParseUint(string(b[:end]), 10, 64)
+	return id
+}
+
+/*
+synchronizedReporter serializes calls to the suite's reporter while Concurrent specs are running.
+
+Like specs running in parallel processes, Concurrent specs don't stream their failures, report entries, and spec events as they happen - doing so would interleave the output of unrelated specs.  Instead, their timeline is reported in its entirety when they finish.
+*/
+type synchronizedReporter struct {
+	reporter       reporters.Reporter
+	lock           *sync.Mutex
+	dropLiveEvents bool
+}
+
+func (r synchronizedReporter) SuiteWillBegin(report types.Report) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.SuiteWillBegin(report)
+}
+
+func (r synchronizedReporter) WillRun(report types.SpecReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.WillRun(report)
+}
+
+func (r synchronizedReporter) DidRun(report types.SpecReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.DidRun(report)
+}
+
+func (r synchronizedReporter) SuiteDidEnd(report types.Report) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.SuiteDidEnd(report)
+}
+
+func (r synchronizedReporter) EmitFailure(state types.SpecState, failure types.Failure) {
+	if r.dropLiveEvents {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.EmitFailure(state, failure)
+}
+
+func (r synchronizedReporter) EmitProgressReport(progressReport types.ProgressReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.EmitProgressReport(progressReport)
+}
+
+func (r synchronizedReporter) EmitReportEntry(entry types.ReportEntry) {
+	if r.dropLiveEvents {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.EmitReportEntry(entry)
+}
+
+func (r synchronizedReporter) EmitSpecEvent(event types.SpecEvent) {
+	if r.dropLiveEvents {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.reporter.EmitSpecEvent(event)
+}
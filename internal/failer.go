@@ -11,6 +11,9 @@ type Failer struct {
 	lock    *sync.Mutex
 	failure types.Failure
 	state   types.SpecState
+
+	// route, when set, returns the Failer belonging to the Concurrent spec running on the calling goroutine (or nil if there isn't one)
+	route func() *Failer
 }
 
 func NewFailer() *Failer {
@@ -20,19 +23,41 @@ func NewFailer() *Failer {
 	}
 }
 
+func (f *Failer) routeTo(route func() *Failer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.route = route
+}
+
+func (f *Failer) routed() *Failer {
+	f.lock.Lock()
+	route := f.route
+	f.lock.Unlock()
+	if route == nil {
+		return f
+	}
+	if routed := route(); routed != nil {
+		return routed
+	}
+	return f
+}
+
 func (f *Failer) GetState() types.SpecState {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	return f.state
 }
 
 func (f *Failer) GetFailure() types.Failure {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 	return f.failure
 }
 
 func (f *Failer) Panic(location types.CodeLocation, forwardedPanic any) {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -47,6 +72,7 @@ func (f *Failer) Panic(location types.CodeLocation, forwardedPanic any) {
 }
 
 func (f *Failer) Fail(message string, location types.CodeLocation) {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -60,6 +86,7 @@ func (f *Failer) Fail(message string, location types.CodeLocation) {
 }
 
 func (f *Failer) Skip(message string, location types.CodeLocation) {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -73,6 +100,7 @@ func (f *Failer) Skip(message string, location types.CodeLocation) {
 }
 
 func (f *Failer) AbortSuite(message string, location types.CodeLocation) {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
@@ -86,6 +114,7 @@ func (f *Failer) AbortSuite(message string, location types.CodeLocation) {
 }
 
 func (f *Failer) Drain() (types.SpecState, types.Failure) {
+	f = f.routed()
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
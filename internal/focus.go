@@ -61,7 +61,7 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 	focusString := strings.Join(suiteConfig.FocusStrings, "|")
 	skipString := strings.Join(suiteConfig.SkipStrings, "|")
 
-	hasFocusCLIFlags := focusString != "" || skipString != "" || len(suiteConfig.SkipFiles) > 0 || len(suiteConfig.FocusFiles) > 0 || suiteConfig.LabelFilter != ""
+	hasFocusCLIFlags := focusString != "" || skipString != "" || len(suiteConfig.SkipFiles) > 0 || len(suiteConfig.FocusFiles) > 0 || len(suiteConfig.FocusSpecs) > 0 || suiteConfig.LabelFilter != "" || suiteConfig.SemVerFilter != ""
 
 	type SkipCheck func(spec Spec) bool
 
@@ -87,6 +87,11 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 		})
 	}
 
+	if suiteConfig.SemVerFilter != "" {
+		semVerFilter, _ := types.ParseSemVerFilter(suiteConfig.SemVerFilter)
+		skipChecks = append(skipChecks, func(spec Spec) bool { return !semVerFilter(spec.Nodes.UnionOfSemVerConstraints()) })
+	}
+
 	if len(suiteConfig.FocusFiles) > 0 {
 		focusFilters, _ := types.ParseFileFilters(suiteConfig.FocusFiles)
 		skipChecks = append(skipChecks, func(spec Spec) bool { return !focusFilters.Matches(spec.Nodes.CodeLocations()) })
@@ -97,6 +102,14 @@ func ApplyFocusToSpecs(specs Specs, description string, suiteLabels Labels, suit
 		skipChecks = append(skipChecks, func(spec Spec) bool { return skipFilters.Matches(spec.Nodes.CodeLocations()) })
 	}
 
+	if len(suiteConfig.FocusSpecs) > 0 {
+		focusSpecs, _ := types.ParseSpecIdentities(suiteConfig.FocusSpecs)
+		skipChecks = append(skipChecks, func(spec Spec) bool {
+			itNode := spec.FirstNodeWithType(types.NodeTypeIt)
+			return !focusSpecs.Matches(itNode.CodeLocation, specFullText(spec, itNode))
+		})
+	}
+
 	if focusString != "" {
 		// skip specs that don't match the focus string
 		re := regexp.MustCompile(focusString)
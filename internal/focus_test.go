@@ -233,6 +233,37 @@ var _ = Describe("Focus", func() {
 			})
 		})
 
+		Context("when configured to focus on specific specs", func() {
+			BeforeEach(func() {
+				specs = Specs{
+					S(N(ntCon, "con"), N(ntIt, "A", CL(`C:\file_a`, 1))),       //include because the file, line, and text match
+					S(N(ntCon, "con"), N(ntIt, "B", CL(`C:\file_a`, 1))),       //skip because the text doesn't match
+					S(N(ntCon, "con"), N(ntIt, "A", CL(`C:\file_a`, 2))),       //skip because the line doesn't match
+					S(N(ntCon, "con"), N(ntIt, "C", CL("file_b", 3))),          //include because the file, line, and text match
+					S(N(ntCon, "con"), N(ntIt, "C", CL("file_b", 3), Pending)), //skip because spec is flagged pending
+					S(N(ntCon, "con"), N(ntIt, "D", CL("file_b", 7))),          //skip because it is not one of the specs
+					S(N(ntCon, "con"), N(ntIt, "D", CL("file_b", 7), Focus)),   //skip because it is not one of the specs - override programmatic focus
+				}
+
+				conf.FocusSpecs = []string{
+					types.SpecIdentity{FileName: `C:\file_a`, LineNumber: 1, FullText: "con A"}.String(),
+					types.SpecIdentity{FileName: "file_b", LineNumber: 3, FullText: "con C"}.String(),
+				}
+			})
+
+			It("only runs the matching specs", func() {
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, true, true, false, true, true, true}))
+				Ω(hasProgrammaticFocus).Should(BeFalse())
+			})
+
+			It("intersects with the other filters", func() {
+				conf.FocusFiles = []string{"file_b"}
+				specs, _ := internal.ApplyFocusToSpecs(specs, description, suiteLabels, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{true, true, true, false, true, true, true}))
+			})
+		})
+
 		Context("when configured with a label filter", func() {
 			BeforeEach(func() {
 				conf.LabelFilter = "(cat || cow) && !fish"
@@ -254,6 +285,25 @@ var _ = Describe("Focus", func() {
 			})
 		})
 
+		Context("when configured with a semver filter", func() {
+			BeforeEach(func() {
+				conf.SemVerFilter = "2.3.1"
+				specs = Specs{
+					S(N(ntCon, SemVerConstraint(">= 2.1.0, < 3.0")), N(ntIt, "A")),                     //include because 2.3.1 is in range
+					S(N(ntCon, SemVerConstraint(">= 2.1.0")), N(ntIt, "B", SemVerConstraint("< 2.3"))), //skip because the spec's constraint is not satisfied
+					S(N(ntCon), N(ntIt, "C")),                                    //include because there are no constraints
+					S(N(ntCon), N(ntIt, "D", SemVerConstraint("^3"), Focus)),     //skip because not satisfied, override focus
+					S(N(ntCon), N(ntIt, "E", SemVerConstraint("~2.3"), Pending)), //skip because pending
+				}
+			})
+
+			It("applies the semver filter", func() {
+				specs, hasProgrammaticFocus := internal.ApplyFocusToSpecs(specs, description, suiteLabels, conf)
+				Ω(harvestSkips(specs)).Should(Equal([]bool{false, true, false, true, true}))
+				Ω(hasProgrammaticFocus).Should(BeFalse())
+			})
+		})
+
 		Context("when configured with a label filter that filters on the suite level label", func() {
 			BeforeEach(func() {
 				conf.LabelFilter = "cat && TopLevelLabel"
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -112,19 +113,24 @@ func newGroup(suite *Suite) *group {
 
 func (g *group) initialReportForSpec(spec Spec) types.SpecReport {
 	return types.SpecReport{
-		ContainerHierarchyTexts:     spec.Nodes.WithType(types.NodeTypeContainer).Texts(),
-		ContainerHierarchyLocations: spec.Nodes.WithType(types.NodeTypeContainer).CodeLocations(),
-		ContainerHierarchyLabels:    spec.Nodes.WithType(types.NodeTypeContainer).Labels(),
-		LeafNodeLocation:            spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation,
-		LeafNodeType:                types.NodeTypeIt,
-		LeafNodeText:                spec.FirstNodeWithType(types.NodeTypeIt).Text,
-		LeafNodeLabels:              []string(spec.FirstNodeWithType(types.NodeTypeIt).Labels),
-		ParallelProcess:             g.suite.config.ParallelProcess,
-		RunningInParallel:           g.suite.isRunningInParallel(),
-		IsSerial:                    spec.Nodes.HasNodeMarkedSerial(),
-		IsInOrderedContainer:        !spec.Nodes.FirstNodeMarkedOrdered().IsZero(),
-		MaxFlakeAttempts:            spec.Nodes.GetMaxFlakeAttempts(),
-		MaxMustPassRepeatedly:       spec.Nodes.GetMaxMustPassRepeatedly(),
+		ContainerHierarchyTexts:             spec.Nodes.WithType(types.NodeTypeContainer).Texts(),
+		ContainerHierarchyLocations:         spec.Nodes.WithType(types.NodeTypeContainer).CodeLocations(),
+		ContainerHierarchyLabels:            spec.Nodes.WithType(types.NodeTypeContainer).Labels(),
+		ContainerHierarchySemVerConstraints: spec.Nodes.WithType(types.NodeTypeContainer).SemVerConstraints(),
+		LeafNodeLocation:                    spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation,
+		LeafNodeType:                        types.NodeTypeIt,
+		LeafNodeText:                        spec.FirstNodeWithType(types.NodeTypeIt).Text,
+		LeafNodeLabels:                      []string(spec.FirstNodeWithType(types.NodeTypeIt).Labels),
+		LeafNodeSemVerConstraints:           []string(spec.FirstNodeWithType(types.NodeTypeIt).SemVerConstraints),
+		ParallelProcess:                     g.suite.config.ParallelProcess,
+		RunningInParallel:                   g.suite.isRunningInParallel() || g.suite.isRunningConcurrentSpec(),
+		IsSerial:                            spec.Nodes.HasNodeMarkedSerial(),
+		IsInOrderedContainer:                !spec.Nodes.FirstNodeMarkedOrdered().IsZero(),
+		ExclusiveResources:                  spec.Nodes.UnionOfExclusiveResources(),
+		MaxFlakeAttempts:                    spec.Nodes.GetMaxFlakeAttempts(),
+		MaxMustPassRepeatedly:               spec.Nodes.GetMaxMustPassRepeatedly(),
+		IsQuarantined:                       !spec.Quarantine.IsZero(),
+		QuarantineReason:                    spec.Quarantine.Reason,
 	}
 }
 
@@ -135,7 +141,7 @@ func (g *group) evaluateSkipStatus(spec Spec) (types.SpecState, types.Failure) {
 	if spec.Skip {
 		return types.SpecStateSkipped, types.Failure{}
 	}
-	if g.suite.interruptHandler.Status().Interrupted() || g.suite.skipAll {
+	if g.suite.interruptHandler.Status().Interrupted() || g.suite.shouldSkipAll() {
 		return types.SpecStateSkipped, types.Failure{}
 	}
 	if !g.suite.deadline.IsZero() && g.suite.deadline.Before(time.Now()) {
@@ -305,6 +311,15 @@ func (g *group) run(specs Specs) {
 		g.runOncePairs[spec.SubjectID()] = runOncePairsForSpec(spec)
 	}
 
+	// the specs in a group share a single lock on their exclusive resources - so an Ordered container holds the union of its specs' resources until all its specs have run
+	exclusiveResources := g.specs.UnionOfExclusiveResources()
+	exclusiveResourcesToken := 0
+	defer func() {
+		if exclusiveResourcesToken != 0 {
+			g.suite.releaseExclusiveResources(exclusiveResourcesToken)
+		}
+	}()
+
 	for _, spec := range g.specs {
 		g.suite.selectiveLock.Lock()
 		g.suite.currentSpecReport = g.initialReportForSpec(spec)
@@ -316,6 +331,21 @@ func (g *group) run(specs Specs) {
 
 		skip := g.suite.config.DryRun || g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates|types.SpecStateSkipped|types.SpecStatePending)
 
+		if !skip && len(exclusiveResources) > 0 && exclusiveResourcesToken == 0 {
+			token, waitTime, err := g.suite.acquireExclusiveResources(exclusiveResources)
+			g.suite.currentSpecReport.ExclusiveResourcesWaitTime = waitTime
+			if err != nil {
+				g.suite.currentSpecReport.State = types.SpecStateFailed
+				g.suite.currentSpecReport.Failure = g.suite.failureForLeafNodeWithMessage(spec.FirstNodeWithType(types.NodeTypeIt),
+					fmt.Sprintf("Failed to acquire exclusive resources %s:\n%s", strings.Join(exclusiveResources, ", "), err.Error()))
+				skip = true
+			} else if token == 0 {
+				g.suite.currentSpecReport.State = types.SpecStateSkipped
+				skip = true
+			}
+			exclusiveResourcesToken = token
+		}
+
 		g.suite.currentSpecReport.StartTime = time.Now()
 		failedInARunOnceBefore := false
 		if !skip {
@@ -367,9 +397,14 @@ func (g *group) run(specs Specs) {
 			}
 		}
 
+		// failures of quarantined specs don't fail the suite; interruptions and aborts still do
+		if g.suite.currentSpecReport.IsQuarantined && g.suite.currentSpecReport.State.Is(types.SpecStateFailed|types.SpecStatePanicked|types.SpecStateTimedout) {
+			g.suite.currentSpecReport.State = types.SpecStateQuarantined
+		}
+
 		g.suite.reportEach(spec, types.NodeTypeReportAfterEach)
 		g.suite.processCurrentSpecReport()
-		if g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
+		if g.suite.currentSpecReport.State.Is(types.SpecStateFailureStates | types.SpecStateQuarantined) {
 			g.succeeded = false
 			g.failedInARunOnceBefore = g.failedInARunOnceBefore || failedInARunOnceBefore
 		}
@@ -0,0 +1,253 @@
+package internal_integration_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Concurrent specs", func() {
+	// rendezvous returns a function that blocks until n specs have called it - and fails if they don't arrive in time
+	rendezvous := func(n int) func() {
+		arrived := &sync.WaitGroup{}
+		arrived.Add(n)
+		return func() {
+			arrived.Done()
+			done := make(chan bool)
+			go func() {
+				arrived.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				F("specs did not run concurrently")
+			}
+		}
+	}
+
+	Describe("running specs marked Concurrent", func() {
+		var lock *sync.Mutex
+		var currentSpecTexts map[string]string
+		BeforeEach(func() {
+			lock = &sync.Mutex{}
+			currentSpecTexts = map[string]string{}
+			recordCurrentSpecText := func(name string) {
+				lock.Lock()
+				defer lock.Unlock()
+				currentSpecTexts[name] = CurrentSpecReport().LeafNodeText
+			}
+			conf.Concurrency = 4
+			wait := rendezvous(4)
+
+			success, _ := RunFixture("concurrent specs", func() {
+				Describe("container", Concurrent, func() {
+					It("A", func() {
+						wait()
+						writer.Print("output from A")
+						By("stepping in A")
+						AddReportEntry("entry from A")
+						recordCurrentSpecText("A")
+					})
+					It("B", func() {
+						wait()
+						writer.Print("output from B")
+						recordCurrentSpecText("B")
+						F("B failed")
+					})
+					It("C", func() {
+						wait()
+						DeferCleanup(rt.T("cleanup C"))
+						done := make(chan bool)
+						GinkgoGo(func() {
+							defer close(done)
+							writer.Print("output from C's goroutine")
+							recordCurrentSpecText("C's goroutine")
+						})
+						<-done
+					})
+					It("D", func() {
+						wait()
+						done, nestedDone := make(chan bool), make(chan bool)
+						GinkgoGo(func() {
+							defer close(done)
+							GinkgoGo(func() {
+								defer close(nestedDone)
+								F("D's goroutine failed")
+							})
+							<-nestedDone
+						})
+						<-done
+					})
+					It("E", func() {
+						done := make(chan bool)
+						go func() {
+							defer GinkgoRecover()
+							defer close(done)
+							writer.Print("output from E's goroutine\n")
+							nestedDone := make(chan bool)
+							go func() {
+								defer close(nestedDone)
+								writer.Print("output from E's nested goroutine")
+							}()
+							<-nestedDone
+							F("E's goroutine failed")
+						}()
+						<-done
+					})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs the specs alongside one another", func() {
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(5), NPassed(2), NFailed(3)))
+		})
+
+		It("isolates each spec's failures and GinkgoWriter output", func() {
+			Ω(reporter.Did.Find("A")).Should(HavePassed(CapturedGinkgoWriterOutput("output from A")))
+			Ω(reporter.Did.Find("B")).Should(HaveFailed("B failed", CapturedGinkgoWriterOutput("output from B")))
+		})
+
+		It("attributes report entries and spec events to the spec that recorded them", func() {
+			Ω(reporter.Did.Find("A").ReportEntries).Should(HaveLen(1))
+			Ω(reporter.Did.Find("A").ReportEntries[0].Name).Should(Equal("entry from A"))
+			Ω(reporter.Did.Find("A").SpecEvents.WithType(types.SpecEventByStart)).Should(HaveLen(1))
+			Ω(reporter.Did.Find("B").ReportEntries).Should(BeEmpty())
+			Ω(reporter.Did.Find("B").SpecEvents.WithType(types.SpecEventByStart)).Should(BeEmpty())
+		})
+
+		It("returns the correct spec report from CurrentSpecReport", func() {
+			Ω(currentSpecTexts).Should(Equal(map[string]string{"A": "A", "B": "B", "C's goroutine": "C"}))
+		})
+
+		It("attributes work done in goroutines launched by the spec with GinkgoGo to the spec", func() {
+			Ω(reporter.Did.Find("C")).Should(HavePassed(CapturedGinkgoWriterOutput("output from C's goroutine")))
+			Ω(rt).Should(HaveTracked("cleanup C"))
+		})
+
+		It("attributes failures in goroutines launched by the spec with GinkgoGo to the spec", func() {
+			Ω(reporter.Did.Find("D")).Should(HaveFailed("D's goroutine failed"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+		})
+
+		It("attributes failures and GinkgoWriter output in goroutines launched by the spec with a bare go statement to the spec", func() {
+			Ω(reporter.Did.Find("E")).Should(HaveFailed("E's goroutine failed", CapturedGinkgoWriterOutput("output from E's goroutine\noutput from E's nested goroutine")))
+			Ω(reporter.Did.Find("A")).Should(HavePassed(CapturedGinkgoWriterOutput("output from A")))
+		})
+
+		It("reports the specs as running in parallel and does not stream their events", func() {
+			Ω(reporter.Did.Find("A").RunningInParallel).Should(BeTrue())
+			Ω(reporter.ReportEntries).Should(BeEmpty())
+			Ω(reporter.SpecEvents).Should(BeEmpty())
+			Ω(reporter.Failures).Should(BeEmpty())
+		})
+	})
+
+	Describe("specs that can't run concurrently", func() {
+		BeforeEach(func() {
+			var inFlight int32
+			run := func(name string) func() {
+				return func() {
+					rt.RunWithData(name, "in-flight", atomic.AddInt32(&inFlight, 1))
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt32(&inFlight, -1)
+				}
+			}
+			conf.Concurrency = 2
+			wait := rendezvous(2)
+
+			success, _ := RunFixture("mixed specs", func() {
+				Describe("container", func() {
+					It("A", Concurrent, func() {
+						wait()
+						run("A")()
+					})
+					It("B", Concurrent, func() {
+						wait()
+						run("B")()
+					})
+					It("C", run("C"))
+					It("D", Concurrent, Serial, run("D"))
+					Describe("ordered", Concurrent, Ordered, func() {
+						It("E", run("E"))
+						It("F", run("F"))
+					})
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs specs that aren't marked Concurrent, Serial specs, and specs in Ordered containers on their own", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("A", "B", "C", "D", "E", "F"))
+			Ω(rt.TrackedRuns()[2:]).Should(Equal([]string{"C", "D", "E", "F"}))
+			for _, name := range []string{"C", "D", "E", "F"} {
+				Ω(rt.DataFor(name)).Should(HaveKeyWithValue("in-flight", int32(1)), name)
+			}
+		})
+	})
+
+	Describe("limiting concurrency", func() {
+		var maxInFlight int32
+		BeforeEach(func() {
+			conf.Concurrency = 2
+			maxInFlight = 0
+			var inFlight int32
+			run := func() {
+				n := atomic.AddInt32(&inFlight, 1)
+				for {
+					current := atomic.LoadInt32(&maxInFlight)
+					if n <= current || atomic.CompareAndSwapInt32(&maxInFlight, current, n) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+			}
+
+			success, _ := RunFixture("limited concurrency", func() {
+				Describe("container", Concurrent, func() {
+					It("A", run)
+					It("B", run)
+					It("C", run)
+					It("D", run)
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs at most --concurrency specs at once", func() {
+			Ω(atomic.LoadInt32(&maxInFlight)).Should(Equal(int32(2)))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(4), NPassed(4)))
+		})
+	})
+
+	Describe("failing fast", func() {
+		BeforeEach(func() {
+			conf.Concurrency = 1
+			conf.FailFast = true
+			success, _ := RunFixture("fail fast", func() {
+				Describe("container", Concurrent, func() {
+					It("A", func() { F("A failed") })
+					It("B", rt.T("B"))
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("skips the remaining specs", func() {
+			Ω(reporter.Did.Find("A")).Should(HaveFailed("A failed"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+			Ω(rt).Should(HaveTrackedNothing())
+		})
+	})
+})
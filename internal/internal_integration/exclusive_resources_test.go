@@ -0,0 +1,174 @@
+package internal_integration_test
+
+import (
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/internal/interrupt_handler"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Exclusive resources", func() {
+	var inFlight, maxInFlight int32
+	// hold tracks the number of specs holding a resource and records the largest number seen at once
+	hold := func(duration time.Duration) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			current := atomic.LoadInt32(&maxInFlight)
+			if n <= current || atomic.CompareAndSwapInt32(&maxInFlight, current, n) {
+				break
+			}
+		}
+		time.Sleep(duration)
+		atomic.AddInt32(&inFlight, -1)
+	}
+
+	BeforeEach(func() {
+		inFlight, maxInFlight = 0, 0
+	})
+
+	Describe("when specs run concurrently", func() {
+		BeforeEach(func() {
+			conf.Concurrency = 4
+			arrived := make(chan bool)
+			success, _ := RunFixture("concurrent exclusive specs", func() {
+				Describe("container", Concurrent, func() {
+					Describe("database", Exclusive("database"), func() {
+						It("A", func() { hold(50 * time.Millisecond) })
+						It("B", Exclusive("cache"), func() { hold(50 * time.Millisecond) })
+					})
+					It("C", func() { arrived <- true })
+					It("D", func() {
+						select {
+						case <-arrived:
+						case <-time.After(time.Second):
+							F("specs without exclusive resources did not run concurrently")
+						}
+					})
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("never runs specs that share a resource at the same time", func() {
+			Ω(atomic.LoadInt32(&maxInFlight)).Should(Equal(int32(1)))
+			Ω(reporter.End).Should(BeASuiteSummary(true, NSpecs(4), NPassed(4)))
+		})
+
+		It("records the resources and wait time in the spec report", func() {
+			Ω(reporter.Did.Find("A").ExclusiveResources).Should(Equal([]string{"database"}))
+			Ω(reporter.Did.Find("B").ExclusiveResources).Should(Equal([]string{"database", "cache"}))
+			Ω(reporter.Did.Find("C").ExclusiveResources).Should(BeEmpty())
+
+			waitTimes := []time.Duration{reporter.Did.Find("A").ExclusiveResourcesWaitTime, reporter.Did.Find("B").ExclusiveResourcesWaitTime}
+			Ω(waitTimes).Should(ContainElement(BeNumerically(">=", 40*time.Millisecond)))
+			Ω(reporter.Did.Find("C").ExclusiveResourcesWaitTime).Should(BeZero())
+		})
+	})
+
+	Describe("progress reports", func() {
+		var firstToAcquire int32
+		BeforeEach(func() {
+			conf.Concurrency = 2
+			firstToAcquire = 0
+			spec := func() {
+				if atomic.CompareAndSwapInt32(&firstToAcquire, 0, 1) {
+					time.Sleep(100 * time.Millisecond)
+					triggerProgressSignal()
+				}
+			}
+			success, _ := RunFixture("progress reports while waiting", func() {
+				Describe("container", Concurrent, Exclusive("database"), func() {
+					It("A", spec)
+					It("B", spec)
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("reports the resources that a waiting spec is waiting for", func() {
+			var waiting types.ProgressReport
+			for _, report := range reporter.ProgressReports {
+				if len(report.WaitingForExclusiveResources) > 0 {
+					waiting = report
+				}
+			}
+			Ω(waiting.WaitingForExclusiveResources).Should(Equal([]string{"database"}))
+			Ω(waiting.ExclusiveResourcesWaitStartTime).ShouldNot(BeZero())
+			Ω(waiting.SpecStartTime).Should(BeZero())
+
+			waiter := reporter.Did.Find(waiting.LeafNodeText)
+			Ω(waiter.ExclusiveResourcesWaitTime).Should(BeNumerically(">=", 90*time.Millisecond))
+			Ω(waiter.ProgressReports).Should(HaveLen(1))
+		})
+	})
+
+	Describe("when the suite is interrupted while a spec is waiting", func() {
+		var firstToAcquire int32
+		BeforeEach(func() {
+			conf.Concurrency = 2
+			firstToAcquire = 0
+			spec := func(ctx SpecContext) {
+				if atomic.CompareAndSwapInt32(&firstToAcquire, 0, 1) {
+					time.Sleep(100 * time.Millisecond)
+					interruptHandler.Interrupt(interrupt_handler.InterruptCauseSignal)
+					<-ctx.Done()
+				}
+			}
+			success, _ := RunFixture("interrupted while waiting", func() {
+				Describe("container", Concurrent, Exclusive("database"), func() {
+					It("A", spec)
+					It("B", spec)
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("stops waiting and skips the spec", func() {
+			Ω(reporter.Did.WithState(types.SpecStateInterrupted)).Should(HaveLen(1))
+			Ω(reporter.Did.WithState(types.SpecStateSkipped)).Should(HaveLen(1))
+		})
+	})
+
+	Describe("Ordered containers", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("ordered", func() {
+				Describe("container", Ordered, func() {
+					It("A", Exclusive("database"), rt.T("A"))
+					It("B", Exclusive("cache"), rt.T("B"))
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("acquires the union of the container's resources before its first spec and holds them until the container finishes", func() {
+			Ω(rt).Should(HaveTracked("A", "B"))
+			Ω(reporter.Did.Find("A").ExclusiveResources).Should(Equal([]string{"database"}))
+			Ω(reporter.Did.Find("B").ExclusiveResources).Should(Equal([]string{"cache"}))
+		})
+	})
+
+	Describe("when running in parallel", func() {
+		BeforeEach(func() {
+			SetUpForParallel(2)
+			success := RunFixtureInParallel("parallel exclusive specs", func(_ int) {
+				Describe("container", Exclusive("database"), func() {
+					It("A", func() { hold(50 * time.Millisecond) })
+					It("B", func() { hold(50 * time.Millisecond) })
+					It("C", func() { hold(50 * time.Millisecond) })
+					It("D", func() { hold(50 * time.Millisecond) })
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("never runs specs that share a resource at the same time, even across processes", func() {
+			Ω(atomic.LoadInt32(&maxInFlight)).Should(Equal(int32(1)))
+			Ω(reporter.Did.WithState(types.SpecStatePassed)).Should(HaveLen(4))
+		})
+	})
+})
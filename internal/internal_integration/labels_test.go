@@ -123,4 +123,34 @@ var _ = Describe("Labels", func() {
 			Ω(report.PreRunStats.TotalSpecs).Should(Equal(2))
 		})
 	})
+
+	Describe("when a suite has specs with semver constraints", func() {
+		BeforeEach(func() {
+			conf.SemVerFilter = "2.3.1"
+			success, hPF := RunFixture("semver constrained tests", func() {
+				Describe("container", SemVerConstraint(">= 2.1.0, < 3.0"), func() {
+					It("A", rt.T("A"))
+					It("B", rt.T("B"), SemVerConstraint("< 2.3"))
+				})
+				It("C", rt.T("C"), SemVerConstraint("~2.3"))
+				It("D", rt.T("D"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(hPF).Should(BeFalse())
+		})
+
+		It("includes the constraints in the spec report", func() {
+			Ω(reporter.Did.Find("A").ContainerHierarchySemVerConstraints).Should(Equal([][]string{{">= 2.1.0, < 3.0"}}))
+			Ω(reporter.Did.Find("A").LeafNodeSemVerConstraints).Should(Equal([]string{}))
+			Ω(reporter.Did.Find("B").SemVerConstraints()).Should(Equal([]string{">= 2.1.0, < 3.0", "< 2.3"}))
+			Ω(reporter.Did.Find("C").SemVerConstraints()).Should(Equal([]string{"~2.3"}))
+			Ω(reporter.Did.Find("D").SemVerConstraints()).Should(BeEmpty())
+		})
+
+		It("honors the SemVerFilter config and skips tests appropriately", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("A", "C", "D"))
+			Ω(reporter.Did.Find("B")).Should(HaveBeenSkipped())
+			Ω(reporter.End).Should(BeASuiteSummary(true, NPassed(3), NSkipped(1), NSpecs(4), NWillRun(3)))
+		})
+	})
 })
@@ -0,0 +1,287 @@
+package internal_integration_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Measurements", func() {
+	Describe("sampling", func() {
+		var indices []int
+		var durations, values Measurement
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			indices = []int{}
+			success, _ := RunFixture("sampling", func() {
+				It("samples durations", func() {
+					cl = types.NewCodeLocation(0)
+					durations = SampleDuration("sleeping", func(idx int) {
+						indices = append(indices, idx)
+						time.Sleep(time.Millisecond)
+					}, SamplingConfig{N: 5})
+				})
+				It("samples values", func() {
+					values = SampleValue("memory", func(idx int) float64 {
+						return float64(idx * 10)
+					}, SamplingConfig{N: 4}, MeasurementUnits("MB"), ReportEntryVisibilityFailureOrVerbose)
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("calls the body N times", func() {
+			Ω(indices).Should(Equal([]int{0, 1, 2, 3, 4}))
+		})
+
+		It("returns the Measurement", func() {
+			Ω(durations.Type).Should(Equal(types.MeasurementTypeDuration))
+			Ω(durations.Name).Should(Equal("sleeping"))
+			Ω(durations.Samples).Should(HaveLen(5))
+			Ω(durations.Stats.N).Should(Equal(5))
+			Ω(time.Duration(durations.Stats.Min)).Should(BeNumerically(">=", time.Millisecond))
+
+			Ω(values).Should(Equal(types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{0, 10, 20, 30})))
+			Ω(values.Stats.Mean).Should(Equal(15.0))
+		})
+
+		It("attaches the Measurement to the spec as a report entry", func() {
+			entries := reporter.Did.Find("samples durations").ReportEntries
+			Ω(entries).Should(HaveLen(1))
+			Ω(entries[0].Name).Should(Equal("sleeping"))
+			Ω(entries[0].Location).Should(Equal(types.CodeLocation{FileName: cl.FileName, LineNumber: cl.LineNumber + 1}))
+			Ω(entries[0].Visibility).Should(Equal(types.ReportEntryVisibilityAlways))
+			Ω(entries[0].Value.GetRawValue()).Should(Equal(durations))
+			Ω(entries[0].Value.String()).Should(Equal(durations.ColorableString()))
+
+			entries = reporter.Did.Find("samples values").ReportEntries
+			Ω(entries).Should(HaveLen(1))
+			Ω(entries[0].Visibility).Should(Equal(types.ReportEntryVisibilityFailureOrVerbose))
+			Ω(entries[0].Value.GetRawValue()).Should(Equal(values))
+		})
+	})
+
+	Describe("sampling for a duration", func() {
+		var count int
+		BeforeEach(func() {
+			count = 0
+			success, _ := RunFixture("sampling for a duration", func() {
+				It("samples until the duration elapses", func() {
+					SampleDuration("sleeping", func(_ int) {
+						count++
+						time.Sleep(20 * time.Millisecond)
+					}, SamplingConfig{N: 1000, Duration: 100 * time.Millisecond})
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("stops sampling once the duration has elapsed", func() {
+			Ω(count).Should(BeNumerically(">", 1))
+			Ω(count).Should(BeNumerically("<", 1000))
+			Ω(reporter.Did.Find("samples until the duration elapses").ReportEntries[0].Value.GetRawValue().(Measurement).Stats.N).Should(Equal(count))
+		})
+	})
+
+	Describe("using Offset", func() {
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			helper := func() {
+				SampleValue("offset", func(_ int) float64 { return 1 }, SamplingConfig{N: 1}, Offset(1))
+			}
+			success, _ := RunFixture("offset", func() {
+				It("samples in a helper", func() {
+					cl = types.NewCodeLocation(0)
+					helper()
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("adjusts the report entry's location", func() {
+			Ω(reporter.Did.Find("samples in a helper").ReportEntries[0].Location).Should(Equal(types.CodeLocation{FileName: cl.FileName, LineNumber: cl.LineNumber + 1}))
+		})
+	})
+
+	Describe("when the SamplingConfig is empty", func() {
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			success, _ := RunFixture("empty config", func() {
+				It("fails", func() {
+					cl = types.NewCodeLocation(0)
+					SampleValue("nothing", func(_ int) float64 { return 1 }, SamplingConfig{})
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec at the call site", func() {
+			Ω(reporter.Did.Find("fails")).Should(HaveFailed("SamplingConfig must specify a positive N or Duration"))
+			Ω(reporter.Did.Find("fails").Failure.Location.LineNumber).Should(Equal(cl.LineNumber + 1))
+			Ω(reporter.Did.Find("fails").ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Describe("when HigherIsBetter is passed to SampleDuration", func() {
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			success, _ := RunFixture("higher is better durations", func() {
+				It("fails", func() {
+					cl = types.NewCodeLocation(0)
+					SampleDuration("sorting", func(_ int) {}, SamplingConfig{N: 1}, HigherIsBetter)
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec at the call site", func() {
+			Ω(reporter.Did.Find("fails")).Should(HaveFailed("HigherIsBetter can only be passed to SampleValue"))
+			Ω(reporter.Did.Find("fails").Failure.Location.LineNumber).Should(Equal(cl.LineNumber + 1))
+		})
+	})
+
+	Describe("comparing against a baseline", func() {
+		var path string
+		var memory float64
+		fixture := func() {
+			Describe("cache", func() {
+				It("fills", func() {
+					SampleValue("memory", func(_ int) float64 { return memory }, SamplingConfig{N: 3})
+				})
+				It("fails", func() {
+					SampleValue("memory", func(_ int) float64 { return memory }, SamplingConfig{N: 3})
+					F("boom")
+				})
+			})
+		}
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "baseline.json")
+			memory = 10
+			conf.SaveMeasurementBaseline = path
+			RunFixture("saving a baseline", fixture)
+			conf.SaveMeasurementBaseline = ""
+		})
+
+		It("saves the Measurements recorded by passing specs", func() {
+			baseline, err := types.LoadMeasurementBaseline(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(baseline).Should(HaveLen(1))
+			Ω(baseline["cache fills"]["memory"].Stats.Median).Should(Equal(10.0))
+		})
+
+		It("does not compare Measurements when no baseline is provided", func() {
+			Ω(reporter.End.MeasurementComparisons).Should(BeEmpty())
+		})
+
+		Context("when the Measurements are within tolerance", func() {
+			BeforeEach(func() {
+				memory = 10.5
+				conf.MeasurementBaseline = path
+				RunFixture("comparing to a baseline", fixture)
+			})
+
+			It("reports the comparisons and does not fail the suite", func() {
+				Ω(reporter.End.MeasurementComparisons).Should(Equal(types.MeasurementComparisons{
+					{SpecText: "cache fills", Name: "memory", Type: types.MeasurementTypeValue, Baseline: 10, Current: 10.5, Change: 0.05},
+				}))
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(BeEmpty())
+			})
+		})
+
+		Context("when a Measurement regresses", func() {
+			BeforeEach(func() {
+				memory = 12
+				conf.MeasurementBaseline = path
+			})
+
+			It("fails the suite", func() {
+				RunFixture("comparing to a baseline", fixture)
+				Ω(reporter.End.MeasurementComparisons.Regressions()).Should(HaveLen(1))
+				Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(2), NPassed(1), NFailed(1)))
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ConsistOf("Measurements regressed relative to the baseline"))
+			})
+
+			It("honors --measurement-tolerance", func() {
+				conf.MeasurementTolerance = 0.5
+				RunFixture("comparing to a baseline", fixture)
+				Ω(reporter.End.MeasurementComparisons.Regressions()).Should(BeEmpty())
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(BeEmpty())
+			})
+		})
+
+		Context("when higher values are better", func() {
+			var throughput float64
+			higherIsBetterFixture := func() {
+				It("serves", func() {
+					SampleValue("throughput", func(_ int) float64 { return throughput }, SamplingConfig{N: 3}, MeasurementUnits("req/s"), HigherIsBetter)
+				})
+			}
+
+			BeforeEach(func() {
+				throughput = 100
+				conf.SaveMeasurementBaseline = path
+				RunFixture("saving a higher-is-better baseline", higherIsBetterFixture)
+				conf.SaveMeasurementBaseline = ""
+				conf.MeasurementBaseline = path
+			})
+
+			It("records the direction in the baseline", func() {
+				baseline, err := types.LoadMeasurementBaseline(path)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(baseline["serves"]["throughput"].HigherIsBetter).Should(BeTrue())
+			})
+
+			It("does not treat an increase as a regression", func() {
+				throughput = 150
+				RunFixture("comparing an improvement", higherIsBetterFixture)
+				Ω(reporter.End.MeasurementComparisons).Should(Equal(types.MeasurementComparisons{
+					{SpecText: "serves", Name: "throughput", Type: types.MeasurementTypeValue, Units: "req/s", HigherIsBetter: true, Baseline: 100, Current: 150, Change: 0.5},
+				}))
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(BeEmpty())
+			})
+
+			It("treats a decrease as a regression", func() {
+				throughput = 80
+				RunFixture("comparing a regression", higherIsBetterFixture)
+				Ω(reporter.End.MeasurementComparisons.Regressions()).Should(HaveLen(1))
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ConsistOf("Measurements regressed relative to the baseline"))
+			})
+		})
+
+		Context("when the baseline can't be loaded", func() {
+			BeforeEach(func() {
+				Ω(os.WriteFile(path, []byte("nope"), 0644)).Should(Succeed())
+				conf.MeasurementBaseline = path
+				RunFixture("comparing to a bad baseline", fixture)
+			})
+
+			It("fails the suite", func() {
+				Ω(reporter.End.SpecialSuiteFailureReasons).Should(ConsistOf(ContainSubstring("Could not load measurement baseline")))
+			})
+		})
+
+		Context("when comparing and saving in the same run", func() {
+			BeforeEach(func() {
+				memory = 20
+				conf.MeasurementBaseline = path
+				conf.SaveMeasurementBaseline = path
+				RunFixture("comparing and saving", fixture)
+			})
+
+			It("compares against the previous baseline before overwriting it", func() {
+				Ω(reporter.End.MeasurementComparisons).Should(HaveLen(1))
+				Ω(reporter.End.MeasurementComparisons[0].Baseline).Should(Equal(10.0))
+				baseline, err := types.LoadMeasurementBaseline(path)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(baseline["cache fills"]["memory"].Stats.Median).Should(Equal(20.0))
+			})
+		})
+	})
+})
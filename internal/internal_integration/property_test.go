@@ -0,0 +1,267 @@
+package internal_integration_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Property-based specs", func() {
+	Describe("when the property holds", func() {
+		var counts map[string]int
+		BeforeEach(func() {
+			counts = map[string]int{}
+			success, _ := RunFixture("passing properties", func() {
+				ItForAll("default", GenInt(0, 10), GenString(5), func(i int, s string) {
+					counts["default"]++
+					if i < 0 || i > 10 || len(s) > 5 {
+						F("out of range")
+					}
+				})
+				ItForAll("decorated", GenBool(), PropertyIterations(7), Label("prop"), func(b bool) {
+					counts["decorated"]++
+				})
+				ItForAll("with context", GenFloat64(1, 2), func(ctx SpecContext, f float64) {
+					counts["with context"]++
+					if ctx == nil || f < 1 || f >= 2 {
+						F("bad")
+					}
+				})
+				ItForAll("with context.Context", GenOneOf("a", "b"), func(ctx context.Context, s string) {
+					counts["with context.Context"]++
+				})
+				PItForAll("pending", GenBool(), func(b bool) {
+					counts["pending"]++
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("runs the body once per iteration with generated inputs", func() {
+			Ω(counts).Should(Equal(map[string]int{
+				"default":              100,
+				"decorated":            7,
+				"with context":         100,
+				"with context.Context": 100,
+			}))
+			Ω(reporter.Did.Find("decorated").Labels()).Should(Equal([]string{"prop"}))
+			Ω(reporter.Did.Find("pending")).Should(BePending())
+			Ω(reporter.Did.Find("default").ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Describe("when the property fails", func() {
+		var runs []int
+		BeforeEach(func() {
+			runs = []int{}
+			success, _ := RunFixture("failing properties", func() {
+				ItForAll("shrinks ints", GenInt(0, 1000), func(i int) {
+					runs = append(runs, i)
+					if i >= 50 {
+						F(fmt.Sprintf("%d is too big", i))
+					}
+				})
+				ItForAll("shrinks slices", GenSliceOf(GenInt(-100, 100), 10), func(s []int) {
+					for _, n := range s {
+						if n > 10 {
+							F("element is too big")
+						}
+					}
+				})
+				ItForAll("shrinks panics", GenString(20), func(s string) {
+					if len(s) > 2 {
+						panic("boom")
+					}
+				})
+				ItForAll("shrinks each input independently", GenBool(), GenInt(0, 1), func(b bool, i int) {
+					if b {
+						F("b is true")
+					}
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("reports the failure for the minimal counterexample", func() {
+			Ω(reporter.Did.Find("shrinks ints")).Should(HaveFailed("50 is too big"))
+			Ω(runs[len(runs)-1]).Should(Equal(50))
+			Ω(reporter.Did.Find("shrinks slices")).Should(HaveFailed("element is too big"))
+			Ω(reporter.Did.Find("shrinks panics")).Should(HavePanicked("boom"))
+			Ω(reporter.Did.Find("shrinks each input independently")).Should(HaveFailed("b is true"))
+		})
+
+		It("records the minimal counterexample as a report entry", func() {
+			entries := reporter.Did.Find("shrinks ints").ReportEntries
+			Ω(entries).Should(HaveLen(1))
+			Ω(entries[0].Name).Should(Equal("Counterexample"))
+			counterexample := entries[0].Value.GetRawValue().(PropertyCounterexample)
+			Ω(counterexample.Inputs).Should(Equal([]string{"50"}))
+			Ω(counterexample.Iteration).Should(BeNumerically(">=", 1))
+			Ω(counterexample.Shrinks).Should(BeNumerically(">", 0))
+			Ω(entries[0].Value.String()).Should(HavePrefix(fmt.Sprintf("failed on iteration %d, shrunk %d times:", counterexample.Iteration, counterexample.Shrinks)))
+			Ω(entries[0].Value.String()).Should(HaveSuffix("\n  #1: 50"))
+
+			counterexample = reporter.Did.Find("shrinks slices").ReportEntries[0].Value.GetRawValue().(PropertyCounterexample)
+			Ω(counterexample.Inputs).Should(Equal([]string{"[]int{11}"}))
+
+			counterexample = reporter.Did.Find("shrinks panics").ReportEntries[0].Value.GetRawValue().(PropertyCounterexample)
+			Ω(counterexample.Inputs).Should(Equal([]string{`"aaa"`}))
+
+			counterexample = reporter.Did.Find("shrinks each input independently").ReportEntries[0].Value.GetRawValue().(PropertyCounterexample)
+			Ω(counterexample.Inputs).Should(Equal([]string{"true", "0"}))
+		})
+	})
+
+	Describe("reproducibility", func() {
+		var generated map[string][]int
+		var fixture = func() {
+			ItForAll("A", GenInt(0, 1000000), PropertyIterations(5), func(i int) {
+				generated["A"] = append(generated["A"], i)
+			})
+			ItForAll("B", GenInt(0, 1000000), PropertyIterations(5), func(i int) {
+				generated["B"] = append(generated["B"], i)
+			})
+		}
+
+		It("derives the inputs from the random seed and the spec's identity", func() {
+			generated = map[string][]int{}
+			RunFixture("first run", fixture)
+			first := generated
+
+			generated = map[string][]int{}
+			RunFixture("second run", fixture)
+			Ω(generated).Should(Equal(first))
+			Ω(generated["A"]).ShouldNot(Equal(generated["B"]))
+		})
+	})
+
+	Describe("when the body skips", func() {
+		var count int
+		BeforeEach(func() {
+			count = 0
+			success, _ := RunFixture("skipping properties", func() {
+				ItForAll("skips", GenInt(0, 10), func(i int) {
+					count++
+					Skip("not today")
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("stops iterating and skips the spec", func() {
+			Ω(count).Should(Equal(1))
+			Ω(reporter.Did.Find("skips")).Should(HaveBeenSkippedWithMessage("not today"))
+		})
+	})
+
+	Describe("when the body skips from a goroutine", func() {
+		var count int
+		BeforeEach(func() {
+			count = 0
+			success, _ := RunFixture("skipping properties from a goroutine", func() {
+				ItForAll("skips", GenInt(0, 10), func(i int) {
+					count++
+					done := make(chan any)
+					go func() {
+						defer GinkgoRecover()
+						defer close(done)
+						Skip("not today")
+					}()
+					<-done
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("stops iterating and skips the spec", func() {
+			Ω(count).Should(Equal(1))
+			Ω(reporter.Did.Find("skips")).Should(HaveBeenSkippedWithMessage("not today"))
+			Ω(reporter.Did.Find("skips").ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Describe("when the counterexample passes when it is replayed", func() {
+		var count int
+		BeforeEach(func() {
+			count = 0
+			success, _ := RunFixture("flaky properties", func() {
+				ItForAll("flaky", GenInt(0, 10), func(i int) {
+					count++
+					if count == 1 {
+						F("flake")
+					}
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the spec, explaining that the property is not deterministic", func() {
+			Ω(reporter.Did.Find("flaky")).Should(HaveFailed(ContainSubstring("passed when the counterexample was replayed")))
+			Ω(reporter.Did.Find("flaky").Failure.Location.FileName).Should(HaveSuffix("property_test.go"))
+			Ω(reporter.Did.Find("flaky").ReportEntries).Should(HaveLen(1))
+		})
+	})
+
+	Describe("report entries, By steps, and GinkgoWriter output from the body", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("noisy properties", func() {
+				ItForAll("holds", GenInt(0, 10), func(i int) {
+					By(fmt.Sprintf("checking %d", i))
+					writer.Printf("input: %d\n", i)
+					AddReportEntry("input", i)
+				})
+				ItForAll("fails", GenInt(0, 1000), func(i int) {
+					By(fmt.Sprintf("checking %d", i))
+					writer.Printf("input: %d\n", i)
+					AddReportEntry("input", i)
+					if i >= 50 {
+						F("too big")
+					}
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("discards them for every run but the final run against the counterexample", func() {
+			Ω(reporter.Did.Find("holds")).Should(HavePassed(CapturedGinkgoWriterOutput("")))
+			Ω(reporter.Did.Find("holds").ReportEntries).Should(BeEmpty())
+			Ω(reporter.Did.Find("holds").SpecEvents.WithType(types.SpecEventByStart)).Should(BeEmpty())
+
+			Ω(reporter.Did.Find("fails")).Should(HaveFailed("too big", CapturedGinkgoWriterOutput("input: 50\n")))
+			entries := reporter.Did.Find("fails").ReportEntries
+			Ω(entries).Should(HaveLen(2))
+			Ω(entries[0].Name).Should(Equal("Counterexample"))
+			Ω(entries[1].Name).Should(Equal("input"))
+			Ω(entries[1].Value.GetRawValue()).Should(Equal(50))
+			byEvents := reporter.Did.Find("fails").SpecEvents.WithType(types.SpecEventByStart)
+			Ω(byEvents).Should(HaveLen(1))
+			Ω(byEvents[0].Message).Should(Equal("checking 50"))
+		})
+	})
+
+	Describe("when the spec times out", func() {
+		var count int
+		BeforeEach(func() {
+			count = 0
+			success, _ := RunFixture("timing out properties", func() {
+				ItForAll("times out", GenInt(0, 10), NodeTimeout(50*time.Millisecond), func(ctx SpecContext, i int) {
+					count++
+					<-ctx.Done()
+				})
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("stops iterating", func() {
+			Ω(count).Should(Equal(1))
+			Ω(reporter.Did.Find("times out")).Should(HaveTimedOut())
+			Ω(reporter.Did.Find("times out").ReportEntries).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package internal_integration_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Quarantining specs with --quarantine-file", func() {
+	var success bool
+	var counterB int
+
+	var fixture = func() {
+		Describe("container", func() {
+			It("A", rt.T("A", func() { F("fail A") }))
+			It("B", rt.T("B", func() {
+				counterB += 1
+				if counterB < 3 {
+					F(fmt.Sprintf("fail B - %d", counterB))
+				}
+			}))
+			It("C", rt.T("C", func() { F("fail C") }))
+			It("D", rt.T("D"))
+		})
+	}
+
+	writeQuarantine := func(entries string) {
+		conf.QuarantineFile = filepath.Join(GinkgoT().TempDir(), "quarantine.json")
+		Ω(os.WriteFile(conf.QuarantineFile, []byte(entries), 0644)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		counterB = 0
+		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		nextYear := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+		writeQuarantine(fmt.Sprintf(`[
+			{"Spec": "container A", "Reason": "tracked in #42", "Expires": "%s"},
+			{"Spec": "container B", "FlakeAttempts": 3},
+			{"Spec": "container C", "Reason": "expired", "Expires": "%s"},
+			{"Spec": "container D", "Reason": "passes anyway"}
+		]`, nextYear, yesterday))
+		success, _ = RunFixture("quarantine", fixture)
+	})
+
+	It("runs every spec", func() {
+		Ω(rt).Should(HaveTracked("A", "B", "B", "B", "C", "D"))
+	})
+
+	It("reports failures of quarantined specs as quarantined", func() {
+		Ω(reporter.Did.Find("A")).Should(HaveBeenQuarantined("fail A"))
+		Ω(reporter.Did.Find("A").IsQuarantined).Should(BeTrue())
+		Ω(reporter.Did.Find("A").QuarantineReason).Should(Equal("tracked in #42"))
+	})
+
+	It("retries specs with FlakeAttempts entries", func() {
+		Ω(reporter.Did.Find("B")).Should(HavePassed(NumAttempts(3)))
+		Ω(reporter.Did.Find("B").MaxFlakeAttempts).Should(Equal(3))
+		Ω(reporter.Did.Find("B").IsQuarantined).Should(BeFalse())
+	})
+
+	It("ignores expired entries", func() {
+		Ω(reporter.Did.Find("C")).Should(HaveFailed("fail C"))
+		Ω(reporter.Did.Find("C").IsQuarantined).Should(BeFalse())
+	})
+
+	It("reports quarantined specs that pass as passed", func() {
+		Ω(reporter.Did.Find("D")).Should(HavePassed())
+		Ω(reporter.Did.Find("D").IsQuarantined).Should(BeTrue())
+	})
+
+	It("fails the suite only because of the unquarantined failure", func() {
+		Ω(success).Should(BeFalse())
+		Ω(reporter.Did.WithState(types.SpecStateFailed).Names()).Should(ConsistOf("C"))
+		Ω(reporter.Did.WithState(types.SpecStateQuarantined).Names()).Should(ConsistOf("A"))
+	})
+})
+
+var _ = Describe("when every failure is quarantined", func() {
+	It("succeeds", func() {
+		conf.QuarantineFile = filepath.Join(GinkgoT().TempDir(), "quarantine.json")
+		Ω(os.WriteFile(conf.QuarantineFile, []byte(`[{"Spec": "A"}]`), 0644)).Should(Succeed())
+		success, _ := RunFixture("all quarantined", func() {
+			It("A", rt.T("A", func() { F("fail A") }))
+			It("B", rt.T("B"))
+		})
+		Ω(success).Should(BeTrue())
+		Ω(reporter.End.SuiteSucceeded).Should(BeTrue())
+		Ω(reporter.Did.Find("A")).Should(HaveBeenQuarantined("fail A"))
+	})
+})
+
+var _ = Describe("when the quarantine file can't be loaded", func() {
+	It("fails the suite without running any specs", func() {
+		conf.QuarantineFile = filepath.Join(GinkgoT().TempDir(), "missing.json")
+		success, _ := RunFixture("missing quarantine file", func() {
+			It("A", rt.T("A"))
+		})
+		Ω(success).Should(BeFalse())
+		Ω(rt).Should(HaveTrackedNothing())
+		Ω(reporter.End.SpecialSuiteFailureReasons).Should(ContainElement(ContainSubstring("missing.json")))
+	})
+})
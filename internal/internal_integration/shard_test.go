@@ -0,0 +1,68 @@
+package internal_integration_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Sharding Tests", func() {
+	var fixture = func() {
+		BeforeSuite(rt.T("before-suite"))
+		It("A", rt.T("A"))
+		It("B", rt.T("B"))
+		Describe("ordered-container", Ordered, func() {
+			It("C", rt.T("C"))
+			It("D", rt.T("D"))
+		})
+		It("E", rt.T("E"))
+		It("F", rt.T("F"), Pending)
+		AfterSuite(rt.T("after-suite"))
+	}
+
+	runShard := func(shard string) []string {
+		rt.Reset()
+		reporter = NewFakeReporter()
+		conf.Shard = shard
+		success, _ := RunFixture("shard "+shard, fixture)
+		Ω(success).Should(BeTrue())
+		return rt.TrackedRuns()
+	}
+
+	It("runs each spec in exactly one shard", func() {
+		ranSpecs, shardsBySpec := []string{}, map[string]string{}
+		for _, shard := range []string{"1/2", "2/2"} {
+			runs := runShard(shard)
+			passed := reporter.Did.WithLeafNodeType(types.NodeTypeIt).WithState(types.SpecStatePassed).Names()
+			ranSpecs = append(ranSpecs, passed...)
+			for _, name := range passed {
+				shardsBySpec[name] = shard
+			}
+			Ω(reporter.Begin.PreRunStats.TotalSpecs).Should(Equal(6))
+			Ω(reporter.Begin.PreRunStats.SpecsThatWillRun).Should(Equal(len(passed)))
+			Ω(reporter.Did.WithLeafNodeType(types.NodeTypeIt)).Should(HaveLen(6 - reporter.Begin.PreRunStats.SpecsInOtherShards))
+			if len(passed) > 0 {
+				Ω(runs).Should(ContainElements("before-suite", "after-suite"))
+			}
+		}
+		Ω(ranSpecs).Should(ConsistOf("A", "B", "C", "D", "E"))
+		Ω(shardsBySpec["C"]).Should(Equal(shardsBySpec["D"]))
+	})
+
+	It("doesn't run the suite-level nodes when no specs will run in the shard", func() {
+		emptyShards := 0
+		for i := 1; i <= 8; i++ {
+			runs := runShard(fmt.Sprintf("%d/8", i))
+			if reporter.End.PreRunStats.SpecsThatWillRun == 0 {
+				emptyShards += 1
+				Ω(runs).Should(BeEmpty())
+				Ω(reporter.End.SpecReports.WithLeafNodeType(types.NodeTypeIt).WithState(types.SpecStatePassed)).Should(BeEmpty())
+			}
+		}
+		Ω(emptyShards).Should(BeNumerically(">=", 3))
+	})
+})
@@ -0,0 +1,199 @@
+package internal_integration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("Table entries loaded from files", func() {
+	var dir string
+
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		Ω(os.WriteFile(path, []byte(content), 0644)).Should(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	Describe("loading JSON files", func() {
+		var path string
+		BeforeEach(func() {
+			path = writeFile("cases.json", `[
+  [1, 1, true],
+  {"description": "unequal", "parameters": [1, 2, false]},
+  {"parameters": [3, 3, true]},
+
+  [2, 3, true]
+]`)
+			success, _ := RunFixture("json entries", func() {
+				DescribeTable("json", func(a, b int, expected bool) {
+					rt.Run(CurrentSpecReport().LeafNodeText)
+					if (a == b) != expected {
+						F("mismatch")
+					}
+				},
+					EntriesFromFile(path, Label("from-json")),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("generates an entry per row, using the table-level description when a row has none", func() {
+			Ω(rt).Should(HaveTracked("Entry: 1, 1, true", "unequal", "Entry: 3, 3, true", "Entry: 2, 3, true"))
+			Ω(reporter.Did.Find("Entry: 2, 3, true")).Should(HaveFailed("mismatch"))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(4), NPassed(3), NFailed(1)))
+		})
+
+		It("points each entry's code location at its row", func() {
+			Ω(reporter.Did.Find("Entry: 1, 1, true").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 2}))
+			Ω(reporter.Did.Find("unequal").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 3}))
+			Ω(reporter.Did.Find("Entry: 3, 3, true").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 4}))
+			Ω(reporter.Did.Find("Entry: 2, 3, true").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 6}))
+		})
+
+		It("applies the decorators to every entry", func() {
+			for _, report := range reporter.Did {
+				Ω(report.Labels()).Should(Equal([]string{"from-json"}))
+			}
+		})
+	})
+
+	Describe("decoding JSON into the body's parameter types", func() {
+		BeforeEach(func() {
+			path := writeFile("cases.json", `[
+  {"description": "structs", "parameters": [{"A": 1, "B": 2}, [3, 4], "x"]},
+  {"description": "variadic", "parameters": [{"A": 1, "B": 2}, [3, 4], "x", "y", "z"]}
+]`)
+			success, _ := RunFixture("json decoding", func() {
+				DescribeTable("json", func(p pair, s []int, strs ...string) {
+					rt.RunWithData(CurrentSpecReport().LeafNodeText, "p", p, "s", s, "strs", strs)
+				},
+					EntriesFromFile(path),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("decodes each parameter into the type expected by the body", func() {
+			Ω(rt.DataFor("structs")).Should(Equal(map[string]any{"p": pair{1, 2}, "s": []int{3, 4}, "strs": []string{"x"}}))
+			Ω(rt.DataFor("variadic")).Should(Equal(map[string]any{"p": pair{1, 2}, "s": []int{3, 4}, "strs": []string{"x", "y", "z"}}))
+		})
+	})
+
+	Describe("loading CSV files", func() {
+		var path string
+		BeforeEach(func() {
+			path = writeFile("cases.csv", `name,count,enabled,ratio,timeout,description
+alpha,1,true,0.5,1s,
+beta,0x10,false,1.5,100ms,second row
+"multi
+line",3,true,2,1m,third row
+`)
+			success, _ := RunFixture("csv entries", func() {
+				DescribeTable("csv", func(name string, count int, enabled bool, ratio float64, timeout time.Duration) {
+					rt.RunWithData(CurrentSpecReport().LeafNodeText, "name", name, "count", count, "enabled", enabled, "ratio", ratio, "timeout", timeout)
+				},
+					EntryDescription("%[1]s-%[2]d"),
+					EntriesFromFile(path),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("uses the description column and passes the remaining columns as parameters", func() {
+			Ω(rt).Should(HaveTracked("alpha-1", "second row", "third row"))
+			Ω(rt.DataFor("alpha-1")).Should(Equal(map[string]any{"name": "alpha", "count": 1, "enabled": true, "ratio": 0.5, "timeout": time.Second}))
+			Ω(rt.DataFor("second row")).Should(Equal(map[string]any{"name": "beta", "count": 16, "enabled": false, "ratio": 1.5, "timeout": 100 * time.Millisecond}))
+			Ω(rt.DataFor("third row")).Should(Equal(map[string]any{"name": "multi\nline", "count": 3, "enabled": true, "ratio": 2.0, "timeout": time.Minute}))
+		})
+
+		It("points each entry's code location at its row", func() {
+			Ω(reporter.Did.Find("alpha-1").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 2}))
+			Ω(reporter.Did.Find("second row").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 3}))
+			Ω(reporter.Did.Find("third row").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 4}))
+		})
+	})
+
+	Describe("mixing files with entries", func() {
+		BeforeEach(func() {
+			path := writeFile("cases.json", `[["B"], ["C"]]`)
+			success, _ := RunFixture("mixed entries", func() {
+				DescribeTable("mixed", func(s string) {
+					rt.Run(s)
+				},
+					Entry(nil, "A"),
+					EntriesFromFile(path),
+					Entry(nil, "D"),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("generates the file's entries alongside the other entries", func() {
+			Ω(rt.TrackedRuns()).Should(ConsistOf("A", "B", "C", "D"))
+		})
+	})
+
+	Describe("interruptible bodies", func() {
+		BeforeEach(func() {
+			path := writeFile("cases.csv", "duration\n10ms\n1s\n")
+			success, _ := RunFixture("interruptible entries", func() {
+				DescribeTable("interruptible", func(ctx context.Context, d time.Duration) {
+					rt.Run(CurrentSpecReport().LeafNodeText)
+					select {
+					case <-ctx.Done():
+					case <-time.After(d):
+					}
+				},
+					EntriesFromFile(path, NodeTimeout(time.Millisecond*100)),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("skips the context when decoding and passes it in", func() {
+			Ω(rt).Should(HaveTracked("Entry: 10ms", "Entry: 1s"))
+			Ω(reporter.Did.Find("Entry: 10ms")).Should(HavePassed())
+			Ω(reporter.Did.Find("Entry: 1s")).Should(HaveTimedOut())
+		})
+	})
+
+	Describe("rows that do not match the body", func() {
+		var path string
+		BeforeEach(func() {
+			path = writeFile("cases.json", `[
+  {"description": "ok", "parameters": [1, "a"]},
+  {"description": "wrong type", "parameters": ["one", "a"]},
+  {"description": "too many", "parameters": [1, "a", 2]},
+  {"description": "too few", "parameters": [1]}
+]`)
+			success, _ := RunFixture("mismatched entries", func() {
+				DescribeTable("mismatched", func(i int, s string) {
+					rt.Run(CurrentSpecReport().LeafNodeText)
+				},
+					EntriesFromFile(path),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the offending entries", func() {
+			Ω(rt).Should(HaveTracked("ok"))
+			Ω(reporter.Did.Find("wrong type")).Should(HavePanicked("Invalid table entry parameter"))
+			Ω(reporter.Did.Find("wrong type").LeafNodeLocation).Should(Equal(types.CodeLocation{FileName: path, LineNumber: 3}))
+			Ω(reporter.Did.Find("too many")).Should(HavePanicked("Too many parameters passed in to Table Body function"))
+			Ω(reporter.Did.Find("too few")).Should(HavePanicked("Too few parameters passed in to Table Body function"))
+		})
+	})
+})
@@ -0,0 +1,269 @@
+package internal_integration_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/v2/internal/test_helpers"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+type pair struct {
+	A int
+	B int
+}
+
+var _ = Describe("Typed table driven tests", func() {
+	var bodyFunc = func(p pair) {
+		rt.Run(CurrentSpecReport().LeafNodeText)
+		if p.A != p.B {
+			F("fail")
+		}
+	}
+
+	Describe("constructing tables", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table happy-path", func() {
+				DescribeTableT("hello", bodyFunc,
+					EntryT("A", pair{1, 1}),
+					EntryT("B", pair{1, 1}),
+					EntryT("C", pair{1, 2}),
+					EntryT("D", pair{1, 1}),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs all the entries", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "C", "D"))
+		})
+
+		It("reports on the tests correctly", func() {
+			Ω(reporter.Did.Names()).Should(Equal([]string{"A", "B", "C", "D"}))
+			Ω(reporter.Did.Find("A").ContainerHierarchyTexts).Should(Equal([]string{"hello"}))
+			Ω(reporter.Did.Find("C")).Should(HaveFailed("fail", types.NodeTypeIt))
+			Ω(reporter.End).Should(BeASuiteSummary(false, NSpecs(4), NPassed(3), NFailed(1)))
+		})
+	})
+
+	Describe("Entry Descriptions", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with entry descriptions", func() {
+				DescribeTableT("structs", func(p pair) {},
+					EntryT(nil, pair{1, 2}),
+					EntryT("B", pair{1, 2}),
+					EntryT(EntryDescription("%d vs %d"), pair{1, 2}),
+					EntryT(func(p pair) string { return fmt.Sprintf("sum is %d", p.A+p.B) }, pair{1, 2}),
+				)
+				DescribeTableT("scalars", func(s string) {},
+					EntryT(nil, "hi"),
+					EntryT(EntryDescription("<%s>"), "there"),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("renders the descriptions, spreading struct fields", func() {
+			Ω(reporter.Did.Names()).Should(Equal([]string{
+				"Entry: 1, 2",
+				"B",
+				"1 vs 2",
+				"sum is 3",
+				"Entry: hi",
+				"<there>",
+			}))
+		})
+	})
+
+	Describe("invalid entry descriptions", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with an invalid entry description", func() {
+				DescribeTableT("hello", func(p pair) { rt.Run("body") },
+					EntryT(17, pair{1, 1}),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("fails the entry without running the body", func() {
+			Ω(rt).Should(HaveTrackedNothing())
+			Ω(reporter.Did.Find("")).Should(HavePanicked("Invalid Entry description"))
+		})
+	})
+
+	Describe("code locations", func() {
+		var tableCL, entryCL types.CodeLocation
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table code locations", func() {
+				tableCL, entryCL = types.NewCodeLocation(0), types.NewCodeLocation(0)
+				DescribeTableT("hello", bodyFunc,
+					EntryT("A", pair{1, 1}),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("points at the table and the entry", func() {
+			Ω(reporter.Did.Find("A").ContainerHierarchyLocations[0].FileName).Should(Equal(tableCL.FileName))
+			Ω(reporter.Did.Find("A").ContainerHierarchyLocations[0].LineNumber).Should(Equal(tableCL.LineNumber + 1))
+			Ω(reporter.Did.Find("A").LeafNodeLocation.LineNumber).Should(Equal(entryCL.LineNumber + 2))
+		})
+	})
+
+	Describe("when table entries are marked pending or focused", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with pending and focused entries", func() {
+				DescribeTableT("hello", bodyFunc,
+					EntryT("A", pair{1, 1}),
+					PEntryT("B", pair{1, 1}),
+					XEntryT("C", pair{1, 1}),
+					FEntryT("D", pair{1, 2}),
+					EntryT("E", pair{1, 1}, Focus),
+					EntryT("F", pair{1, 1}, Pending),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("runs only the focused entries", func() {
+			Ω(rt).Should(HaveTracked("D", "E"))
+		})
+
+		It("reports on the tests correctly", func() {
+			Ω(reporter.Did.Find("A")).Should(HaveBeenSkipped())
+			Ω(reporter.Did.Find("B")).Should(BePending())
+			Ω(reporter.Did.Find("C")).Should(BePending())
+			Ω(reporter.Did.Find("D")).Should(HaveFailed("fail"))
+			Ω(reporter.Did.Find("E")).Should(HavePassed())
+			Ω(reporter.Did.Find("F")).Should(BePending())
+		})
+	})
+
+	Describe("when tables are marked pending or focused", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed tables marked pending and focused", func() {
+				PDescribeTableT("pending", bodyFunc, EntryT("A", pair{1, 1}))
+				XDescribeTableT("pending", bodyFunc, EntryT("B", pair{1, 1}))
+				FDescribeTableT("focused", bodyFunc, EntryT("C", pair{1, 1}))
+				It("does not run", rt.T("does not run"))
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("honors the focus and pending state", func() {
+			Ω(rt).Should(HaveTracked("C"))
+			Ω(reporter.Did.Find("A")).Should(BePending())
+			Ω(reporter.Did.Find("B")).Should(BePending())
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+			Ω(reporter.Did.Find("does not run")).Should(HaveBeenSkipped())
+		})
+	})
+
+	Describe("decorators", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with decorators", func() {
+				counter := 0
+				Describe("container", Label("outer"), func() {
+					DescribeTableT("hello", func(failUntil int) {
+						rt.Run(CurrentSpecReport().LeafNodeText)
+						counter += 1
+						if counter < failUntil {
+							F("fail")
+						}
+					},
+						EntryT("A", 2, FlakeAttempts(2), Label("flaky")),
+						EntryT("B", 1, []any{Label("nested"), Serial}),
+					)
+				})
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("applies the entry decorators", func() {
+			Ω(rt).Should(HaveTracked("A", "A", "B"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed(NumAttempts(2)))
+			Ω(reporter.Did.Find("A").Labels()).Should(Equal([]string{"outer", "flaky"}))
+			Ω(reporter.Did.Find("B").Labels()).Should(Equal([]string{"outer", "nested"}))
+			Ω(reporter.Did.Find("B").IsSerial).Should(BeTrue())
+		})
+	})
+
+	Describe("table-level decorators and entry descriptions", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with table-level arguments", func() {
+				DescribeTableT(TableT("labeled", Label("table"), Ordered, EntryDescription("%d vs %d")), bodyFunc,
+					EntryT(nil, pair{1, 1}),
+					EntryT("B", pair{2, 2}, Label("entry")),
+				)
+				DescribeTableT(TableT("described", func(p pair) string { return fmt.Sprintf("sum is %d", p.A+p.B) }), bodyFunc,
+					EntryT(nil, pair{2, 2}),
+				)
+				DescribeTableT(TableT("pending", Pending), bodyFunc,
+					EntryT("C", pair{3, 3}),
+				)
+			})
+			Ω(success).Should(BeTrue())
+		})
+
+		It("applies the table decorators to the table's container", func() {
+			Ω(rt).Should(HaveTracked("1 vs 1", "B", "sum is 4"))
+			Ω(reporter.Did.Find("1 vs 1").Labels()).Should(Equal([]string{"table"}))
+			Ω(reporter.Did.Find("1 vs 1").IsInOrderedContainer).Should(BeTrue())
+			Ω(reporter.Did.Find("B").Labels()).Should(Equal([]string{"table", "entry"}))
+			Ω(reporter.Did.Find("C")).Should(BePending())
+		})
+
+		It("honors a table-level Focus decorator", func() {
+			success, _ := RunFixture("typed table with a table-level focus", func() {
+				DescribeTableT(TableT("focused", Focus), bodyFunc,
+					EntryT("D", pair{4, 4}),
+				)
+				It("does not run", rt.T("does not run"))
+			})
+			Ω(success).Should(BeTrue())
+			Ω(reporter.Did.Find("D")).Should(HavePassed())
+			Ω(reporter.Did.Find("does not run")).Should(HaveBeenSkipped())
+		})
+
+		It("uses the table-level entry description for entries with a nil description", func() {
+			Ω(reporter.Did.Find("1 vs 1").ContainerHierarchyTexts).Should(Equal([]string{"labeled"}))
+			Ω(reporter.Did.Find("sum is 4").ContainerHierarchyTexts).Should(Equal([]string{"described"}))
+		})
+	})
+
+	Describe("interruptible bodies", func() {
+		BeforeEach(func() {
+			success, _ := RunFixture("typed table with context bodies", func() {
+				DescribeTableT("spec context", func(c SpecContext, d time.Duration) {
+					rt.Run(CurrentSpecReport().LeafNodeText)
+					select {
+					case <-c.Done():
+						rt.Run("interrupted")
+					case <-time.After(d):
+					}
+				},
+					EntryT("A", time.Millisecond),
+					EntryT("B", time.Hour, NodeTimeout(time.Millisecond*50)),
+				)
+				DescribeTableT("context", func(c context.Context, s string) {
+					rt.Run(s)
+					Ω(c).ShouldNot(BeNil())
+				},
+					EntryT("C", "C"),
+				)
+			})
+			Ω(success).Should(BeFalse())
+		})
+
+		It("passes in the SpecContext and honors timeouts", func() {
+			Ω(rt).Should(HaveTracked("A", "B", "interrupted", "C"))
+			Ω(reporter.Did.Find("A")).Should(HavePassed())
+			Ω(reporter.Did.Find("B")).Should(HaveTimedOut())
+			Ω(reporter.Did.Find("C")).Should(HavePassed())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"sync"
@@ -50,12 +51,15 @@ type Node struct {
 	MarkedFocus             bool
 	MarkedPending           bool
 	MarkedSerial            bool
+	MarkedConcurrent        bool
 	MarkedOrdered           bool
 	MarkedContinueOnFailure bool
 	MarkedOncePerOrdered    bool
 	FlakeAttempts           int
 	MustPassRepeatedly      int
 	Labels                  Labels
+	SemVerConstraints       SemVerConstraints
+	ExclusiveResources      ExclusiveResources
 	PollProgressAfter       time.Duration
 	PollProgressInterval    time.Duration
 	NodeTimeout             time.Duration
@@ -69,6 +73,7 @@ type Node struct {
 type focusType bool
 type pendingType bool
 type serialType bool
+type concurrentType bool
 type orderedType bool
 type continueOnFailureType bool
 type honorsOrderedType bool
@@ -77,6 +82,7 @@ type suppressProgressReporting bool
 const Focus = focusType(true)
 const Pending = pendingType(true)
 const Serial = serialType(true)
+const Concurrent = concurrentType(true)
 const Ordered = orderedType(true)
 const ContinueOnFailure = continueOnFailureType(true)
 const OncePerOrdered = honorsOrderedType(true)
@@ -84,9 +90,12 @@ const SuppressProgressReporting = suppressProgressReporting(true)
 
 type FlakeAttempts uint
 type MustPassRepeatedly uint
+type PropertyIterations uint
 type Offset uint
 type Done chan<- any // Deprecated Done Channel for asynchronous testing
 type Labels []string
+type SemVerConstraints []string
+type ExclusiveResources []string
 type PollProgressInterval time.Duration
 type PollProgressAfter time.Duration
 type NodeTimeout time.Duration
@@ -138,6 +147,8 @@ func isDecoration(arg any) bool {
 		return true
 	case t == reflect.TypeOf(Serial):
 		return true
+	case t == reflect.TypeOf(Concurrent):
+		return true
 	case t == reflect.TypeOf(Ordered):
 		return true
 	case t == reflect.TypeOf(ContinueOnFailure):
@@ -150,8 +161,14 @@ func isDecoration(arg any) bool {
 		return true
 	case t == reflect.TypeOf(MustPassRepeatedly(0)):
 		return true
+	case t == reflect.TypeOf(PropertyIterations(0)):
+		return true
 	case t == reflect.TypeOf(Labels{}):
 		return true
+	case t == reflect.TypeOf(SemVerConstraints{}):
+		return true
+	case t == reflect.TypeOf(ExclusiveResources{}):
+		return true
 	case t == reflect.TypeOf(PollProgressInterval(0)):
 		return true
 	case t == reflect.TypeOf(PollProgressAfter(0)):
@@ -192,6 +209,8 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 		NodeType:             nodeType,
 		Text:                 text,
 		Labels:               Labels{},
+		SemVerConstraints:    SemVerConstraints{},
+		ExclusiveResources:   ExclusiveResources{},
 		CodeLocation:         types.NewCodeLocation(baseOffset),
 		NestingLevel:         -1,
 		PollProgressAfter:    -1,
@@ -222,6 +241,8 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 	}
 
 	labelsSeen := map[string]bool{}
+	semVerConstraintsSeen := map[string]bool{}
+	exclusiveResourcesSeen := map[string]bool{}
 	trackedFunctionError := false
 	args = remainingArgs
 	remainingArgs = []any{}
@@ -245,6 +266,11 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Serial"))
 			}
+		case t == reflect.TypeOf(Concurrent):
+			node.MarkedConcurrent = bool(arg.(concurrentType))
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Concurrent"))
+			}
 		case t == reflect.TypeOf(Ordered):
 			node.MarkedOrdered = bool(arg.(orderedType))
 			if !nodeType.Is(types.NodeTypeContainer) {
@@ -272,6 +298,9 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "MustPassRepeatedly"))
 			}
+		case t == reflect.TypeOf(PropertyIterations(0)):
+			// ItForAll consumes PropertyIterations before constructing its node
+			appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "PropertyIterations"))
 		case t == reflect.TypeOf(PollProgressAfter(0)):
 			node.PollProgressAfter = time.Duration(arg.(PollProgressAfter))
 			if nodeType.Is(types.NodeTypeContainer) {
@@ -302,11 +331,44 @@ func NewNode(deprecationTracker *types.DeprecationTracker, nodeType types.NodeTy
 				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Label"))
 			}
 			for _, label := range arg.(Labels) {
+				label, err := types.ValidateAndCleanupLabel(label, node.CodeLocation)
+				if err != nil {
+					appendError(err)
+					continue
+				}
 				if !labelsSeen[label] {
 					labelsSeen[label] = true
-					label, err := types.ValidateAndCleanupLabel(label, node.CodeLocation)
 					node.Labels = append(node.Labels, label)
+				}
+			}
+		case t == reflect.TypeOf(SemVerConstraints{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "SemVerConstraint"))
+			}
+			for _, constraint := range arg.(SemVerConstraints) {
+				constraint, err := types.ValidateAndCleanupSemVerConstraint(constraint, node.CodeLocation)
+				if err != nil {
 					appendError(err)
+					continue
+				}
+				if !semVerConstraintsSeen[constraint] {
+					semVerConstraintsSeen[constraint] = true
+					node.SemVerConstraints = append(node.SemVerConstraints, constraint)
+				}
+			}
+		case t == reflect.TypeOf(ExclusiveResources{}):
+			if !nodeType.Is(types.NodeTypesForContainerAndIt) {
+				appendError(types.GinkgoErrors.InvalidDecoratorForNodeType(node.CodeLocation, nodeType, "Exclusive"))
+			}
+			for _, resource := range arg.(ExclusiveResources) {
+				resource = strings.TrimSpace(resource)
+				if resource == "" {
+					appendError(types.GinkgoErrors.InvalidEmptyExclusiveResource(node.CodeLocation))
+					continue
+				}
+				if !exclusiveResourcesSeen[resource] {
+					exclusiveResourcesSeen[resource] = true
+					node.ExclusiveResources = append(node.ExclusiveResources, resource)
 				}
 			}
 		case t.Kind() == reflect.Func:
@@ -808,6 +870,46 @@ func (n Nodes) UnionOfLabels() []string {
 	return out
 }
 
+func (n Nodes) SemVerConstraints() [][]string {
+	out := make([][]string, len(n))
+	for i := range n {
+		if n[i].SemVerConstraints == nil {
+			out[i] = []string{}
+		} else {
+			out[i] = []string(n[i].SemVerConstraints)
+		}
+	}
+	return out
+}
+
+func (n Nodes) UnionOfSemVerConstraints() []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for i := range n {
+		for _, constraint := range n[i].SemVerConstraints {
+			if !seen[constraint] {
+				seen[constraint] = true
+				out = append(out, constraint)
+			}
+		}
+	}
+	return out
+}
+
+func (n Nodes) UnionOfExclusiveResources() []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for i := range n {
+		for _, resource := range n[i].ExclusiveResources {
+			if !seen[resource] {
+				seen[resource] = true
+				out = append(out, resource)
+			}
+		}
+	}
+	return out
+}
+
 func (n Nodes) CodeLocations() []types.CodeLocation {
 	out := make([]types.CodeLocation, len(n))
 	for i := range n {
@@ -866,6 +968,15 @@ func (n Nodes) HasNodeMarkedSerial() bool {
 	return false
 }
 
+func (n Nodes) HasNodeMarkedConcurrent() bool {
+	for i := range n {
+		if n[i].MarkedConcurrent {
+			return true
+		}
+	}
+	return false
+}
+
 func (n Nodes) FirstNodeMarkedOrdered() Node {
 	for i := range n {
 		if n[i].MarkedOrdered {
@@ -912,7 +1023,7 @@ func unrollInterfaceSlice(args any) []any {
 	out := []any{}
 	for i := 0; i < v.Len(); i++ {
 		el := reflect.ValueOf(v.Index(i).Interface())
-		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) {
+		if el.Kind() == reflect.Slice && el.Type() != reflect.TypeOf(Labels{}) && el.Type() != reflect.TypeOf(SemVerConstraints{}) && el.Type() != reflect.TypeOf(ExclusiveResources{}) {
 			out = append(out, unrollInterfaceSlice(el.Interface())...)
 		} else {
 			out = append(out, v.Index(i).Interface())
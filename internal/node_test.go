@@ -35,6 +35,7 @@ var _ = Describe("Partitioning Decorations", func() {
 			2.0,
 			Pending,
 			Serial,
+			Concurrent,
 			Ordered,
 			ContinueOnFailure,
 			SuppressProgressReporting,
@@ -50,9 +51,11 @@ var _ = Describe("Partitioning Decorations", func() {
 			[]string{"a", "b", "c"},
 			Label("A", "B", "C"),
 			Label("D"),
+			Exclusive("database"),
 			[]any{},
 			FlakeAttempts(1),
 			MustPassRepeatedly(1),
+			PropertyIterations(10),
 			true,
 			OncePerOrdered,
 		)
@@ -63,6 +66,7 @@ var _ = Describe("Partitioning Decorations", func() {
 			Focus,
 			Pending,
 			Serial,
+			Concurrent,
 			Ordered,
 			ContinueOnFailure,
 			SuppressProgressReporting,
@@ -74,8 +78,10 @@ var _ = Describe("Partitioning Decorations", func() {
 			PollProgressAfter(time.Second),
 			Label("A", "B", "C"),
 			Label("D"),
+			Exclusive("database"),
 			FlakeAttempts(1),
 			MustPassRepeatedly(1),
+			PropertyIterations(10),
 			OncePerOrdered,
 		}))
 
@@ -284,6 +290,30 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("the Concurrent decoration", func() {
+		It("the node is not Concurrent by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node.MarkedConcurrent).Should(BeFalse())
+			ExpectAllWell(errors)
+		})
+		It("marks the node as Concurrent", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Concurrent)
+			Ω(node.MarkedConcurrent).Should(BeTrue())
+			ExpectAllWell(errors)
+		})
+		It("allows containers to be marked", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, Concurrent)
+			Ω(node.MarkedConcurrent).Should(BeTrue())
+			ExpectAllWell(errors)
+		})
+		It("does not allow non-container/it nodes to be marked", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, Concurrent)
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Concurrent")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+	})
+
 	Describe("the Ordered decoration", func() {
 		It("the node is not Ordered by default", func() {
 			node, errors := internal.NewNode(dt, ntCon, "", body)
@@ -365,6 +395,18 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("the PropertyIterations decoration", func() {
+		It("cannot be applied to any node as ItForAll consumes it", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, cl, PropertyIterations(2))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntIt, "PropertyIterations")))
+
+			node, errors = internal.NewNode(dt, ntCon, "text", body, cl, PropertyIterations(2))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntCon, "PropertyIterations")))
+		})
+	})
+
 	Describe("the FlakeAttempts and MustPassRepeatedly decorations", func() {
 		It("the node sets FlakeAttempts and MustPassRepeatedly to zero by default", func() {
 			node, errors := internal.NewNode(dt, ntIt, "text", body)
@@ -443,6 +485,12 @@ var _ = Describe("Constructing nodes", func() {
 			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
 		})
 
+		It("normalizes and dedupes key:value labels", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Label("owner: payments", "owner:payments", " env : staging", "A", "Feature:IPv6:DualStack"))
+			Ω(node.Labels).Should(Equal(Labels{"owner:payments", "env:staging", "A", "Feature:IPv6:DualStack"}))
+			ExpectAllWell(errors)
+		})
+
 		It("validates labels", func() {
 			node, errors := internal.NewNode(dt, ntIt, "", body, cl, Label("A", "B&C", "C,D", "C,D ", "  "))
 			Ω(node).Should(BeZero())
@@ -451,6 +499,76 @@ var _ = Describe("Constructing nodes", func() {
 		})
 	})
 
+	Describe("The SemVerConstraint decoration", func() {
+		It("has no constraints by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node).ShouldNot(BeZero())
+			Ω(node.SemVerConstraints).Should(Equal(SemVerConstraints{}))
+			ExpectAllWell(errors)
+		})
+
+		It("appends, trims, and dedupes all constraints together, even if nested", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, SemVerConstraint(">= 2.1.0, < 3.0", " ~1.2 "), []any{SemVerConstraint("~1.2", "!= 2.2.0")})
+			Ω(node.SemVerConstraints).Should(Equal(SemVerConstraints{">= 2.1.0, < 3.0", "~1.2", "!= 2.2.0"}))
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to containers", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, SemVerConstraint("^2"))
+			Ω(node.SemVerConstraints).Should(Equal(SemVerConstraints{"^2"}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, SemVerConstraint("^2"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "SemVerConstraint")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+
+		It("validates constraints", func() {
+			node, errors := internal.NewNode(dt, ntIt, "", body, cl, SemVerConstraint("^2", "  ", "not a version"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(HaveLen(2))
+			Ω(errors[0]).Should(Equal(types.GinkgoErrors.InvalidSemVerConstraint("  ", "constraints cannot be empty", cl)))
+			Ω(errors[1].Error()).Should(ContainSubstring("'not a version' is an invalid semantic version constraint"))
+		})
+	})
+
+	Describe("The Exclusive decoration", func() {
+		It("has no exclusive resources by default", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body)
+			Ω(node).ShouldNot(BeZero())
+			Ω(node.ExclusiveResources).Should(Equal(ExclusiveResources{}))
+			ExpectAllWell(errors)
+		})
+
+		It("appends, trims, and dedupes all resources together, even if nested", func() {
+			node, errors := internal.NewNode(dt, ntIt, "text", body, Exclusive("database", " cache "), []any{Exclusive("cache", "queue")})
+			Ω(node.ExclusiveResources).Should(Equal(ExclusiveResources{"database", "cache", "queue"}))
+			ExpectAllWell(errors)
+		})
+
+		It("can be applied to containers", func() {
+			node, errors := internal.NewNode(dt, ntCon, "text", body, Exclusive("database"))
+			Ω(node.ExclusiveResources).Should(Equal(ExclusiveResources{"database"}))
+			ExpectAllWell(errors)
+		})
+
+		It("cannot be applied to non-container/it nodes", func() {
+			node, errors := internal.NewNode(dt, ntBef, "", body, cl, Exclusive("database"))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidDecoratorForNodeType(cl, ntBef, "Exclusive")))
+			Ω(dt.DidTrackDeprecations()).Should(BeFalse())
+		})
+
+		It("does not allow empty resources", func() {
+			node, errors := internal.NewNode(dt, ntIt, "", body, cl, Exclusive("database", "  "))
+			Ω(node).Should(BeZero())
+			Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidEmptyExclusiveResource(cl)))
+		})
+	})
+
 	Describe("the timeout-related decorators", func() {
 		It("correctly assigned timeouts when specified", func() {
 			node, errors := internal.NewNode(dt, ntIt, "spec", func(_ SpecContext) {}, cl, NodeTimeout(time.Second), SpecTimeout(2*time.Second), GracePeriod(3*time.Second))
@@ -1448,6 +1566,32 @@ var _ = Describe("Nodes", func() {
 		})
 	})
 
+	Describe("SemVerConstraints and UnionOfSemVerConstraints", func() {
+		var nodes Nodes
+		BeforeEach(func() {
+			nodes = Nodes{N(SemVerConstraint("^2", "< 2.4")), N(), N(SemVerConstraint("^2", "!= 2.2.0"))}
+		})
+
+		It("SemVerConstraints returns a slice containing the constraints for each node in order", func() {
+			Ω(nodes.SemVerConstraints()).Should(Equal([][]string{
+				{"^2", "< 2.4"},
+				{},
+				{"^2", "!= 2.2.0"},
+			}))
+		})
+
+		It("UnionOfSemVerConstraints returns a single slice of constraints harvested from all nodes and deduped", func() {
+			Ω(nodes.UnionOfSemVerConstraints()).Should(Equal([]string{"^2", "< 2.4", "!= 2.2.0"}))
+		})
+	})
+
+	Describe("UnionOfExclusiveResources", func() {
+		It("returns a single slice of resources harvested from all nodes and deduped", func() {
+			nodes := Nodes{N(Exclusive("database", "cache")), N(), N(Exclusive("cache", "queue"))}
+			Ω(nodes.UnionOfExclusiveResources()).Should(Equal([]string{"database", "cache", "queue"}))
+		})
+	})
+
 	Describe("CodeLocation", func() {
 		var nodes Nodes
 		var cl1, cl2 types.CodeLocation
@@ -1,8 +1,12 @@
 package internal
 
 import (
+	"fmt"
+	"hash/fnv"
 	"math/rand"
+	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
 )
@@ -69,7 +73,7 @@ func (s *SortableSpecs) Less(i, j int) bool {
 type GroupedSpecIndices []SpecIndices
 type SpecIndices []int
 
-func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices, GroupedSpecIndices) {
+func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices, GroupedSpecIndices, error) {
 	/*
 		Ginkgo has sophisticated support for randomizing specs.  Specs are guaranteed to have the same
 		order for a given seed across test runs.
@@ -81,7 +85,11 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 
 		In addition, spec containers can be marked as Ordered.  Specs within an Ordered container are never shuffled.
 
+		When a suite is split across machines via --shard=i/N, each execution group is assigned to one of the N shards by a hash of its identity (before any shuffling) and only the groups in shard i are returned.
+
 		Finally, specs and spec containers can be marked as Serial.  When running in parallel, serial specs run on Process #1 _after_ all other processes have finished.
+
+		If the user provides historical run times via --order-by-duration the shuffled execution groups are then (stably) sorted so that the longest groups run first.  When running in parallel this ensures a long Ordered container isn't dealt out last and left running on its own.
 	*/
 
 	// Seed a new random source based on thee configured random seed.
@@ -108,6 +116,16 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 		}
 	}
 
+	// if the suite is split across machines we only keep the execution groups assigned to this shard
+	// this happens before shuffling so that shard assignments depend only on the (sorted) identity of the specs and not on the random seed
+	shard, err := types.ParseShard(suiteConfig.Shard)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !shard.IsZero() {
+		executionGroupIDs = shardExecutionGroupIDs(executionGroupIDs, executionGroups, specs, shard)
+	}
+
 	// now, we only shuffle all the execution groups if we're randomizing all specs, otherwise
 	// we shuffle outermost containers.  so we need to form shufflable groupings of GroupIDs
 	shufflableGroupingIDs := []uint{}
@@ -150,9 +168,18 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 		}
 	}
 
+	// If we have historical run times we schedule the longest execution groups first
+	if suiteConfig.DurationReport != "" {
+		runTimes, err := types.LoadSpecRunTimes(suiteConfig.DurationReport)
+		if err != nil {
+			return nil, nil, err
+		}
+		orderedGroups = SortGroupsByRunTime(orderedGroups, specs, runTimes)
+	}
+
 	// If we're running in series, we're done.
 	if suiteConfig.ParallelTotal == 1 {
-		return orderedGroups, GroupedSpecIndices{}
+		return orderedGroups, GroupedSpecIndices{}, nil
 	}
 
 	// We're running in parallel so we need to partition the ordered groups into a parallelizable set and a serialized set.
@@ -167,5 +194,65 @@ func OrderSpecs(specs Specs, suiteConfig types.SuiteConfig) (GroupedSpecIndices,
 		}
 	}
 
-	return parallelizableGroups, serialGroups
+	return parallelizableGroups, serialGroups, nil
+}
+
+/*
+shardExecutionGroupIDs returns the execution groups assigned to the requested shard.
+
+Each group is assigned by hashing its identity - the texts of the group's node and its containers along with the group node's file and line - so that adding or removing a spec doesn't move any other group to a different shard.  Only the base name of the file is used as the absolute path differs between machines.
+*/
+func shardExecutionGroupIDs(executionGroupIDs []uint, executionGroups map[uint]SpecIndices, specs Specs, shard types.Shard) []uint {
+	out := []uint{}
+	for _, groupID := range executionGroupIDs {
+		spec := specs[executionGroups[groupID][0]]
+		hash := fnv.New32a()
+		for _, node := range spec.Nodes.WithType(types.NodeTypesForContainerAndIt) {
+			fmt.Fprintf(hash, "%s\x00", node.Text)
+			if node.ID == groupID {
+				fmt.Fprintf(hash, "%s:%d", filepath.Base(node.CodeLocation.FileName), node.CodeLocation.LineNumber)
+				break
+			}
+		}
+		if int(hash.Sum32()%uint32(shard.Total)) == shard.Index-1 {
+			out = append(out, groupID)
+		}
+	}
+	return out
+}
+
+/*
+SortGroupsByRunTime performs a stable sort of the passed-in execution groups such that the groups with the longest expected run time come first.
+
+This is the longest-processing-time-first scheduling strategy: as parallel processes pull the next group off the shared counter the long groups are dealt out early and the short groups fill in the gaps at the end of the run.
+
+The expected run time of a group is the sum of the recorded run times of its specs.  Specs with no recorded run time are assumed to take the mean recorded run time.
+*/
+func SortGroupsByRunTime(groups GroupedSpecIndices, specs Specs, runTimes types.SpecRunTimes) GroupedSpecIndices {
+	mean := runTimes.Mean()
+	expectedRunTimes := make([]time.Duration, len(groups))
+	for i, specIndices := range groups {
+		for _, idx := range specIndices {
+			spec := specs[idx]
+			runTime, ok := runTimes[types.SpecRunTimeKey(spec.FirstNodeWithType(types.NodeTypeIt).CodeLocation, spec.Nodes.WithType(types.NodeTypeContainer).Texts(), spec.FirstNodeWithType(types.NodeTypeIt).Text)]
+			if !ok {
+				runTime = mean
+			}
+			expectedRunTimes[i] += runTime
+		}
+	}
+
+	permutation := make([]int, len(groups))
+	for i := range permutation {
+		permutation[i] = i
+	}
+	sort.SliceStable(permutation, func(i, j int) bool {
+		return expectedRunTimes[permutation[i]] > expectedRunTimes[permutation[j]]
+	})
+
+	out := make(GroupedSpecIndices, len(groups))
+	for i, j := range permutation {
+		out[i] = groups[j]
+	}
+	return out
 }
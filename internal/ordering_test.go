@@ -1,6 +1,8 @@
 package internal_test
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -53,7 +55,7 @@ var _ = Describe("OrderSpecs", func() {
 	Context("when configured to only randomize top-level specs", func() {
 		It("shuffles top level specs only", func() {
 			for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-				groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				Ω(getTexts(specs, groupedSpecIndices).Join()).Should(ContainSubstring("CDE"))
@@ -61,9 +63,9 @@ var _ = Describe("OrderSpecs", func() {
 			}
 
 			conf.RandomSeed = 1
-			groupedSpecIndices1, _ := internal.OrderSpecs(specs, conf)
+			groupedSpecIndices1, _, _ := internal.OrderSpecs(specs, conf)
 			conf.RandomSeed = 2
-			groupedSpecIndices2, _ := internal.OrderSpecs(specs, conf)
+			groupedSpecIndices2, _, _ := internal.OrderSpecs(specs, conf)
 			Ω(getTexts(specs, groupedSpecIndices1)).ShouldNot(Equal(getTexts(specs, groupedSpecIndices2)))
 		})
 	})
@@ -77,7 +79,7 @@ var _ = Describe("OrderSpecs", func() {
 			hasCDE := true
 			hasGH := true
 			for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-				groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				hasCDE, _ = ContainSubstring("CDE").Match(getTexts(specs, groupedSpecIndices).Join())
@@ -90,9 +92,9 @@ var _ = Describe("OrderSpecs", func() {
 			Ω(hasCDE || hasGH).Should(BeFalse(), "after 10 randomizations, we really shouldn't have gotten CDE and GH in order as all specs should be shuffled, not just top-level containers and specs")
 
 			conf.RandomSeed = 1
-			groupedSpecIndices1, _ := internal.OrderSpecs(specs, conf)
+			groupedSpecIndices1, _, _ := internal.OrderSpecs(specs, conf)
 			conf.RandomSeed = 2
-			groupedSpecIndices2, _ := internal.OrderSpecs(specs, conf)
+			groupedSpecIndices2, _, _ := internal.OrderSpecs(specs, conf)
 			Ω(getTexts(specs, groupedSpecIndices1)).ShouldNot(Equal(getTexts(specs, groupedSpecIndices2)))
 		})
 	})
@@ -101,10 +103,10 @@ var _ = Describe("OrderSpecs", func() {
 		It("always generates the same order", func() {
 			for _, conf.RandomizeAllSpecs = range []bool{true, false} {
 				for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-					groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+					groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 					Ω(serialSpecIndices).Should(BeEmpty())
 					for i := 0; i < 10; i++ {
-						reshuffledGroupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+						reshuffledGroupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 						Ω(serialSpecIndices).Should(BeEmpty())
 
 						Ω(getTexts(specs, groupedSpecIndices)).Should(Equal(getTexts(specs, reshuffledGroupedSpecIndices)))
@@ -145,10 +147,10 @@ var _ = Describe("OrderSpecs", func() {
 					specsOrderBA = append(specsOrderBA, specsInFileB...)
 					specsOrderBA = append(specsOrderBA, specsInFileA...)
 
-					groupedSpecIndicesAB, serialSpecIndices := internal.OrderSpecs(specsOrderAB, conf)
+					groupedSpecIndicesAB, serialSpecIndices, _ := internal.OrderSpecs(specsOrderAB, conf)
 					Ω(serialSpecIndices).Should(BeEmpty())
 
-					groupedSpecIndicesBA, serialSpecIndices := internal.OrderSpecs(specsOrderBA, conf)
+					groupedSpecIndicesBA, serialSpecIndices, _ := internal.OrderSpecs(specsOrderBA, conf)
 					Ω(serialSpecIndices).Should(BeEmpty())
 
 					Ω(getTexts(specsOrderAB, groupedSpecIndicesAB)).Should(Equal(getTexts(specsOrderBA, groupedSpecIndicesBA)))
@@ -177,7 +179,7 @@ var _ = Describe("OrderSpecs", func() {
 
 		It("never shuffles the specs in ordered specs", func() {
 			for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-				groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				Ω(getTexts(specs, groupedSpecIndices).Join()).Should(ContainSubstring("CDE"))
@@ -206,7 +208,7 @@ var _ = Describe("OrderSpecs", func() {
 
 		It("runs all the specs in order", func() {
 			for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-				groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				Ω(getTexts(specs, groupedSpecIndices).Join()).Should(Equal("ABCDEFGH"))
@@ -238,7 +240,7 @@ var _ = Describe("OrderSpecs", func() {
 
 			It("puts all the tests in the parallelizable group and returns an empty serial group", func() {
 				for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-					groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+					groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 					Ω(serialSpecIndices).Should(BeEmpty())
 
 					Ω(getTexts(specs, groupedSpecIndices).Join()).Should(ContainSubstring("CDE"))
@@ -246,9 +248,9 @@ var _ = Describe("OrderSpecs", func() {
 				}
 
 				conf.RandomSeed = 1
-				groupedSpecIndices1, _ := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices1, _, _ := internal.OrderSpecs(specs, conf)
 				conf.RandomSeed = 2
-				groupedSpecIndices2, _ := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices2, _, _ := internal.OrderSpecs(specs, conf)
 				Ω(getTexts(specs, groupedSpecIndices1)).ShouldNot(Equal(getTexts(specs, groupedSpecIndices2)))
 			})
 		})
@@ -260,7 +262,7 @@ var _ = Describe("OrderSpecs", func() {
 
 			It("puts all parallelizable tests in the parallelizable group and all serial tests in the serial group, preserving ordered test order", func() {
 				for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
-					groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+					groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 
 					Ω(getTexts(specs, groupedSpecIndices)).Should(ConsistOf("B", "F", "G"))
 					Ω(getTexts(specs, serialSpecIndices).Join()).Should(ContainSubstring("CDE"))
@@ -268,9 +270,9 @@ var _ = Describe("OrderSpecs", func() {
 				}
 
 				conf.RandomSeed = 1
-				groupedSpecIndices1, serialSpecIndices1 := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices1, serialSpecIndices1, _ := internal.OrderSpecs(specs, conf)
 				conf.RandomSeed = 2
-				groupedSpecIndices2, serialSpecIndices2 := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices2, serialSpecIndices2, _ := internal.OrderSpecs(specs, conf)
 				Ω(getTexts(specs, groupedSpecIndices1)).ShouldNot(Equal(getTexts(specs, groupedSpecIndices2)))
 				Ω(getTexts(specs, serialSpecIndices1)).ShouldNot(Equal(getTexts(specs, serialSpecIndices2)))
 			})
@@ -309,7 +311,7 @@ var _ = Describe("OrderSpecs", func() {
 
 			It("ensures a deterministic order for specs that are defined at the same line without messing with the natural order of specs and containers; it also ensures ordered containers run in the correct order - even if specs are generated in a helper function at a different line", func() {
 				conf.RandomSeed = 1 // this happens to sort conA0 ahead of conB0 - other than that, though, we are actually testing SortableSpecs
-				groupedSpecIndices, serialSpecIndices := internal.OrderSpecs(specs, conf)
+				groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				Ω(getTexts(specs, groupedSpecIndices).Join()).Should(Equal("ABCDEFGHB-ZB-YB-BB-CB-DB-AC-AC-BC-CC-DC-EC-F"))
@@ -352,9 +354,9 @@ var _ = Describe("OrderSpecs", func() {
 
 				specsA := generateSpecs()
 				specsB := generateSpecs()
-				groupedSpecIndicesA, serialSpecIndices := internal.OrderSpecs(specsA, conf)
+				groupedSpecIndicesA, serialSpecIndices, _ := internal.OrderSpecs(specsA, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
-				groupedSpecIndicesB, serialSpecIndices := internal.OrderSpecs(specsB, conf)
+				groupedSpecIndicesB, serialSpecIndices, _ := internal.OrderSpecs(specsB, conf)
 				Ω(serialSpecIndices).Should(BeEmpty())
 
 				Ω(getTexts(specsA, groupedSpecIndicesA).Join()).Should(Equal(getTexts(specsB, groupedSpecIndicesB).Join()))
@@ -363,3 +365,143 @@ var _ = Describe("OrderSpecs", func() {
 		})
 	})
 })
+
+var _ = Describe("SortGroupsByRunTime", func() {
+	var specs Specs
+	var groups internal.GroupedSpecIndices
+	var runTimes types.SpecRunTimes
+
+	key := func(spec Spec) string {
+		it := spec.FirstNodeWithType(types.NodeTypeIt)
+		return types.SpecRunTimeKey(it.CodeLocation, spec.Nodes.WithType(types.NodeTypeContainer).Texts(), it.Text)
+	}
+
+	BeforeEach(func() {
+		con := N(ntCon, Ordered, CL("file_test.go", 3))
+		specs = Specs{
+			S(N("A", ntIt, CL("file_test.go", 1))),
+			S(N("B", ntIt, CL("file_test.go", 2))),
+			S(con, N("C", ntIt, CL("file_test.go", 4))),
+			S(con, N("D", ntIt, CL("file_test.go", 5))),
+			S(N("E", ntIt, CL("file_test.go", 6))),
+			S(N("F", ntIt, CL("file_test.go", 7))),
+		}
+		groups = internal.GroupedSpecIndices{{0}, {1}, {2, 3}, {4}, {5}}
+		runTimes = types.SpecRunTimes{
+			key(specs[0]): time.Second,
+			key(specs[1]): 5 * time.Second,
+			key(specs[2]): 2 * time.Second,
+			key(specs[3]): 2 * time.Second,
+			key(specs[4]): time.Second,
+		}
+	})
+
+	It("runs the longest groups first, keeping ordered containers intact and preserving the order of groups with equal run times", func() {
+		sorted := internal.SortGroupsByRunTime(groups, specs, runTimes)
+		Ω(getTexts(specs, sorted).Join()).Should(Equal("BCDFAE"))
+	})
+
+	It("assumes specs with no recorded run time take the mean run time", func() {
+		runTimes[key(specs[4])] = 10 * time.Second
+		sorted := internal.SortGroupsByRunTime(groups, specs, runTimes)
+		Ω(getTexts(specs, sorted).Join()).Should(Equal("EBCDFA"))
+	})
+
+	It("leaves the order unchanged when there are no recorded run times", func() {
+		sorted := internal.SortGroupsByRunTime(groups, specs, types.SpecRunTimes{})
+		Ω(getTexts(specs, sorted).Join()).Should(Equal("ABCDEF"))
+	})
+})
+
+var _ = Describe("Sharding specs via OrderSpecs", func() {
+	var conf types.SuiteConfig
+	var specs Specs
+
+	BeforeEach(func() {
+		conf = types.SuiteConfig{RandomSeed: 1, ParallelTotal: 1}
+
+		con1 := N(ntCon, Ordered, CL("file_test.go", 3))
+		con2 := N(ntCon, CL("file_test.go", 7))
+		specs = Specs{
+			S(N("A", ntIt, CL("file_test.go", 1))),
+			S(N("B", ntIt, CL("file_test.go", 2))),
+			S(con1, N("C", ntIt, CL("file_test.go", 4))),
+			S(con1, N("D", ntIt, CL("file_test.go", 5))),
+			S(N("E", ntIt, CL("file_test.go", 6))),
+			S(con2, N("F", ntIt, CL("file_test.go", 8))),
+			S(con2, N("G", ntIt, CL("file_test.go", 9))),
+			S(N("H", ntIt, CL("file_test.go", 10))),
+		}
+	})
+
+	textsForShard := func(shard string) []string {
+		conf.Shard = shard
+		groupedSpecIndices, serialSpecIndices, _ := internal.OrderSpecs(specs, conf)
+		Ω(serialSpecIndices).Should(BeEmpty())
+		return getTexts(specs, groupedSpecIndices)
+	}
+
+	shardsBySpec := func(total int) map[string]int {
+		out := map[string]int{}
+		for i := 1; i <= total; i++ {
+			for _, text := range textsForShard(fmt.Sprintf("%d/%d", i, total)) {
+				Ω(out).ShouldNot(HaveKey(text), "%s was assigned to more than one shard", text)
+				out[text] = i
+			}
+		}
+		return out
+	}
+
+	It("partitions the execution groups into disjoint shards that, together, cover every spec", func() {
+		Ω(shardsBySpec(3)).Should(HaveLen(len(specs)))
+	})
+
+	It("keeps ordered containers intact", func() {
+		conf.RandomizeAllSpecs = true
+		shards := shardsBySpec(3)
+		Ω(shards["C"]).Should(Equal(shards["D"]))
+		Ω(SpecTexts(textsForShard(fmt.Sprintf("%d/3", shards["C"]))).Join()).Should(ContainSubstring("CD"))
+	})
+
+	It("assigns specs to shards independently of the random seed", func() {
+		expected := textsForShard("2/3")
+		for conf.RandomSeed = 1; conf.RandomSeed < 10; conf.RandomSeed += 1 {
+			Ω(textsForShard("2/3")).Should(ConsistOf(expected))
+		}
+	})
+
+	It("assigns specs to shards independently of the other specs in the suite", func() {
+		expected := shardsBySpec(3)
+		specs = append(specs[:1], specs[2:]...)
+		specs = append(specs, S(N("I", ntIt, CL("file_test.go", 11))))
+		shards := shardsBySpec(3)
+		for _, text := range []string{"A", "C", "D", "E", "F", "G", "H"} {
+			Ω(shards[text]).Should(Equal(expected[text]), text)
+		}
+	})
+
+	It("assigns specs to shards independently of the directory the suite lives in", func() {
+		expected := shardsBySpec(3)
+		for i := range specs {
+			nodes := make(Nodes, len(specs[i].Nodes))
+			for j, node := range specs[i].Nodes {
+				node.CodeLocation.FileName = filepath.Join("/some/other/checkout", node.CodeLocation.FileName)
+				nodes[j] = node
+			}
+			specs[i].Nodes = nodes
+		}
+		Ω(shardsBySpec(3)).Should(Equal(expected))
+	})
+
+	It("returns an error if the shard is invalid", func() {
+		conf.Shard = "4/3"
+		_, _, err := internal.OrderSpecs(specs, conf)
+		Ω(err).Should(MatchError(types.GinkgoErrors.InvalidShard("4/3")))
+	})
+
+	It("returns an error if the duration report can't be loaded", func() {
+		conf.DurationReport = filepath.Join(GinkgoT().TempDir(), "missing.json")
+		_, _, err := internal.OrderSpecs(specs, conf)
+		Ω(err).Should(HaveOccurred())
+	})
+})
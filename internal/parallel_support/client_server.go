@@ -1,9 +1,15 @@
 package parallel_support
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/reporters"
@@ -25,14 +31,20 @@ var ErrorEarly = fmt.Errorf("early")
 
 var POLLING_INTERVAL = 50 * time.Millisecond
 
+// A process that is waiting in line for exclusive resources asks the server to hold its request open for up to EXCLUSIVE_RESOURCES_WAIT rather than polling.
+var EXCLUSIVE_RESOURCES_WAIT = time.Second
+
 type Server interface {
 	Start()
 	Close()
 	Address() string
 	RegisterAlive(node int, alive func() bool)
+	AcceptRemoteProcs(procs []RemoteProc)
+	GetRemoteProcResults() chan RemoteProcStatus
 	GetSuiteDone() chan any
 	GetOutputDestination() io.Writer
 	SetOutputDestination(io.Writer)
+	SetStreamDestination(io.Writer)
 }
 
 type Client interface {
@@ -51,22 +63,157 @@ type Client interface {
 	FetchNextCounter() (int, error)
 	PostAbort() error
 	ShouldAbort() bool
+	AcquireExclusiveResources(request ExclusiveResourcesRequest) (ExclusiveResourcesLock, error)
+	ReleaseExclusiveResources(token int) error
 	PostEmitProgressReport(report types.ProgressReport) error
+	PostEmitStreamEvent(event []byte) error
+	FetchRemoteProcs(max int) ([]RemoteProc, error)
+	PostRemoteProcStatus(status RemoteProcStatus) error
 	Write(p []byte) (int, error)
 }
 
+/*
+Transports the server can listen on.
+
+TransportAuto prefers a Unix domain socket and falls back to a TCP loopback port if a socket can't be created.  Clients determine the transport from the server's address.
+*/
+const (
+	TransportAuto = "auto"
+	TransportUnix = "unix"
+	TransportTCP  = "tcp"
+)
+
+// the address of a server listening on a Unix domain socket is the path to the socket with this prefix
+const unixSocketScheme = "unix://"
+
 func NewServer(parallelTotal int, reporter reporters.Reporter) (Server, error) {
+	return NewServerWithTransport(parallelTotal, reporter, TransportAuto)
+}
+
+func NewServerWithTransport(parallelTotal int, reporter reporters.Reporter, transport string) (Server, error) {
+	listener, err := listen(transport)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(listener, newServerHandler(parallelTotal, reporter), ""), nil
+}
+
+/*
+NewServerOnAddress returns a server that listens on the passed-in TCP address (e.g. ":7777").  Unlike NewServer, the server can be reached by workers running on other machines.
+
+Anyone who can reach the address can take part in the run, so the server rejects clients that don't present token.  Clients read the token from the environment variable named by types.PARALLEL_TOKEN_ENV_VAR.
+*/
+func NewServerOnAddress(parallelTotal int, reporter reporters.Reporter, address string, token string) (Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("a server listening on %s requires a token", address)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(listener, newServerHandler(parallelTotal, reporter), token), nil
+}
+
+func newServer(listener net.Listener, handler *ServerHandler, token string) Server {
 	if os.Getenv("GINKGO_PARALLEL_PROTOCOL") == "HTTP" {
-		return newHttpServer(parallelTotal, reporter)
+		return newHttpServer(listener, handler, token)
 	} else {
-		return newRPCServer(parallelTotal, reporter)
+		return newRPCServer(listener, handler, token)
 	}
 }
 
 func NewClient(serverHost string) Client {
+	token := os.Getenv(types.PARALLEL_TOKEN_ENV_VAR)
 	if os.Getenv("GINKGO_PARALLEL_PROTOCOL") == "HTTP" {
-		return newHttpClient(serverHost)
+		return newHttpClient(serverHost, token)
 	} else {
-		return newRPCClient(serverHost)
+		return newRPCClient(serverHost, token)
+	}
+}
+
+// clients present the server's token in this header with every HTTP request (and, for the RPC protocol, when they connect)
+const tokenHeader = "X-Ginkgo-Parallel-Token"
+
+// requireToken rejects requests that don't present token.  Servers that don't have a token accept every request.
+func requireToken(token string, handler http.Handler) http.Handler {
+	if token == "" {
+		return handler
+	}
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(request.Header.Get(tokenHeader)), []byte(token)) != 1 {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+func listen(transport string) (net.Listener, error) {
+	switch transport {
+	case TransportTCP:
+		return net.Listen("tcp", "127.0.0.1:0")
+	case TransportUnix:
+		return listenOnUnixSocket()
+	default:
+		if runtime.GOOS != "windows" {
+			if listener, err := listenOnUnixSocket(); err == nil {
+				return listener, nil
+			}
+		}
+		return net.Listen("tcp", "127.0.0.1:0")
+	}
+}
+
+// listenOnUnixSocket listens on a socket in a fresh temporary directory.  The directory is removed when the listener is closed.
+func listenOnUnixSocket() (net.Listener, error) {
+	dir, err := os.MkdirTemp("", "ginkgo")
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", filepath.Join(dir, "ginkgo.sock"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return unixSocketListener{Listener: listener, dir: dir}, nil
+}
+
+type unixSocketListener struct {
+	net.Listener
+	dir string
+}
+
+func (l unixSocketListener) Close() error {
+	err := l.Listener.Close()
+	os.RemoveAll(l.dir)
+	return err
+}
+
+// isUnixSocket returns true if the listener is listening on a Unix domain socket
+func isUnixSocket(listener net.Listener) bool {
+	return listener.Addr().Network() == "unix"
+}
+
+// dialTarget returns the network and address a client should dial to reach the server at serverHost
+func dialTarget(serverHost string) (string, string) {
+	if strings.HasPrefix(serverHost, unixSocketScheme) {
+		return "unix", strings.TrimPrefix(serverHost, unixSocketScheme)
+	}
+	return "tcp", serverHost
+}
+
+// NewStreamEventWriter returns an io.Writer that forwards each write to the server as a single stream event.  This allows parallel processes to share the server's JSON event stream.
+func NewStreamEventWriter(client Client) io.Writer {
+	return streamEventWriter{client: client}
+}
+
+type streamEventWriter struct {
+	client Client
+}
+
+func (w streamEventWriter) Write(p []byte) (int, error) {
+	if err := w.client.PostEmitStreamEvent(p); err != nil {
+		return 0, err
 	}
+	return len(p), nil
 }
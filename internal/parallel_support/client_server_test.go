@@ -3,6 +3,9 @@ package parallel_support_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -30,379 +33,377 @@ func (s ColorableStringerStruct) ColorableString() string {
 
 var _ = Describe("The Parallel Support Client & Server", func() {
 	for _, protocol := range []string{"RPC", "HTTP"} {
-		protocol := protocol
-		Describe(fmt.Sprintf("The %s protocol", protocol), Label(protocol), func() {
-			var (
-				server   parallel_support.Server
-				client   parallel_support.Client
-				reporter *FakeReporter
-				buffer   *gbytes.Buffer
-			)
-
-			BeforeEach(func() {
-				GinkgoT().Setenv("GINKGO_PARALLEL_PROTOCOL", protocol)
+		for _, transport := range []string{parallel_support.TransportTCP, parallel_support.TransportUnix} {
+			protocol, transport := protocol, transport
+			Describe(fmt.Sprintf("The %s protocol over %s", protocol, transport), Label(protocol), func() {
+				var (
+					server   parallel_support.Server
+					client   parallel_support.Client
+					reporter *FakeReporter
+					buffer   *gbytes.Buffer
+				)
 
-				var err error
-				reporter = NewFakeReporter()
-				server, err = parallel_support.NewServer(3, reporter)
-				Ω(err).ShouldNot(HaveOccurred())
-				server.Start()
-
-				buffer = gbytes.NewBuffer()
-				server.SetOutputDestination(buffer)
+				BeforeEach(func() {
+					GinkgoT().Setenv("GINKGO_PARALLEL_PROTOCOL", protocol)
 
-				client = parallel_support.NewClient(server.Address())
-				Eventually(client.Connect).Should(BeTrue())
+					var err error
+					reporter = NewFakeReporter()
+					server, err = parallel_support.NewServerWithTransport(3, reporter, transport)
+					Ω(err).ShouldNot(HaveOccurred())
+					server.Start()
 
-				DeferCleanup(server.Close)
-				DeferCleanup(client.Close)
-			})
+					buffer = gbytes.NewBuffer()
+					server.SetOutputDestination(buffer)
 
-			Describe("Reporting endpoints", func() {
-				var beginReport, thirdBeginReport types.Report
-				var endReport1, endReport2, endReport3 types.Report
-				var specReportA, specReportB, specReportC types.SpecReport
+					client = parallel_support.NewClient(server.Address())
+					Eventually(client.Connect).Should(BeTrue())
 
-				var t time.Time
+					DeferCleanup(server.Close)
+					DeferCleanup(client.Close)
+				})
 
-				BeforeEach(func() {
-					beginReport = types.Report{SuiteDescription: "my sweet suite"}
-					thirdBeginReport = types.Report{SuiteDescription: "last one in gets forwarded"}
+				Describe("Reporting endpoints", func() {
+					var beginReport, thirdBeginReport types.Report
+					var endReport1, endReport2, endReport3 types.Report
+					var specReportA, specReportB, specReportC types.SpecReport
 
-					specReportA = types.SpecReport{LeafNodeText: "A"}
-					specReportB = types.SpecReport{LeafNodeText: "B"}
-					specReportC = types.SpecReport{LeafNodeText: "C"}
+					var t time.Time
 
-					t = time.Now()
+					BeforeEach(func() {
+						beginReport = types.Report{SuiteDescription: "my sweet suite"}
+						thirdBeginReport = types.Report{SuiteDescription: "last one in gets forwarded"}
 
-					endReport1 = types.Report{StartTime: t.Add(-time.Second), EndTime: t.Add(time.Second), SuiteSucceeded: true, SpecReports: types.SpecReports{specReportA}}
-					endReport2 = types.Report{StartTime: t.Add(-2 * time.Second), EndTime: t.Add(time.Second), SuiteSucceeded: true, SpecReports: types.SpecReports{specReportB}}
-					endReport3 = types.Report{StartTime: t.Add(-time.Second), EndTime: t.Add(2 * time.Second), SuiteSucceeded: false, SpecReports: types.SpecReports{specReportC}}
-				})
+						specReportA = types.SpecReport{LeafNodeText: "A"}
+						specReportB = types.SpecReport{LeafNodeText: "B"}
+						specReportC = types.SpecReport{LeafNodeText: "C"}
 
-				Context("before all procs have reported SuiteWillBegin", func() {
-					BeforeEach(func() {
-						Ω(client.PostSuiteWillBegin(beginReport)).Should(Succeed())
-						Ω(client.PostDidRun(specReportA)).Should(Succeed())
-						Ω(client.PostSuiteWillBegin(beginReport)).Should(Succeed())
-						Ω(client.PostDidRun(specReportB)).Should(Succeed())
-					})
+						t = time.Now()
 
-					It("should not forward anything to the attached reporter", func() {
-						Ω(reporter.Begin).Should(BeZero())
-						Ω(reporter.Will).Should(BeEmpty())
-						Ω(reporter.Did).Should(BeEmpty())
+						endReport1 = types.Report{StartTime: t.Add(-time.Second), EndTime: t.Add(time.Second), SuiteSucceeded: true, SpecReports: types.SpecReports{specReportA}}
+						endReport2 = types.Report{StartTime: t.Add(-2 * time.Second), EndTime: t.Add(time.Second), SuiteSucceeded: true, SpecReports: types.SpecReports{specReportB}}
+						endReport3 = types.Report{StartTime: t.Add(-time.Second), EndTime: t.Add(2 * time.Second), SuiteSucceeded: false, SpecReports: types.SpecReports{specReportC}}
 					})
 
-					Context("when the final proc reports SuiteWillBegin", func() {
+					Context("before all procs have reported SuiteWillBegin", func() {
 						BeforeEach(func() {
-							Ω(client.PostSuiteWillBegin(thirdBeginReport)).Should(Succeed())
+							Ω(client.PostSuiteWillBegin(beginReport)).Should(Succeed())
+							Ω(client.PostDidRun(specReportA)).Should(Succeed())
+							Ω(client.PostSuiteWillBegin(beginReport)).Should(Succeed())
+							Ω(client.PostDidRun(specReportB)).Should(Succeed())
 						})
 
-						It("forwards to SuiteWillBegin and catches up on any received summaries", func() {
-							Ω(reporter.Begin).Should(Equal(thirdBeginReport))
-							Ω(reporter.Will.Names()).Should(ConsistOf("A", "B"))
-							Ω(reporter.Did.Names()).Should(ConsistOf("A", "B"))
+						It("should not forward anything to the attached reporter", func() {
+							Ω(reporter.Begin).Should(BeZero())
+							Ω(reporter.Will).Should(BeEmpty())
+							Ω(reporter.Did).Should(BeEmpty())
 						})
 
-						Context("any subsequent summaries", func() {
+						Context("when the final proc reports SuiteWillBegin", func() {
 							BeforeEach(func() {
-								Ω(client.PostDidRun(specReportC)).Should(Succeed())
+								Ω(client.PostSuiteWillBegin(thirdBeginReport)).Should(Succeed())
 							})
 
-							It("are forwarded immediately", func() {
-								Ω(reporter.Will.Names()).Should(ConsistOf("A", "B", "C"))
-								Ω(reporter.Did.Names()).Should(ConsistOf("A", "B", "C"))
-							})
-						})
-
-						Context("when SuiteDidEnd start arriving", func() {
-							BeforeEach(func() {
-								Ω(client.PostSuiteDidEnd(endReport1)).Should(Succeed())
-								Ω(client.PostSuiteDidEnd(endReport2)).Should(Succeed())
+							It("forwards to SuiteWillBegin and catches up on any received summaries", func() {
+								Ω(reporter.Begin).Should(Equal(thirdBeginReport))
+								Ω(reporter.Will.Names()).Should(ConsistOf("A", "B"))
+								Ω(reporter.Did.Names()).Should(ConsistOf("A", "B"))
 							})
 
-							It("does not forward them yet...", func() {
-								Ω(reporter.End).Should(BeZero())
-							})
+							Context("any subsequent summaries", func() {
+								BeforeEach(func() {
+									Ω(client.PostDidRun(specReportC)).Should(Succeed())
+								})
 
-							It("doesn't signal it's done", func() {
-								Ω(server.GetSuiteDone()).ShouldNot(BeClosed())
+								It("are forwarded immediately", func() {
+									Ω(reporter.Will.Names()).Should(ConsistOf("A", "B", "C"))
+									Ω(reporter.Did.Names()).Should(ConsistOf("A", "B", "C"))
+								})
 							})
 
-							Context("when the final SuiteDidEnd arrive", func() {
+							Context("when SuiteDidEnd start arriving", func() {
 								BeforeEach(func() {
-									Ω(client.PostSuiteDidEnd(endReport3)).Should(Succeed())
+									Ω(client.PostSuiteDidEnd(endReport1)).Should(Succeed())
+									Ω(client.PostSuiteDidEnd(endReport2)).Should(Succeed())
+								})
+
+								It("does not forward them yet...", func() {
+									Ω(reporter.End).Should(BeZero())
 								})
 
-								It("forwards the aggregation of all received end summaries", func() {
-									Ω(reporter.End.StartTime.Unix()).Should(BeNumerically("~", t.Add(-2*time.Second).Unix()))
-									Ω(reporter.End.EndTime.Unix()).Should(BeNumerically("~", t.Add(2*time.Second).Unix()))
-									Ω(reporter.End.RunTime).Should(BeNumerically("~", 4*time.Second))
-									Ω(reporter.End.SuiteSucceeded).Should(BeFalse())
-									Ω(reporter.End.SpecReports).Should(ConsistOf(specReportA, specReportB, specReportC))
+								It("doesn't signal it's done", func() {
+									Ω(server.GetSuiteDone()).ShouldNot(BeClosed())
 								})
 
-								It("should signal it's done", func() {
-									Ω(server.GetSuiteDone()).Should(BeClosed())
+								Context("when the final SuiteDidEnd arrive", func() {
+									BeforeEach(func() {
+										Ω(client.PostSuiteDidEnd(endReport3)).Should(Succeed())
+									})
+
+									It("forwards the aggregation of all received end summaries", func() {
+										Ω(reporter.End.StartTime.Unix()).Should(BeNumerically("~", t.Add(-2*time.Second).Unix()))
+										Ω(reporter.End.EndTime.Unix()).Should(BeNumerically("~", t.Add(2*time.Second).Unix()))
+										Ω(reporter.End.RunTime).Should(BeNumerically("~", 4*time.Second))
+										Ω(reporter.End.SuiteSucceeded).Should(BeFalse())
+										Ω(reporter.End.SpecReports).Should(ConsistOf(specReportA, specReportB, specReportC))
+									})
+
+									It("should signal it's done", func() {
+										Ω(server.GetSuiteDone()).Should(BeClosed())
+									})
 								})
 							})
 						})
 					})
 				})
-			})
 
-			Describe("supporting ReportEntries (which RPC struggled with when I first implemented it)", func() {
-				BeforeEach(func() {
-					Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
-					Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
-					Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
+				Describe("supporting ReportEntries (which RPC struggled with when I first implemented it)", func() {
+					BeforeEach(func() {
+						Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
+						Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
+						Ω(client.PostSuiteWillBegin(types.Report{SuiteDescription: "my sweet suite"})).Should(Succeed())
+					})
+					It("can pass in ReportEntries that include custom types", func() {
+						cl := types.NewCodeLocation(0)
+						entry, err := internal.NewReportEntry("No Value Entry", cl)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(client.PostDidRun(types.SpecReport{
+							LeafNodeText:  "no-value",
+							ReportEntries: types.ReportEntries{entry},
+						})).Should(Succeed())
+
+						entry, err = internal.NewReportEntry("String Value Entry", cl, "The String")
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(client.PostDidRun(types.SpecReport{
+							LeafNodeText:  "string-value",
+							ReportEntries: types.ReportEntries{entry},
+						})).Should(Succeed())
+
+						entry, err = internal.NewReportEntry("Custom Type Value Entry", cl, ColorableStringerStruct{Label: "apples", Count: 17})
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(client.PostDidRun(types.SpecReport{
+							LeafNodeText:  "custom-value",
+							ReportEntries: types.ReportEntries{entry},
+						})).Should(Succeed())
+
+						Ω(reporter.Did.Find("no-value").ReportEntries[0].Name).Should(Equal("No Value Entry"))
+						Ω(reporter.Did.Find("no-value").ReportEntries[0].StringRepresentation()).Should(Equal(""))
+
+						Ω(reporter.Did.Find("string-value").ReportEntries[0].Name).Should(Equal("String Value Entry"))
+						Ω(reporter.Did.Find("string-value").ReportEntries[0].StringRepresentation()).Should(Equal("The String"))
+
+						Ω(reporter.Did.Find("custom-value").ReportEntries[0].Name).Should(Equal("Custom Type Value Entry"))
+						Ω(reporter.Did.Find("custom-value").ReportEntries[0].StringRepresentation()).Should(Equal("{{red}}apples {{green}}17{{/}}"))
+					})
 				})
-				It("can pass in ReportEntries that include custom types", func() {
-					cl := types.NewCodeLocation(0)
-					entry, err := internal.NewReportEntry("No Value Entry", cl)
-					Ω(err).ShouldNot(HaveOccurred())
-					Ω(client.PostDidRun(types.SpecReport{
-						LeafNodeText:  "no-value",
-						ReportEntries: types.ReportEntries{entry},
-					})).Should(Succeed())
-
-					entry, err = internal.NewReportEntry("String Value Entry", cl, "The String")
-					Ω(err).ShouldNot(HaveOccurred())
-					Ω(client.PostDidRun(types.SpecReport{
-						LeafNodeText:  "string-value",
-						ReportEntries: types.ReportEntries{entry},
-					})).Should(Succeed())
-
-					entry, err = internal.NewReportEntry("Custom Type Value Entry", cl, ColorableStringerStruct{Label: "apples", Count: 17})
-					Ω(err).ShouldNot(HaveOccurred())
-					Ω(client.PostDidRun(types.SpecReport{
-						LeafNodeText:  "custom-value",
-						ReportEntries: types.ReportEntries{entry},
-					})).Should(Succeed())
-
-					Ω(reporter.Did.Find("no-value").ReportEntries[0].Name).Should(Equal("No Value Entry"))
-					Ω(reporter.Did.Find("no-value").ReportEntries[0].StringRepresentation()).Should(Equal(""))
 
-					Ω(reporter.Did.Find("string-value").ReportEntries[0].Name).Should(Equal("String Value Entry"))
-					Ω(reporter.Did.Find("string-value").ReportEntries[0].StringRepresentation()).Should(Equal("The String"))
+				Describe("Streaming output", func() {
+					It("is configured to stream to stdout", func() {
+						server, err := parallel_support.NewServer(3, reporter)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(server.GetOutputDestination().(*os.File).Fd()).Should(Equal(uintptr(1)))
+					})
 
-					Ω(reporter.Did.Find("custom-value").ReportEntries[0].Name).Should(Equal("Custom Type Value Entry"))
-					Ω(reporter.Did.Find("custom-value").ReportEntries[0].StringRepresentation()).Should(Equal("{{red}}apples {{green}}17{{/}}"))
+					It("streams output to the provided buffer", func() {
+						n, err := client.Write([]byte("hello"))
+						Ω(n).Should(Equal(5))
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(buffer).Should(gbytes.Say("hello"))
+					})
 				})
-			})
 
-			Describe("Streaming output", func() {
-				It("is configured to stream to stdout", func() {
-					server, err := parallel_support.NewServer(3, reporter)
-					Ω(err).ShouldNot(HaveOccurred())
-					Ω(server.GetOutputDestination().(*os.File).Fd()).Should(Equal(uintptr(1)))
-				})
+				Describe("Streaming events", func() {
+					It("drops events when no stream destination is configured", func() {
+						Ω(client.PostEmitStreamEvent([]byte("{}\n"))).Should(Succeed())
+					})
 
-				It("streams output to the provided buffer", func() {
-					n, err := client.Write([]byte("hello"))
-					Ω(n).Should(Equal(5))
-					Ω(err).ShouldNot(HaveOccurred())
-					Ω(buffer).Should(gbytes.Say("hello"))
+					It("writes events to the stream destination as they arrive", func() {
+						stream := gbytes.NewBuffer()
+						server.SetStreamDestination(stream)
+						writer := parallel_support.NewStreamEventWriter(client)
+
+						n, err := writer.Write([]byte("{\"Event\":\"WillRun\"}\n"))
+						Ω(n).Should(Equal(20))
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(client.PostEmitStreamEvent([]byte("{\"Event\":\"DidRun\"}\n"))).Should(Succeed())
+						Ω(string(stream.Contents())).Should(Equal("{\"Event\":\"WillRun\"}\n{\"Event\":\"DidRun\"}\n"))
+					})
 				})
-			})
 
-			Describe("progress reports", func() {
-				It("can emit progress reports", func() {
-					pr := types.ProgressReport{LeafNodeText: "hola"}
-					Ω(client.PostEmitProgressReport(pr)).Should(Succeed())
-					Ω(reporter.ProgressReports).Should(ConsistOf(pr))
+				Describe("progress reports", func() {
+					It("can emit progress reports", func() {
+						pr := types.ProgressReport{LeafNodeText: "hola"}
+						Ω(client.PostEmitProgressReport(pr)).Should(Succeed())
+						Ω(reporter.ProgressReports).Should(ConsistOf(pr))
+					})
 				})
-			})
 
-			Describe("Synchronization endpoints", func() {
-				var proc1Exited, proc2Exited, proc3Exited chan any
-				BeforeEach(func() {
-					proc1Exited, proc2Exited, proc3Exited = make(chan any), make(chan any), make(chan any)
-					aliveFunc := func(c chan any) func() bool {
-						return func() bool {
-							select {
-							case <-c:
-								return false
-							default:
-								return true
+				Describe("Synchronization endpoints", func() {
+					var proc1Exited, proc2Exited, proc3Exited chan any
+					BeforeEach(func() {
+						proc1Exited, proc2Exited, proc3Exited = make(chan any), make(chan any), make(chan any)
+						aliveFunc := func(c chan any) func() bool {
+							return func() bool {
+								select {
+								case <-c:
+									return false
+								default:
+									return true
+								}
 							}
 						}
-					}
-					server.RegisterAlive(1, aliveFunc(proc1Exited))
-					server.RegisterAlive(2, aliveFunc(proc2Exited))
-					server.RegisterAlive(3, aliveFunc(proc3Exited))
-				})
+						server.RegisterAlive(1, aliveFunc(proc1Exited))
+						server.RegisterAlive(2, aliveFunc(proc2Exited))
+						server.RegisterAlive(3, aliveFunc(proc3Exited))
+					})
 
-				Describe("Managing ReportBeforeSuite synchronization", func() {
-					Context("when proc 1 succeeds", func() {
-						It("passes that success along to other procs", func() {
-							Ω(client.PostReportBeforeSuiteCompleted(types.SpecStatePassed)).Should(Succeed())
-							state, err := client.BlockUntilReportBeforeSuiteCompleted()
-							Ω(state).Should(Equal(types.SpecStatePassed))
-							Ω(err).ShouldNot(HaveOccurred())
+					Describe("Managing ReportBeforeSuite synchronization", func() {
+						Context("when proc 1 succeeds", func() {
+							It("passes that success along to other procs", func() {
+								Ω(client.PostReportBeforeSuiteCompleted(types.SpecStatePassed)).Should(Succeed())
+								state, err := client.BlockUntilReportBeforeSuiteCompleted()
+								Ω(state).Should(Equal(types.SpecStatePassed))
+								Ω(err).ShouldNot(HaveOccurred())
+							})
 						})
-					})
 
-					Context("when proc 1 fails", func() {
-						It("passes that state information along to the other procs", func() {
-							Ω(client.PostReportBeforeSuiteCompleted(types.SpecStateFailed)).Should(Succeed())
-							state, err := client.BlockUntilReportBeforeSuiteCompleted()
-							Ω(state).Should(Equal(types.SpecStateFailed))
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 fails", func() {
+							It("passes that state information along to the other procs", func() {
+								Ω(client.PostReportBeforeSuiteCompleted(types.SpecStateFailed)).Should(Succeed())
+								state, err := client.BlockUntilReportBeforeSuiteCompleted()
+								Ω(state).Should(Equal(types.SpecStateFailed))
+								Ω(err).ShouldNot(HaveOccurred())
+							})
 						})
-					})
 
-					Context("when proc 1 disappears before reporting back", func() {
-						It("returns a meaningful error", func() {
-							close(proc1Exited)
-							state, err := client.BlockUntilReportBeforeSuiteCompleted()
-							Ω(state).Should(Equal(types.SpecStateFailed))
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 disappears before reporting back", func() {
+							It("returns a meaningful error", func() {
+								close(proc1Exited)
+								state, err := client.BlockUntilReportBeforeSuiteCompleted()
+								Ω(state).Should(Equal(types.SpecStateFailed))
+								Ω(err).ShouldNot(HaveOccurred())
+							})
+						})
+
+						Context("when proc 1 hasn't responded yet", func() {
+							It("blocks until it does", func() {
+								done := make(chan any)
+								go func() {
+									defer GinkgoRecover()
+									state, err := client.BlockUntilReportBeforeSuiteCompleted()
+									Ω(state).Should(Equal(types.SpecStatePassed))
+									Ω(err).ShouldNot(HaveOccurred())
+									close(done)
+								}()
+								Consistently(done).ShouldNot(BeClosed())
+								Ω(client.PostReportBeforeSuiteCompleted(types.SpecStatePassed)).Should(Succeed())
+								Eventually(done).Should(BeClosed())
+							})
 						})
 					})
 
-					Context("when proc 1 hasn't responded yet", func() {
-						It("blocks until it does", func() {
-							done := make(chan any)
-							go func() {
-								defer GinkgoRecover()
-								state, err := client.BlockUntilReportBeforeSuiteCompleted()
+					Describe("Managing SynchronizedBeforeSuite synchronization", func() {
+						Context("when proc 1 succeeds and returns data", func() {
+							It("passes that data along to other procs", func() {
+								Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, []byte("hello there"))).Should(Succeed())
+								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
 								Ω(state).Should(Equal(types.SpecStatePassed))
+								Ω(data).Should(Equal([]byte("hello there")))
 								Ω(err).ShouldNot(HaveOccurred())
-								close(done)
-							}()
-							Consistently(done).ShouldNot(BeClosed())
-							Ω(client.PostReportBeforeSuiteCompleted(types.SpecStatePassed)).Should(Succeed())
-							Eventually(done).Should(BeClosed())
+							})
 						})
-					})
-				})
 
-				Describe("Managing SynchronizedBeforeSuite synchronization", func() {
-					Context("when proc 1 succeeds and returns data", func() {
-						It("passes that data along to other procs", func() {
-							Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, []byte("hello there"))).Should(Succeed())
-							state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-							Ω(state).Should(Equal(types.SpecStatePassed))
-							Ω(data).Should(Equal([]byte("hello there")))
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 succeeds and the data happens to be nil", func() {
+							It("passes reports success and returns nil", func() {
+								Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, nil)).Should(Succeed())
+								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+								Ω(state).Should(Equal(types.SpecStatePassed))
+								Ω(data).Should(BeNil())
+								Ω(err).ShouldNot(HaveOccurred())
+							})
 						})
-					})
 
-					Context("when proc 1 succeeds and the data happens to be nil", func() {
-						It("passes reports success and returns nil", func() {
-							Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, nil)).Should(Succeed())
-							state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-							Ω(state).Should(Equal(types.SpecStatePassed))
-							Ω(data).Should(BeNil())
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 is skipped", func() {
+							It("passes that state information along to the other procs", func() {
+								Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateSkipped, nil)).Should(Succeed())
+								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+								Ω(state).Should(Equal(types.SpecStateSkipped))
+								Ω(data).Should(BeNil())
+								Ω(err).ShouldNot(HaveOccurred())
+							})
 						})
-					})
 
-					Context("when proc 1 is skipped", func() {
-						It("passes that state information along to the other procs", func() {
-							Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateSkipped, nil)).Should(Succeed())
-							state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-							Ω(state).Should(Equal(types.SpecStateSkipped))
-							Ω(data).Should(BeNil())
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 fails", func() {
+							It("passes that state information along to the other procs", func() {
+								Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateFailed, nil)).Should(Succeed())
+								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+								Ω(state).Should(Equal(types.SpecStateFailed))
+								Ω(data).Should(BeNil())
+								Ω(err).ShouldNot(HaveOccurred())
+							})
 						})
-					})
 
-					Context("when proc 1 fails", func() {
-						It("passes that state information along to the other procs", func() {
-							Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStateFailed, nil)).Should(Succeed())
-							state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-							Ω(state).Should(Equal(types.SpecStateFailed))
-							Ω(data).Should(BeNil())
-							Ω(err).ShouldNot(HaveOccurred())
+						Context("when proc 1 disappears before reporting back", func() {
+							It("returns a meaningful error", func() {
+								close(proc1Exited)
+								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+								Ω(state).Should(Equal(types.SpecStateInvalid))
+								Ω(data).Should(BeNil())
+								Ω(err).Should(MatchError(types.GinkgoErrors.SynchronizedBeforeSuiteDisappearedOnProc1()))
+							})
 						})
-					})
 
-					Context("when proc 1 disappears before reporting back", func() {
-						It("returns a meaningful error", func() {
-							close(proc1Exited)
-							state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-							Ω(state).Should(Equal(types.SpecStateInvalid))
-							Ω(data).Should(BeNil())
-							Ω(err).Should(MatchError(types.GinkgoErrors.SynchronizedBeforeSuiteDisappearedOnProc1()))
+						Context("when proc 1 hasn't responded yet", func() {
+							It("blocks until it does", func() {
+								done := make(chan any)
+								go func() {
+									defer GinkgoRecover()
+									state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
+									Ω(state).Should(Equal(types.SpecStatePassed))
+									Ω(data).Should(Equal([]byte("hello there")))
+									Ω(err).ShouldNot(HaveOccurred())
+									close(done)
+								}()
+								Consistently(done).ShouldNot(BeClosed())
+								Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, []byte("hello there"))).Should(Succeed())
+								Eventually(done).Should(BeClosed())
+							})
 						})
 					})
 
-					Context("when proc 1 hasn't responded yet", func() {
-						It("blocks until it does", func() {
+					Describe("BlockUntilNonprimaryProcsHaveFinished", func() {
+						It("blocks until non-primary procs exit", func() {
 							done := make(chan any)
 							go func() {
 								defer GinkgoRecover()
-								state, data, err := client.BlockUntilSynchronizedBeforeSuiteData()
-								Ω(state).Should(Equal(types.SpecStatePassed))
-								Ω(data).Should(Equal([]byte("hello there")))
-								Ω(err).ShouldNot(HaveOccurred())
+								Ω(client.BlockUntilNonprimaryProcsHaveFinished()).Should(Succeed())
 								close(done)
 							}()
 							Consistently(done).ShouldNot(BeClosed())
-							Ω(client.PostSynchronizedBeforeSuiteCompleted(types.SpecStatePassed, []byte("hello there"))).Should(Succeed())
+							close(proc2Exited)
+							Consistently(done).ShouldNot(BeClosed())
+							close(proc3Exited)
 							Eventually(done).Should(BeClosed())
 						})
 					})
-				})
-
-				Describe("BlockUntilNonprimaryProcsHaveFinished", func() {
-					It("blocks until non-primary procs exit", func() {
-						done := make(chan any)
-						go func() {
-							defer GinkgoRecover()
-							Ω(client.BlockUntilNonprimaryProcsHaveFinished()).Should(Succeed())
-							close(done)
-						}()
-						Consistently(done).ShouldNot(BeClosed())
-						close(proc2Exited)
-						Consistently(done).ShouldNot(BeClosed())
-						close(proc3Exited)
-						Eventually(done).Should(BeClosed())
-					})
-				})
 
-				Describe("BlockUntilAggregatedNonprimaryProcsReport", func() {
-					var specReportA, specReportB types.SpecReport
-					var endReport2, endReport3 types.Report
+					Describe("BlockUntilAggregatedNonprimaryProcsReport", func() {
+						var specReportA, specReportB types.SpecReport
+						var endReport2, endReport3 types.Report
 
-					BeforeEach(func() {
-						specReportA = types.SpecReport{LeafNodeText: "A"}
-						specReportB = types.SpecReport{LeafNodeText: "B"}
-						endReport2 = types.Report{SpecReports: types.SpecReports{specReportA}}
-						endReport3 = types.Report{SpecReports: types.SpecReports{specReportB}}
-					})
-
-					It("blocks until all non-primary procs exit, then returns the aggregated report", func() {
-						done := make(chan any)
-						go func() {
-							defer GinkgoRecover()
-							report, err := client.BlockUntilAggregatedNonprimaryProcsReport()
-							Ω(err).ShouldNot(HaveOccurred())
-							Ω(report.SpecReports).Should(ConsistOf(specReportA, specReportB))
-							close(done)
-						}()
-						Consistently(done).ShouldNot(BeClosed())
-
-						Ω(client.PostSuiteDidEnd(endReport2)).Should(Succeed())
-						close(proc2Exited)
-						Consistently(done).ShouldNot(BeClosed())
-
-						Ω(client.PostSuiteDidEnd(endReport3)).Should(Succeed())
-						close(proc3Exited)
-						Eventually(done).Should(BeClosed())
-					})
+						BeforeEach(func() {
+							specReportA = types.SpecReport{LeafNodeText: "A"}
+							specReportB = types.SpecReport{LeafNodeText: "B"}
+							endReport2 = types.Report{SpecReports: types.SpecReports{specReportA}}
+							endReport3 = types.Report{SpecReports: types.SpecReports{specReportB}}
+						})
 
-					Context("when a non-primary proc disappears without reporting back", func() {
-						It("blocks returns an appropriate error", func() {
+						It("blocks until all non-primary procs exit, then returns the aggregated report", func() {
 							done := make(chan any)
 							go func() {
 								defer GinkgoRecover()
 								report, err := client.BlockUntilAggregatedNonprimaryProcsReport()
-								Ω(err).Should(Equal(types.GinkgoErrors.AggregatedReportUnavailableDueToNodeDisappearing()))
-								Ω(report).Should(BeZero())
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(report.SpecReports).Should(ConsistOf(specReportA, specReportB))
 								close(done)
 							}()
 							Consistently(done).ShouldNot(BeClosed())
@@ -411,38 +412,392 @@ var _ = Describe("The Parallel Support Client & Server", func() {
 							close(proc2Exited)
 							Consistently(done).ShouldNot(BeClosed())
 
+							Ω(client.PostSuiteDidEnd(endReport3)).Should(Succeed())
 							close(proc3Exited)
 							Eventually(done).Should(BeClosed())
 						})
+
+						Context("when a non-primary proc disappears without reporting back", func() {
+							It("blocks returns an appropriate error", func() {
+								done := make(chan any)
+								go func() {
+									defer GinkgoRecover()
+									report, err := client.BlockUntilAggregatedNonprimaryProcsReport()
+									Ω(err).Should(Equal(types.GinkgoErrors.AggregatedReportUnavailableDueToNodeDisappearing()))
+									Ω(report).Should(BeZero())
+									close(done)
+								}()
+								Consistently(done).ShouldNot(BeClosed())
+
+								Ω(client.PostSuiteDidEnd(endReport2)).Should(Succeed())
+								close(proc2Exited)
+								Consistently(done).ShouldNot(BeClosed())
+
+								close(proc3Exited)
+								Eventually(done).Should(BeClosed())
+							})
+						})
 					})
-				})
 
-				Describe("Fetching counters", func() {
-					It("returns ascending counters", func() {
-						Ω(client.FetchNextCounter()).Should(Equal(0))
-						Ω(client.FetchNextCounter()).Should(Equal(1))
-						Ω(client.FetchNextCounter()).Should(Equal(2))
-						Ω(client.FetchNextCounter()).Should(Equal(3))
+					Describe("Fetching counters", func() {
+						It("returns ascending counters", func() {
+							Ω(client.FetchNextCounter()).Should(Equal(0))
+							Ω(client.FetchNextCounter()).Should(Equal(1))
+							Ω(client.FetchNextCounter()).Should(Equal(2))
+							Ω(client.FetchNextCounter()).Should(Equal(3))
+						})
 					})
-				})
 
-				Describe("Aborting", func() {
-					It("should not abort by default", func() {
-						Ω(client.ShouldAbort()).Should(BeFalse())
+					Describe("Aborting", func() {
+						It("should not abort by default", func() {
+							Ω(client.ShouldAbort()).Should(BeFalse())
+						})
+
+						Context("when told to abort", func() {
+							BeforeEach(func() {
+								Ω(client.PostAbort()).Should(Succeed())
+							})
+
+							It("should abort", func() {
+								Ω(client.ShouldAbort()).Should(BeTrue())
+							})
+						})
 					})
 
-					Context("when told to abort", func() {
+					Describe("Exclusive resources", func() {
+						request := func(proc int, resources ...string) parallel_support.ExclusiveResourcesRequest {
+							return parallel_support.ExclusiveResourcesRequest{Proc: proc, Resources: resources}
+						}
+
 						BeforeEach(func() {
-							Ω(client.PostAbort()).Should(Succeed())
+							wait := parallel_support.EXCLUSIVE_RESOURCES_WAIT
+							parallel_support.EXCLUSIVE_RESOURCES_WAIT = 100 * time.Millisecond
+							DeferCleanup(func() { parallel_support.EXCLUSIVE_RESOURCES_WAIT = wait })
+						})
+
+						It("grants locks on resources that aren't held", func() {
+							lock, err := client.AcquireExclusiveResources(request(1, "database", "cache"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+
+							lock, err = client.AcquireExclusiveResources(request(2, "queue"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+						})
+
+						It("puts requests for held resources in line until the resources are released", func() {
+							lock, err := client.AcquireExclusiveResources(request(1, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+
+							waiting, err := client.AcquireExclusiveResources(request(2, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(waiting.Acquired).Should(BeFalse())
+							Ω(waiting.Token).ShouldNot(BeZero())
+
+							Ω(client.ReleaseExclusiveResources(lock.Token)).Should(Succeed())
+							waitingRequest := request(2, "database")
+							waitingRequest.Token = waiting.Token
+							Ω(client.AcquireExclusiveResources(waitingRequest)).Should(Equal(parallel_support.ExclusiveResourcesLock{Token: waiting.Token, Acquired: true}))
+						})
+
+						It("holds requests that are in line open until they are granted", func() {
+							lock, err := client.AcquireExclusiveResources(request(1, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							waiting, err := client.AcquireExclusiveResources(request(2, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+
+							waitingRequest := request(2, "database")
+							waitingRequest.Token = waiting.Token
+							granted := make(chan parallel_support.ExclusiveResourcesLock, 1)
+							go func() {
+								lock, _ := client.AcquireExclusiveResources(waitingRequest)
+								granted <- lock
+							}()
+							time.Sleep(20 * time.Millisecond)
+							Ω(client.ReleaseExclusiveResources(lock.Token)).Should(Succeed())
+							Eventually(granted, 50*time.Millisecond).Should(Receive(Equal(parallel_support.ExclusiveResourcesLock{Token: waiting.Token, Acquired: true})))
+						})
+
+						It("gives up holding a request open after EXCLUSIVE_RESOURCES_WAIT", func() {
+							_, err := client.AcquireExclusiveResources(request(1, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							waiting, err := client.AcquireExclusiveResources(request(2, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+
+							waitingRequest := request(2, "database")
+							waitingRequest.Token = waiting.Token
+							t := time.Now()
+							Ω(client.AcquireExclusiveResources(waitingRequest)).Should(Equal(parallel_support.ExclusiveResourcesLock{Token: waiting.Token}))
+							Ω(time.Since(t)).Should(BeNumerically(">=", 100*time.Millisecond))
+						})
+
+						It("grants all of the requested resources or none of them", func() {
+							lock, err := client.AcquireExclusiveResources(request(1, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+
+							waiting, err := client.AcquireExclusiveResources(request(2, "cache", "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(waiting.Acquired).Should(BeFalse())
+							Ω(client.ReleaseExclusiveResources(waiting.Token)).Should(Succeed())
+
+							lock, err = client.AcquireExclusiveResources(request(3, "cache"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+						})
+
+						It("does not let later requests starve a request that is waiting on several resources", func() {
+							database, err := client.AcquireExclusiveResources(request(1, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(database.Acquired).Should(BeTrue())
+
+							both, err := client.AcquireExclusiveResources(request(2, "database", "cache"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(both.Acquired).Should(BeFalse())
+
+							cache, err := client.AcquireExclusiveResources(request(3, "cache"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(cache.Acquired).Should(BeFalse(), "cache is free, but proc 2 is ahead in line for it")
+
+							queue, err := client.AcquireExclusiveResources(request(4, "queue"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(queue.Acquired).Should(BeTrue(), "requests that don't overlap with the line are granted")
+
+							Ω(client.ReleaseExclusiveResources(database.Token)).Should(Succeed())
+							bothRequest := request(2, "database", "cache")
+							bothRequest.Token = both.Token
+							Ω(client.AcquireExclusiveResources(bothRequest)).Should(Equal(parallel_support.ExclusiveResourcesLock{Token: both.Token, Acquired: true}))
+
+							cacheRequest := request(3, "cache")
+							cacheRequest.Token = cache.Token
+							Ω(client.AcquireExclusiveResources(cacheRequest)).Should(Equal(parallel_support.ExclusiveResourcesLock{Token: cache.Token}))
+							Ω(client.ReleaseExclusiveResources(both.Token)).Should(Succeed())
+							Ω(client.AcquireExclusiveResources(cacheRequest)).Should(Equal(parallel_support.ExclusiveResourcesLock{Token: cache.Token, Acquired: true}))
 						})
 
-						It("should abort", func() {
-							Ω(client.ShouldAbort()).Should(BeTrue())
+						It("releases the locks held, and drops the requests made, by procs that have exited", func() {
+							proc2Exited := make(chan any)
+							server.RegisterAlive(2, func() bool {
+								select {
+								case <-proc2Exited:
+									return false
+								default:
+									return true
+								}
+							})
+							lock, err := client.AcquireExclusiveResources(request(2, "database"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+							lock, err = client.AcquireExclusiveResources(request(2, "cache"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
+							inLine, err := client.AcquireExclusiveResources(request(2, "database", "queue"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(inLine.Acquired).Should(BeFalse())
+
+							close(proc2Exited)
+							lock, err = client.AcquireExclusiveResources(request(1, "database", "queue"))
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(lock.Acquired).Should(BeTrue())
 						})
 					})
+
 				})
 
+				Describe("Remote procs", func() {
+					var remoteProcs []parallel_support.RemoteProc
+
+					BeforeEach(func() {
+						remoteProcs = []parallel_support.RemoteProc{
+							{Proc: 2, Args: []string{"--ginkgo.parallel.process=2"}},
+							{Proc: 3, Args: []string{"--ginkgo.parallel.process=3"}},
+						}
+						server.AcceptRemoteProcs(remoteProcs)
+					})
+
+					It("hands out the remote procs until there are none left", func() {
+						Ω(client.FetchRemoteProcs(1)).Should(Equal(remoteProcs[:1]))
+						Ω(client.FetchRemoteProcs(5)).Should(Equal(remoteProcs[1:]))
+
+						procs, err := client.FetchRemoteProcs(1)
+						Ω(procs).Should(BeEmpty())
+						Ω(err).Should(Equal(parallel_support.ErrorGone))
+					})
+
+					It("considers remote procs alive until they report that they've exited", func() {
+						Ω(client.FetchRemoteProcs(2)).Should(HaveLen(2))
+						done := make(chan any)
+						go func() {
+							client.BlockUntilNonprimaryProcsHaveFinished()
+							close(done)
+						}()
+
+						Ω(client.PostRemoteProcStatus(parallel_support.RemoteProcStatus{Proc: 2})).Should(Succeed())
+						Ω(client.PostRemoteProcStatus(parallel_support.RemoteProcStatus{Proc: 2, Exited: true, Passed: true, Output: "proc 2"})).Should(Succeed())
+						Eventually(server.GetRemoteProcResults()).Should(Receive(Equal(parallel_support.RemoteProcStatus{Proc: 2, Exited: true, Passed: true, Output: "proc 2"})))
+						Consistently(done).ShouldNot(BeClosed())
+
+						Ω(client.PostRemoteProcStatus(parallel_support.RemoteProcStatus{Proc: 3, Exited: true})).Should(Succeed())
+						Eventually(server.GetRemoteProcResults()).Should(Receive(Equal(parallel_support.RemoteProcStatus{Proc: 3, Exited: true})))
+						Eventually(done).Should(BeClosed())
+					})
+
+					It("ignores statuses for procs that haven't been handed out", func() {
+						Ω(client.PostRemoteProcStatus(parallel_support.RemoteProcStatus{Proc: 2, Exited: true})).Should(Succeed())
+						Consistently(server.GetRemoteProcResults()).ShouldNot(Receive())
+					})
+				})
 			})
+		}
+	}
+
+	Describe("losing contact with remote procs", func() {
+		It("reports remote procs that stop sending heartbeats as lost", func() {
+			server, err := parallel_support.NewServerWithRemoteProcTiming(2, NewFakeReporter(), 10*time.Millisecond, 100*time.Millisecond)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+			DeferCleanup(server.Close)
+			client := parallel_support.NewClient(server.Address())
+			Eventually(client.Connect).Should(BeTrue())
+			DeferCleanup(client.Close)
+
+			server.AcceptRemoteProcs([]parallel_support.RemoteProc{{Proc: 2}})
+			Ω(client.FetchRemoteProcs(1)).Should(HaveLen(1))
+
+			Consistently(func(g Gomega) {
+				g.Expect(client.PostRemoteProcStatus(parallel_support.RemoteProcStatus{Proc: 2})).Should(Succeed())
+				g.Expect(server.GetRemoteProcResults()).ShouldNot(Receive())
+			}, 300*time.Millisecond, 20*time.Millisecond).Should(Succeed())
+
+			Eventually(server.GetRemoteProcResults()).Should(Receive(Equal(parallel_support.RemoteProcStatus{Proc: 2, Exited: true, Lost: true})))
+			Ω(client.BlockUntilNonprimaryProcsHaveFinished()).Should(Succeed())
+		})
+	})
+
+	Describe("choosing a transport", func() {
+		It("listens on a Unix domain socket that is cleaned up when the server closes", func() {
+			server, err := parallel_support.NewServerWithTransport(3, NewFakeReporter(), parallel_support.TransportUnix)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(server.Address()).Should(HavePrefix("unix://"))
+			socket := strings.TrimPrefix(server.Address(), "unix://")
+			Ω(socket).Should(BeAnExistingFile())
+
+			server.Close()
+			Ω(filepath.Dir(socket)).ShouldNot(BeAnExistingFile())
+		})
+
+		It("listens on a loopback port when asked to use tcp", func() {
+			server, err := parallel_support.NewServerWithTransport(3, NewFakeReporter(), parallel_support.TransportTCP)
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(server.Close)
+			Ω(server.Address()).Should(HavePrefix("127.0.0.1:"))
+		})
+
+		It("listens on the requested address so that workers on other machines can connect", func() {
+			for _, protocol := range []string{"RPC", "HTTP"} {
+				GinkgoT().Setenv("GINKGO_PARALLEL_PROTOCOL", protocol)
+				GinkgoT().Setenv(types.PARALLEL_TOKEN_ENV_VAR, "secret")
+				server, err := parallel_support.NewServerOnAddress(3, NewFakeReporter(), "127.0.0.1:0", "secret")
+				Ω(err).ShouldNot(HaveOccurred())
+				server.Start()
+				DeferCleanup(server.Close)
+
+				// workers are given a bare host:port regardless of protocol
+				address := strings.TrimPrefix(server.Address(), "http://")
+				Ω(address).Should(HavePrefix("127.0.0.1:"))
+				client := parallel_support.NewClient(address)
+				Eventually(client.Connect).Should(BeTrue())
+				Ω(client.FetchNextCounter()).Should(Equal(0))
+				client.Close()
+			}
+		})
+
+		It("requires a token to listen on an address", func() {
+			server, err := parallel_support.NewServerOnAddress(3, NewFakeReporter(), "127.0.0.1:0", "")
+			Ω(err).Should(HaveOccurred())
+			Ω(server).Should(BeNil())
+		})
+
+		It("rejects clients that don't present the token", func() {
+			for _, protocol := range []string{"RPC", "HTTP"} {
+				GinkgoT().Setenv("GINKGO_PARALLEL_PROTOCOL", protocol)
+				server, err := parallel_support.NewServerOnAddress(3, NewFakeReporter(), "127.0.0.1:0", "secret")
+				Ω(err).ShouldNot(HaveOccurred())
+				server.Start()
+				DeferCleanup(server.Close)
+				address := strings.TrimPrefix(server.Address(), "http://")
+
+				GinkgoT().Setenv(types.PARALLEL_TOKEN_ENV_VAR, "")
+				Consistently(parallel_support.NewClient(address).Connect, 200*time.Millisecond).Should(BeFalse(), protocol)
+
+				GinkgoT().Setenv(types.PARALLEL_TOKEN_ENV_VAR, "wrong")
+				Consistently(parallel_support.NewClient(address).Connect, 200*time.Millisecond).Should(BeFalse(), protocol)
+			}
+		})
+
+		It("prefers a Unix domain socket by default", func() {
+			if runtime.GOOS == "windows" {
+				Skip("Unix domain sockets are not used by default on Windows")
+			}
+			server, err := parallel_support.NewServer(3, NewFakeReporter())
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(server.Close)
+			Ω(server.Address()).Should(HavePrefix("unix://"))
 		})
+	})
+})
+
+var _ = Describe("ExclusiveResourceLocks", func() {
+	var locks *parallel_support.ExclusiveResourceLocks
+	request := func(resources ...string) parallel_support.ExclusiveResourcesRequest {
+		return parallel_support.ExclusiveResourcesRequest{Proc: 1, Resources: resources}
 	}
+
+	BeforeEach(func() {
+		locks = parallel_support.NewExclusiveResourceLocks()
+	})
+
+	It("grants waiting requests in the order they were made, without polling", func() {
+		database := locks.TryAcquire(request("database"), nil)
+		Ω(database.Acquired).Should(BeTrue())
+
+		granted := make(chan string, 2)
+		both := locks.TryAcquire(request("database", "cache"), nil)
+		go func() {
+			r := request("database", "cache")
+			r.Token = both.Token
+			locks.Acquire(r, nil, nil)
+			granted <- "both"
+		}()
+		cache := locks.TryAcquire(request("cache"), nil)
+		Ω(cache.Acquired).Should(BeFalse())
+		go func() {
+			r := request("cache")
+			r.Token = cache.Token
+			locks.Acquire(r, nil, nil)
+			granted <- "cache"
+		}()
+
+		Consistently(granted).ShouldNot(Receive())
+		locks.Release(database.Token)
+		Eventually(granted).Should(Receive(Equal("both")))
+		Consistently(granted).ShouldNot(Receive())
+		locks.Release(both.Token)
+		Eventually(granted).Should(Receive(Equal("cache")))
+	})
+
+	It("stops waiting when stop is closed, leaving the request in line", func() {
+		locks.TryAcquire(request("database"), nil)
+		stop := make(chan any)
+		close(stop)
+		lock := locks.Acquire(request("database"), nil, stop)
+		Ω(lock.Acquired).Should(BeFalse())
+		Ω(lock.Token).ShouldNot(BeZero())
+
+		locks.Release(lock.Token)
+		r := request("database")
+		r.Token = lock.Token
+		Ω(locks.TryAcquire(r, nil)).Should(Equal(parallel_support.ExclusiveResourcesLock{}), "released requests leave the line")
+	})
 })
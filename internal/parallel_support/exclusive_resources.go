@@ -0,0 +1,184 @@
+package parallel_support
+
+import (
+	"sync"
+)
+
+// ExclusiveResourcesRequest is sent by a process that would like to hold a set of exclusive resources.  Token identifies a request that is already waiting in line - leave it zero to get in line.
+type ExclusiveResourcesRequest struct {
+	Proc      int
+	Resources []string
+	Token     int
+}
+
+// ExclusiveResourcesLock describes the outcome of an ExclusiveResourcesRequest.  Token identifies the request's place in line (and, once Acquired, the lock) and must be used to release it.  A zero Token means the request is no longer waiting in line.
+type ExclusiveResourcesLock struct {
+	Token    int
+	Acquired bool
+}
+
+type exclusiveResourcesClaim struct {
+	proc      int
+	resources []string
+}
+
+/*
+ExclusiveResourceLocks hands out locks on named resources.  A lock covers a set of resources and is granted all-or-nothing: either every resource in the set is free and the lock is granted, or none of them are claimed.  This prevents processes that need overlapping sets of resources from deadlocking one another.
+
+Requests wait in line and are granted in the order they arrive.  A request is only granted if no request ahead of it in line is waiting on any of the same resources - so a request for {database, cache} can't be starved by a stream of requests for {database} and {cache} that never leave both free at once.
+
+The server uses ExclusiveResourceLocks to coordinate between parallel processes.  When running in series the suite uses its own ExclusiveResourceLocks to coordinate between specs marked Concurrent.
+*/
+type ExclusiveResourceLocks struct {
+	lock      *sync.Mutex
+	holders   map[int]exclusiveResourcesClaim
+	heldBy    map[string]int
+	waiters   map[int]exclusiveResourcesClaim
+	line      []int
+	changed   chan any
+	lastToken int
+}
+
+func NewExclusiveResourceLocks() *ExclusiveResourceLocks {
+	return &ExclusiveResourceLocks{
+		lock:    &sync.Mutex{},
+		holders: map[int]exclusiveResourcesClaim{},
+		heldBy:  map[string]int{},
+		waiters: map[int]exclusiveResourcesClaim{},
+		changed: make(chan any),
+	}
+}
+
+// TryAcquire puts request in line (if it isn't already) and grants it a lock if it has reached the front of the line for all of its resources.  It does not wait.  Locks held and requests made by processes that procIsAlive reports as gone are dropped.  procIsAlive may be nil.
+func (l *ExclusiveResourceLocks) TryAcquire(request ExclusiveResourcesRequest, procIsAlive func(int) bool) ExclusiveResourcesLock {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.tryAcquire(request, procIsAlive)
+}
+
+// Acquire is like TryAcquire but waits until the request is granted, the request leaves the line (because it was released), or stop is closed.
+func (l *ExclusiveResourceLocks) Acquire(request ExclusiveResourcesRequest, procIsAlive func(int) bool, stop <-chan any) ExclusiveResourcesLock {
+	for {
+		l.lock.Lock()
+		lock := l.tryAcquire(request, procIsAlive)
+		changed := l.changed
+		l.lock.Unlock()
+		if lock.Acquired || lock.Token == 0 {
+			return lock
+		}
+		request.Token = lock.Token
+		select {
+		case <-changed:
+		case <-stop:
+			return lock
+		}
+	}
+}
+
+// Release releases the lock identified by token, or takes the request identified by token out of line if it is still waiting.  Releasing a token that isn't held or waiting is a no-op.
+func (l *ExclusiveResourceLocks) Release(token int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, waiting := l.waiters[token]; waiting {
+		l.leaveLine(token)
+		return
+	}
+	l.release(token)
+}
+
+func (l *ExclusiveResourceLocks) tryAcquire(request ExclusiveResourcesRequest, procIsAlive func(int) bool) ExclusiveResourcesLock {
+	token := request.Token
+	if _, held := l.holders[token]; held && token != 0 {
+		return ExclusiveResourcesLock{Token: token, Acquired: true}
+	}
+	if _, waiting := l.waiters[token]; !waiting {
+		if token != 0 {
+			return ExclusiveResourcesLock{}
+		}
+		l.lastToken += 1
+		token = l.lastToken
+		l.waiters[token] = exclusiveResourcesClaim{proc: request.Proc, resources: request.Resources}
+		l.line = append(l.line, token)
+	}
+
+	l.grant(procIsAlive)
+
+	if _, held := l.holders[token]; held {
+		return ExclusiveResourcesLock{Token: token, Acquired: true}
+	}
+	if _, waiting := l.waiters[token]; !waiting {
+		return ExclusiveResourcesLock{}
+	}
+	return ExclusiveResourcesLock{Token: token}
+}
+
+// grant walks the line in order, granting every request whose resources are neither held nor wanted by a request further ahead
+func (l *ExclusiveResourceLocks) grant(procIsAlive func(int) bool) {
+	wantedAhead := map[string]bool{}
+	for _, token := range append([]int{}, l.line...) {
+		waiter := l.waiters[token]
+		if l.isFree(waiter, wantedAhead, procIsAlive) {
+			l.leaveLine(token)
+			l.holders[token] = waiter
+			for _, resource := range waiter.resources {
+				l.heldBy[resource] = token
+			}
+			continue
+		}
+		if procIsAlive != nil && !procIsAlive(waiter.proc) {
+			l.leaveLine(token)
+			continue
+		}
+		for _, resource := range waiter.resources {
+			wantedAhead[resource] = true
+		}
+	}
+}
+
+func (l *ExclusiveResourceLocks) isFree(waiter exclusiveResourcesClaim, wantedAhead map[string]bool, procIsAlive func(int) bool) bool {
+	for _, resource := range waiter.resources {
+		if wantedAhead[resource] {
+			return false
+		}
+		token, held := l.heldBy[resource]
+		if !held {
+			continue
+		}
+		holder := l.holders[token]
+		if procIsAlive != nil && holder.proc != waiter.proc && !procIsAlive(holder.proc) {
+			l.release(token)
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func (l *ExclusiveResourceLocks) leaveLine(token int) {
+	delete(l.waiters, token)
+	for i, t := range l.line {
+		if t == token {
+			l.line = append(l.line[:i], l.line[i+1:]...)
+			break
+		}
+	}
+	l.notifyChanged()
+}
+
+func (l *ExclusiveResourceLocks) release(token int) {
+	holder, ok := l.holders[token]
+	if !ok {
+		return
+	}
+	for _, resource := range holder.resources {
+		delete(l.heldBy, resource)
+	}
+	delete(l.holders, token)
+	l.notifyChanged()
+}
+
+// notifyChanged wakes any goroutines waiting in Acquire so they can check whether their request has been granted
+func (l *ExclusiveResourceLocks) notifyChanged() {
+	close(l.changed)
+	l.changed = make(chan any)
+}
@@ -0,0 +1,18 @@
+package parallel_support
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+)
+
+// NewServerWithRemoteProcTiming is NewServer with a shorter heartbeat interval and timeout for remote procs so that tests don't have to wait REMOTE_PROC_TIMEOUT to see a remote proc get lost
+func NewServerWithRemoteProcTiming(parallelTotal int, reporter reporters.Reporter, heartbeatInterval time.Duration, timeout time.Duration) (Server, error) {
+	listener, err := listen(TransportAuto)
+	if err != nil {
+		return nil, err
+	}
+	handler := newServerHandler(parallelTotal, reporter)
+	handler.remoteProcs = newRemoteProcs(heartbeatInterval, timeout)
+	return newServer(listener, handler, ""), nil
+}
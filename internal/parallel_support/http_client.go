@@ -2,10 +2,13 @@ package parallel_support
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -13,16 +16,55 @@ import (
 
 type httpClient struct {
 	serverHost string
+	client     *http.Client
 }
 
-func newHttpClient(serverHost string) *httpClient {
+func newHttpClient(serverHost string, token string) *httpClient {
+	network, address := dialTarget(serverHost)
+	if network != "unix" {
+		// workers are handed a bare host:port when they connect to a Ginkgo CLI on another machine
+		if !strings.HasPrefix(serverHost, "http://") {
+			serverHost = "http://" + serverHost
+		}
+		return &httpClient{
+			serverHost: serverHost,
+			client: &http.Client{
+				Transport: tokenTransport{token: token, transport: http.DefaultTransport},
+			},
+		}
+	}
+
+	// requests are sent over the socket so the host in the URL is just a placeholder
+	dialer := &net.Dialer{}
 	return &httpClient{
-		serverHost: serverHost,
+		serverHost: "http://ginkgo",
+		client: &http.Client{
+			Transport: tokenTransport{token: token, transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", address)
+				},
+			}},
+		},
 	}
 }
 
+// tokenTransport presents the server's token with every request
+type tokenTransport struct {
+	token     string
+	transport http.RoundTripper
+}
+
+func (t tokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.token == "" {
+		return t.transport.RoundTrip(request)
+	}
+	request = request.Clone(request.Context())
+	request.Header.Set(tokenHeader, t.token)
+	return t.transport.RoundTrip(request)
+}
+
 func (client *httpClient) Connect() bool {
-	resp, err := http.Get(client.serverHost + "/up")
+	resp, err := client.client.Get(client.serverHost + "/up")
 	if err != nil {
 		return false
 	}
@@ -43,7 +85,7 @@ func (client *httpClient) post(path string, data any) error {
 		}
 		body = bytes.NewBuffer(encoded)
 	}
-	resp, err := http.Post(client.serverHost+path, "application/json", body)
+	resp, err := client.client.Post(client.serverHost+path, "application/json", body)
 	if err != nil {
 		return err
 	}
@@ -56,7 +98,7 @@ func (client *httpClient) post(path string, data any) error {
 
 func (client *httpClient) poll(path string, data any) error {
 	for {
-		resp, err := http.Get(client.serverHost + path)
+		resp, err := client.client.Get(client.serverHost + path)
 		if err != nil {
 			return err
 		}
@@ -98,6 +140,18 @@ func (client *httpClient) PostEmitProgressReport(report types.ProgressReport) er
 	return client.post("/progress-report", report)
 }
 
+func (client *httpClient) PostEmitStreamEvent(event []byte) error {
+	resp, err := client.client.Post(client.serverHost+"/emit-stream-event", "application/x-ndjson", bytes.NewReader(event))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func (client *httpClient) PostReportBeforeSuiteCompleted(state types.SpecState) error {
 	return client.post("/report-before-suite-completed", state)
 }
@@ -159,8 +213,55 @@ func (client *httpClient) ShouldAbort() bool {
 	return false
 }
 
+func (client *httpClient) AcquireExclusiveResources(request ExclusiveResourcesRequest) (ExclusiveResourcesLock, error) {
+	var lock ExclusiveResourcesLock
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return lock, err
+	}
+	resp, err := client.client.Post(client.serverHost+"/acquire-exclusive-resources", "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return lock, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return lock, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&lock)
+	return lock, err
+}
+
+func (client *httpClient) ReleaseExclusiveResources(token int) error {
+	return client.post("/release-exclusive-resources", token)
+}
+
+func (client *httpClient) FetchRemoteProcs(max int) ([]RemoteProc, error) {
+	var procs []RemoteProc
+	encoded, err := json.Marshal(max)
+	if err != nil {
+		return procs, err
+	}
+	resp, err := client.client.Post(client.serverHost+"/assign-remote-procs", "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return procs, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusGone {
+		return procs, ErrorGone
+	}
+	if resp.StatusCode != http.StatusOK {
+		return procs, fmt.Errorf("received unexpected status code %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&procs)
+	return procs, err
+}
+
+func (client *httpClient) PostRemoteProcStatus(status RemoteProcStatus) error {
+	return client.post("/remote-proc-status", status)
+}
+
 func (client *httpClient) Write(p []byte) (int, error) {
-	resp, err := http.Post(client.serverHost+"/emit-output", "text/plain;charset=UTF-8 ", bytes.NewReader(p))
+	resp, err := client.client.Post(client.serverHost+"/emit-output", "text/plain;charset=UTF-8 ", bytes.NewReader(p))
 	resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		return 0, fmt.Errorf("failed to emit output")
@@ -13,36 +13,33 @@ import (
 	"net"
 	"net/http"
 
-	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
 )
 
 /*
-httpServer spins up on an automatically selected port and listens for communication from the forwarding reporter.
+httpServer spins up on an automatically selected port (or Unix domain socket) and listens for communication from the forwarding reporter.
 It then forwards that communication to attached reporters.
 */
 type httpServer struct {
 	listener net.Listener
 	handler  *ServerHandler
+	token    string
 }
 
-// Create a new server, automatically selecting a port
-func newHttpServer(parallelTotal int, reporter reporters.Reporter) (*httpServer, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		return nil, err
-	}
+// Create a new server that listens on the passed-in listener and hands requests to handler
+func newHttpServer(listener net.Listener, handler *ServerHandler, token string) *httpServer {
 	return &httpServer{
 		listener: listener,
-		handler:  newServerHandler(parallelTotal, reporter),
-	}, nil
+		handler:  handler,
+		token:    token,
+	}
 }
 
 // Start the server.  You don't need to `go s.Start()`, just `s.Start()`
 func (server *httpServer) Start() {
 	httpServer := &http.Server{}
 	mux := http.NewServeMux()
-	httpServer.Handler = mux
+	httpServer.Handler = requireToken(server.token, mux)
 
 	//streaming endpoints
 	mux.HandleFunc("/suite-will-begin", server.specSuiteWillBegin)
@@ -50,6 +47,7 @@ func (server *httpServer) Start() {
 	mux.HandleFunc("/suite-did-end", server.specSuiteDidEnd)
 	mux.HandleFunc("/emit-output", server.emitOutput)
 	mux.HandleFunc("/progress-report", server.emitProgressReport)
+	mux.HandleFunc("/emit-stream-event", server.emitStreamEvent)
 
 	//synchronization endpoints
 	mux.HandleFunc("/report-before-suite-completed", server.handleReportBeforeSuiteCompleted)
@@ -61,6 +59,12 @@ func (server *httpServer) Start() {
 	mux.HandleFunc("/counter", server.handleCounter)
 	mux.HandleFunc("/up", server.handleUp)
 	mux.HandleFunc("/abort", server.handleAbort)
+	mux.HandleFunc("/acquire-exclusive-resources", server.handleAcquireExclusiveResources)
+	mux.HandleFunc("/release-exclusive-resources", server.handleReleaseExclusiveResources)
+
+	//remote proc endpoints
+	mux.HandleFunc("/assign-remote-procs", server.handleAssignRemoteProcs)
+	mux.HandleFunc("/remote-proc-status", server.handleRemoteProcStatus)
 
 	go httpServer.Serve(server.listener)
 }
@@ -68,10 +72,14 @@ func (server *httpServer) Start() {
 // Stop the server
 func (server *httpServer) Close() {
 	server.listener.Close()
+	server.handler.close()
 }
 
 // The address the server can be reached it.  Pass this into the `ForwardingReporter`.
 func (server *httpServer) Address() string {
+	if isUnixSocket(server.listener) {
+		return unixSocketScheme + server.listener.Addr().String()
+	}
 	return "http://" + server.listener.Addr().String()
 }
 
@@ -87,10 +95,24 @@ func (server *httpServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *httpServer) SetStreamDestination(w io.Writer) {
+	server.handler.streamLock.Lock()
+	defer server.handler.streamLock.Unlock()
+	server.handler.streamDestination = w
+}
+
 func (server *httpServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
 
+func (server *httpServer) AcceptRemoteProcs(procs []RemoteProc) {
+	server.handler.acceptRemoteProcs(procs)
+}
+
+func (server *httpServer) GetRemoteProcResults() chan RemoteProcStatus {
+	return server.handler.remoteProcs.results
+}
+
 //
 // Streaming Endpoints
 //
@@ -166,6 +188,15 @@ func (server *httpServer) emitProgressReport(writer http.ResponseWriter, request
 	server.handleError(server.handler.EmitProgressReport(report, voidReceiver), writer)
 }
 
+func (server *httpServer) emitStreamEvent(writer http.ResponseWriter, request *http.Request) {
+	event, err := io.ReadAll(request.Body)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	server.handleError(server.handler.EmitStreamEvent(event, voidReceiver), writer)
+}
+
 func (server *httpServer) handleReportBeforeSuiteCompleted(writer http.ResponseWriter, request *http.Request) {
 	var state types.SpecState
 	if !server.decode(writer, request, &state) {
@@ -240,3 +271,43 @@ func (server *httpServer) handleAbort(writer http.ResponseWriter, request *http.
 		server.handler.Abort(voidSender, voidReceiver)
 	}
 }
+
+func (server *httpServer) handleAcquireExclusiveResources(writer http.ResponseWriter, request *http.Request) {
+	var exclusiveResourcesRequest ExclusiveResourcesRequest
+	if !server.decode(writer, request, &exclusiveResourcesRequest) {
+		return
+	}
+	var lock ExclusiveResourcesLock
+	if server.handleError(server.handler.AcquireExclusiveResources(exclusiveResourcesRequest, &lock), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(lock)
+}
+
+func (server *httpServer) handleReleaseExclusiveResources(writer http.ResponseWriter, request *http.Request) {
+	var token int
+	if !server.decode(writer, request, &token) {
+		return
+	}
+	server.handleError(server.handler.ReleaseExclusiveResources(token, voidReceiver), writer)
+}
+
+func (server *httpServer) handleAssignRemoteProcs(writer http.ResponseWriter, request *http.Request) {
+	var max int
+	if !server.decode(writer, request, &max) {
+		return
+	}
+	var procs []RemoteProc
+	if server.handleError(server.handler.AssignRemoteProcs(max, &procs), writer) {
+		return
+	}
+	json.NewEncoder(writer).Encode(procs)
+}
+
+func (server *httpServer) handleRemoteProcStatus(writer http.ResponseWriter, request *http.Request) {
+	var status RemoteProcStatus
+	if !server.decode(writer, request, &status) {
+		return
+	}
+	server.handleError(server.handler.ReportRemoteProcStatus(status, voidReceiver), writer)
+}
@@ -0,0 +1,150 @@
+package parallel_support
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+RemoteProc is a process the Ginkgo CLI has handed off to a worker on another machine.  Args are the arguments the worker should pass to the compiled test binary (the worker supplies --ginkgo.parallel.host itself).
+
+QuarantineFile and DurationReport hold the contents of the --quarantine-file and --order-by-duration files, if any.  The paths the Ginkgo CLI was given don't exist on the worker's machine so the worker writes these out itself and points the test binary at them.
+*/
+type RemoteProc struct {
+	Proc           int
+	Args           []string
+	QuarantineFile []byte
+	DurationReport []byte
+}
+
+// RemoteProcStatus is sent by a worker while a remote process runs and once more when it exits.  Lost is set by the server when it stops hearing from the worker.
+type RemoteProcStatus struct {
+	Proc                 int
+	Exited               bool
+	Passed               bool
+	HasProgrammaticFocus bool
+	Lost                 bool
+	Output               string
+}
+
+// Workers send a RemoteProcStatus for each running process every REMOTE_PROC_HEARTBEAT_INTERVAL.  By default, a remote process the server hasn't heard from in REMOTE_PROC_TIMEOUT is considered lost.
+const REMOTE_PROC_HEARTBEAT_INTERVAL = time.Second
+const REMOTE_PROC_TIMEOUT = 10 * time.Second
+
+/*
+remoteProcs tracks the processes the server is waiting for workers to run.  Processes that haven't been assigned to a worker yet are considered alive so that the other processes wait for them.
+
+Once processes are accepted, remoteProcs checks for lost processes every heartbeatInterval until it is closed.
+*/
+type remoteProcs struct {
+	lock              *sync.Mutex
+	heartbeatInterval time.Duration
+	timeout           time.Duration
+	pending           []RemoteProc
+	lastSeen          map[int]time.Time
+	exited            map[int]bool
+	results           chan RemoteProcStatus
+	watching          bool
+	stop              chan any
+	closed            bool
+}
+
+func newRemoteProcs(heartbeatInterval time.Duration, timeout time.Duration) *remoteProcs {
+	return &remoteProcs{
+		lock:              &sync.Mutex{},
+		heartbeatInterval: heartbeatInterval,
+		timeout:           timeout,
+		lastSeen:          map[int]time.Time{},
+		exited:            map[int]bool{},
+		results:           make(chan RemoteProcStatus),
+		stop:              make(chan any),
+	}
+}
+
+func (r *remoteProcs) accept(procs []RemoteProc) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pending = append(r.pending, procs...)
+	r.results = make(chan RemoteProcStatus, len(r.pending))
+	if !r.watching && !r.closed {
+		r.watching = true
+		go r.watch()
+	}
+}
+
+// close stops the watcher.  It is safe to call close more than once.
+func (r *remoteProcs) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.closed {
+		r.closed = true
+		close(r.stop)
+	}
+}
+
+// assign hands up to max pending processes to a worker
+func (r *remoteProcs) assign(max int) []RemoteProc {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if max > len(r.pending) {
+		max = len(r.pending)
+	}
+	assigned := r.pending[:max]
+	r.pending = r.pending[max:]
+	for _, proc := range assigned {
+		r.lastSeen[proc.Proc] = time.Now()
+	}
+	return assigned
+}
+
+func (r *remoteProcs) update(status RemoteProcStatus) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, assigned := r.lastSeen[status.Proc]; !assigned || r.exited[status.Proc] {
+		return
+	}
+	r.lastSeen[status.Proc] = time.Now()
+	if status.Exited {
+		r.exit(status)
+	}
+}
+
+func (r *remoteProcs) exit(status RemoteProcStatus) {
+	r.exited[status.Proc] = true
+	r.results <- status
+}
+
+func (r *remoteProcs) isAlive(proc int) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.exited[proc] {
+		return false
+	}
+	lastSeen, assigned := r.lastSeen[proc]
+	return !assigned || time.Since(lastSeen) < r.timeout
+}
+
+// markLostProcs marks processes whose workers have stopped sending heartbeats as exited
+func (r *remoteProcs) markLostProcs() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for proc, lastSeen := range r.lastSeen {
+		if !r.exited[proc] && time.Since(lastSeen) >= r.timeout {
+			r.exit(RemoteProcStatus{Proc: proc, Exited: true, Lost: true})
+		}
+	}
+}
+
+// watch periodically marks lost processes until remoteProcs is closed
+func (r *remoteProcs) watch() {
+	ticker := time.NewTicker(r.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.markLostProcs()
+		}
+	}
+}
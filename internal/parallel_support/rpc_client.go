@@ -1,6 +1,11 @@
 package parallel_support
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"net/rpc"
 	"time"
 
@@ -9,12 +14,14 @@ import (
 
 type rpcClient struct {
 	serverHost string
+	token      string
 	client     *rpc.Client
 }
 
-func newRPCClient(serverHost string) *rpcClient {
+func newRPCClient(serverHost string, token string) *rpcClient {
 	return &rpcClient{
 		serverHost: serverHost,
+		token:      token,
 	}
 }
 
@@ -23,7 +30,8 @@ func (client *rpcClient) Connect() bool {
 	if client.client != nil {
 		return true
 	}
-	client.client, err = rpc.DialHTTPPath("tcp", client.serverHost, "/")
+	network, address := dialTarget(client.serverHost)
+	client.client, err = dialRPC(network, address, client.token)
 	if err != nil {
 		client.client = nil
 		return false
@@ -31,6 +39,32 @@ func (client *rpcClient) Connect() bool {
 	return true
 }
 
+// dialRPC works like rpc.DialHTTPPath(network, address, "/") but presents token when it connects
+func dialRPC(network string, address string, token string) (*rpc.Client, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	handshake := "CONNECT / HTTP/1.0\n"
+	if token != "" {
+		handshake += tokenHeader + ": " + token + "\n"
+	}
+	if _, err := io.WriteString(conn, handshake+"\n"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected HTTP response: %s", resp.Status)
+	}
+	return rpc.NewClient(conn), nil
+}
+
 func (client *rpcClient) Close() error {
 	return client.client.Close()
 }
@@ -76,6 +110,10 @@ func (client *rpcClient) PostEmitProgressReport(report types.ProgressReport) err
 	return client.client.Call("Server.EmitProgressReport", report, voidReceiver)
 }
 
+func (client *rpcClient) PostEmitStreamEvent(event []byte) error {
+	return client.client.Call("Server.EmitStreamEvent", event, voidReceiver)
+}
+
 func (client *rpcClient) PostReportBeforeSuiteCompleted(state types.SpecState) error {
 	return client.client.Call("Server.ReportBeforeSuiteCompleted", state, voidReceiver)
 }
@@ -134,3 +172,26 @@ func (client *rpcClient) ShouldAbort() bool {
 	client.client.Call("Server.ShouldAbort", voidSender, &shouldAbort)
 	return shouldAbort
 }
+
+func (client *rpcClient) AcquireExclusiveResources(request ExclusiveResourcesRequest) (ExclusiveResourcesLock, error) {
+	var lock ExclusiveResourcesLock
+	err := client.client.Call("Server.AcquireExclusiveResources", request, &lock)
+	return lock, err
+}
+
+func (client *rpcClient) ReleaseExclusiveResources(token int) error {
+	return client.client.Call("Server.ReleaseExclusiveResources", token, voidReceiver)
+}
+
+func (client *rpcClient) FetchRemoteProcs(max int) ([]RemoteProc, error) {
+	var procs []RemoteProc
+	err := client.client.Call("Server.AssignRemoteProcs", max, &procs)
+	if err != nil && err.Error() == ErrorGone.Error() {
+		return procs, ErrorGone
+	}
+	return procs, err
+}
+
+func (client *rpcClient) PostRemoteProcStatus(status RemoteProcStatus) error {
+	return client.client.Call("Server.ReportRemoteProcStatus", status, voidReceiver)
+}
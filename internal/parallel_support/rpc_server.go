@@ -12,29 +12,25 @@ import (
 	"net"
 	"net/http"
 	"net/rpc"
-
-	"github.com/onsi/ginkgo/v2/reporters"
 )
 
 /*
-RPCServer spins up on an automatically selected port and listens for communication from the forwarding reporter.
+RPCServer spins up on an automatically selected port (or Unix domain socket) and listens for communication from the forwarding reporter.
 It then forwards that communication to attached reporters.
 */
 type RPCServer struct {
 	listener net.Listener
 	handler  *ServerHandler
+	token    string
 }
 
-// Create a new server, automatically selecting a port
-func newRPCServer(parallelTotal int, reporter reporters.Reporter) (*RPCServer, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		return nil, err
-	}
+// Create a new server that listens on the passed-in listener and hands requests to handler
+func newRPCServer(listener net.Listener, handler *ServerHandler, token string) *RPCServer {
 	return &RPCServer{
 		listener: listener,
-		handler:  newServerHandler(parallelTotal, reporter),
-	}, nil
+		handler:  handler,
+		token:    token,
+	}
 }
 
 // Start the server.  You don't need to `go s.Start()`, just `s.Start()`
@@ -43,7 +39,7 @@ func (server *RPCServer) Start() {
 	rpcServer.RegisterName("Server", server.handler) //register the handler's methods as the server
 
 	httpServer := &http.Server{}
-	httpServer.Handler = rpcServer
+	httpServer.Handler = requireToken(server.token, rpcServer)
 
 	go httpServer.Serve(server.listener)
 }
@@ -51,10 +47,14 @@ func (server *RPCServer) Start() {
 // Stop the server
 func (server *RPCServer) Close() {
 	server.listener.Close()
+	server.handler.close()
 }
 
 // The address the server can be reached it.  Pass this into the `ForwardingReporter`.
 func (server *RPCServer) Address() string {
+	if isUnixSocket(server.listener) {
+		return unixSocketScheme + server.listener.Addr().String()
+	}
 	return server.listener.Addr().String()
 }
 
@@ -70,6 +70,20 @@ func (server *RPCServer) SetOutputDestination(w io.Writer) {
 	server.handler.outputDestination = w
 }
 
+func (server *RPCServer) SetStreamDestination(w io.Writer) {
+	server.handler.streamLock.Lock()
+	defer server.handler.streamLock.Unlock()
+	server.handler.streamDestination = w
+}
+
 func (server *RPCServer) RegisterAlive(node int, alive func() bool) {
 	server.handler.registerAlive(node, alive)
 }
+
+func (server *RPCServer) AcceptRemoteProcs(procs []RemoteProc) {
+	server.handler.acceptRemoteProcs(procs)
+}
+
+func (server *RPCServer) GetRemoteProcResults() chan RemoteProcStatus {
+	return server.handler.remoteProcs.results
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/reporters"
 	"github.com/onsi/ginkgo/v2/types"
@@ -20,6 +21,8 @@ var voidSender Void
 type ServerHandler struct {
 	done                   chan any
 	outputDestination      io.Writer
+	streamDestination      io.Writer
+	streamLock             *sync.Mutex
 	reporter               reporters.Reporter
 	alives                 []func() bool
 	lock                   *sync.Mutex
@@ -29,6 +32,8 @@ type ServerHandler struct {
 	counter                int
 	counterLock            *sync.Mutex
 	shouldAbort            bool
+	exclusiveResourceLocks *ExclusiveResourceLocks
+	remoteProcs            *remoteProcs
 
 	numSuiteDidBegins int
 	numSuiteDidEnds   int
@@ -41,9 +46,13 @@ func newServerHandler(parallelTotal int, reporter reporters.Reporter) *ServerHan
 		reporter:         reporter,
 		lock:             &sync.Mutex{},
 		counterLock:      &sync.Mutex{},
+		streamLock:       &sync.Mutex{},
 		alives:           make([]func() bool, parallelTotal),
 		beforeSuiteState: BeforeSuiteState{Data: nil, State: types.SpecStateInvalid},
 
+		exclusiveResourceLocks: NewExclusiveResourceLocks(),
+		remoteProcs:            newRemoteProcs(REMOTE_PROC_HEARTBEAT_INTERVAL, REMOTE_PROC_TIMEOUT),
+
 		parallelTotal:     parallelTotal,
 		outputDestination: os.Stdout,
 		done:              make(chan any),
@@ -117,6 +126,16 @@ func (handler *ServerHandler) EmitProgressReport(report types.ProgressReport, _
 	return nil
 }
 
+func (handler *ServerHandler) EmitStreamEvent(event []byte, _ *Void) error {
+	handler.streamLock.Lock()
+	defer handler.streamLock.Unlock()
+	if handler.streamDestination == nil {
+		return nil
+	}
+	_, err := handler.streamDestination.Write(event)
+	return err
+}
+
 func (handler *ServerHandler) registerAlive(proc int, alive func() bool) {
 	handler.lock.Lock()
 	defer handler.lock.Unlock()
@@ -232,3 +251,48 @@ func (handler *ServerHandler) ShouldAbort(_ Void, shouldAbort *bool) error {
 	*shouldAbort = handler.shouldAbort
 	return nil
 }
+
+// AcquireExclusiveResources puts new requests in line and returns immediately so the caller learns its token.  Requests that are already in line are held open until they are granted or EXCLUSIVE_RESOURCES_WAIT elapses.
+func (handler *ServerHandler) AcquireExclusiveResources(request ExclusiveResourcesRequest, lock *ExclusiveResourcesLock) error {
+	if request.Token == 0 {
+		*lock = handler.exclusiveResourceLocks.TryAcquire(request, handler.procIsAlive)
+		return nil
+	}
+	stop := make(chan any)
+	timer := time.AfterFunc(EXCLUSIVE_RESOURCES_WAIT, func() { close(stop) })
+	defer timer.Stop()
+	*lock = handler.exclusiveResourceLocks.Acquire(request, handler.procIsAlive, stop)
+	return nil
+}
+
+func (handler *ServerHandler) ReleaseExclusiveResources(token int, _ *Void) error {
+	handler.exclusiveResourceLocks.Release(token)
+	return nil
+}
+
+func (handler *ServerHandler) acceptRemoteProcs(procs []RemoteProc) {
+	handler.remoteProcs.accept(procs)
+	for _, proc := range procs {
+		proc := proc.Proc
+		handler.registerAlive(proc, func() bool { return handler.remoteProcs.isAlive(proc) })
+	}
+}
+
+// close stops the handler's background work.  The servers call it when they close.
+func (handler *ServerHandler) close() {
+	handler.remoteProcs.close()
+}
+
+func (handler *ServerHandler) AssignRemoteProcs(max int, procs *[]RemoteProc) error {
+	*procs = handler.remoteProcs.assign(max)
+	if len(*procs) == 0 {
+		return ErrorGone
+	}
+	return nil
+}
+
+func (handler *ServerHandler) ReportRemoteProcStatus(status RemoteProcStatus, _ *Void) error {
+	status.Lost = false
+	handler.remoteProcs.update(status)
+	return nil
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+ApplyQuarantineToSpecs applies the entries in the --quarantine-file to the matching specs.
+
+Entries that set FlakeAttempts override the FlakeAttempts of the spec.  All other matching specs are quarantined - any failure is reported as SpecStateQuarantined.
+*/
+func ApplyQuarantineToSpecs(specs Specs, suiteConfig types.SuiteConfig) (Specs, error) {
+	if suiteConfig.QuarantineFile == "" {
+		return specs, nil
+	}
+	quarantine, err := types.LoadQuarantine(suiteConfig.QuarantineFile)
+	if err != nil || len(quarantine) == 0 {
+		return specs, err
+	}
+
+	now := time.Now()
+	out := Specs{}
+	for _, spec := range specs {
+		itNode := spec.FirstNodeWithType(types.NodeTypeIt)
+		entry, found := quarantine.EntryFor(specFullText(spec, itNode), itNode.CodeLocation, now)
+		if found {
+			if entry.FlakeAttempts > 0 {
+				nodes := make(Nodes, len(spec.Nodes))
+				for i := range spec.Nodes {
+					nodes[i] = spec.Nodes[i]
+					if nodes[i].ID == itNode.ID {
+						nodes[i].FlakeAttempts = entry.FlakeAttempts
+					}
+				}
+				spec.Nodes = nodes
+			} else {
+				spec.Quarantine = entry
+			}
+		}
+		out = append(out, spec)
+	}
+	return out, nil
+}
+
+// specFullText matches SpecReport.FullText() so that entries can be copied straight out of a report
+func specFullText(spec Spec, itNode Node) string {
+	texts := spec.Nodes.WithType(types.NodeTypeContainer).Texts()
+	if itNode.Text != "" {
+		texts = append(texts, itNode.Text)
+	}
+	return strings.Join(texts, " ")
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type Spec struct {
-	Nodes Nodes
-	Skip  bool
+	Nodes      Nodes
+	Skip       bool
+	Quarantine types.QuarantineEntry
 }
 
 func (s Spec) SubjectID() uint {
@@ -68,6 +69,24 @@ func (s Specs) HasAnySpecsMarkedPending() bool {
 	return false
 }
 
+func (s Specs) HasAnySpecsMarkedConcurrent() bool {
+	for _, spec := range s {
+		if spec.Nodes.HasNodeMarkedConcurrent() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Specs) UnionOfExclusiveResources() []string {
+	nodes := Nodes{}
+	for _, spec := range s {
+		nodes = append(nodes, spec.Nodes...)
+	}
+	return nodes.UnionOfExclusiveResources()
+}
+
 func (s Specs) CountWithoutSkip() int {
 	n := 0
 	for i := range s {
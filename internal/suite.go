@@ -52,6 +52,12 @@ type Suite struct {
 	currentByStep types.SpecEvent
 	timelineOrder int
 
+	waitingForExclusiveResources    []string
+	exclusiveResourcesWaitStartTime time.Time
+
+	// runningPropertyTrial is set while an ItForAll body runs in search of a counterexample (see RunPropertyTrial)
+	runningPropertyTrial bool
+
 	/*
 		We don't need to lock around all operations.  Just those that *could* happen concurrently.
 
@@ -65,6 +71,13 @@ type Suite struct {
 	selectiveLock *sync.Mutex
 
 	client parallel_support.Client
+
+	// exclusiveResourceLocks hands out locks for specs marked Exclusive when the suite isn't running in parallel.  When it is, the parallel_support server hands them out instead.
+	exclusiveResourceLocks *parallel_support.ExclusiveResourceLocks
+
+	// concurrentSpecs is set when the run includes specs marked Concurrent.  Those specs run on copies of the suite which point back to the primary suite (see concurrency.go)
+	concurrentSpecs *concurrentSpecs
+	primary         *Suite
 }
 
 func NewSuite() *Suite {
@@ -106,6 +119,7 @@ func (suite *Suite) Run(description string, suiteLabels Labels, suitePath string
 	suite.outputInterceptor = outputInterceptor
 	suite.interruptHandler = interruptHandler
 	suite.config = suiteConfig
+	suite.exclusiveResourceLocks = parallel_support.NewExclusiveResourceLocks()
 
 	if suite.config.Timeout > 0 {
 		suite.deadline = time.Now().Add(suite.config.Timeout)
@@ -226,6 +240,7 @@ func (suite *Suite) pushSuiteNode(node Node) error {
 }
 
 func (suite *Suite) pushCleanupNode(node Node) error {
+	suite = suite.forCurrentGoroutine()
 	if suite.phase != PhaseRun || suite.currentNode.IsZero() {
 		return types.GinkgoErrors.PushingCleanupNodeDuringTreeConstruction(node.CodeLocation)
 	}
@@ -240,6 +255,9 @@ func (suite *Suite) pushCleanupNode(node Node) error {
 	case types.NodeTypeCleanupInvalid, types.NodeTypeCleanupAfterEach, types.NodeTypeCleanupAfterAll, types.NodeTypeCleanupAfterSuite:
 		return types.GinkgoErrors.PushingCleanupInCleanupNode(node.CodeLocation)
 	default:
+		if suite.isRunningPropertyTrial() {
+			return types.GinkgoErrors.PushingCleanupInPropertyBody(node.CodeLocation)
+		}
 		node.NodeType = types.NodeTypeCleanupAfterEach
 	}
 
@@ -283,11 +301,21 @@ func (suite *Suite) handleSpecEventEnd(eventType types.SpecEventType, startEvent
 }
 
 func (suite *Suite) By(text string, callback ...func()) error {
+	suite = suite.forCurrentGoroutine()
 	cl := types.NewCodeLocation(2)
 	if suite.phase != PhaseRun {
 		return types.GinkgoErrors.ByNotDuringRunPhase(cl)
 	}
 
+	if suite.isRunningPropertyTrial() {
+		if len(callback) > 1 {
+			panic("just one callback per By, please")
+		} else if len(callback) == 1 {
+			callback[0]()
+		}
+		return nil
+	}
+
 	event := suite.handleSpecEvent(types.SpecEvent{
 		SpecEventType: types.SpecEventByStart,
 		CodeLocation:  cl,
@@ -315,6 +343,7 @@ func (suite *Suite) By(text string, callback ...func()) error {
 Spec Running methods - used during PhaseRun
 */
 func (suite *Suite) CurrentSpecReport() types.SpecReport {
+	suite = suite.forCurrentGoroutine()
 	suite.selectiveLock.Lock()
 	defer suite.selectiveLock.Unlock()
 	report := suite.currentSpecReport
@@ -327,9 +356,13 @@ func (suite *Suite) CurrentSpecReport() types.SpecReport {
 }
 
 func (suite *Suite) AddReportEntry(entry ReportEntry) error {
+	suite = suite.forCurrentGoroutine()
 	if suite.phase != PhaseRun {
 		return types.GinkgoErrors.AddReportEntryNotDuringRunPhase(entry.Location)
 	}
+	if suite.isRunningPropertyTrial() {
+		return nil
+	}
 	entry.TimelineLocation = suite.generateTimelineLocation()
 	entry.Time = entry.TimelineLocation.Time
 	suite.selectiveLock.Lock()
@@ -339,6 +372,35 @@ func (suite *Suite) AddReportEntry(entry ReportEntry) error {
 	return nil
 }
 
+/*
+RunPropertyTrial runs one of the many runs of an ItForAll body that searches for a counterexample.  Only the final run against the counterexample (which happens outside of a trial) should leave a trace on the spec, so report entries, By steps, and GinkgoWriter output produced during the trial are discarded and DeferCleanup is rejected.
+*/
+func (suite *Suite) RunPropertyTrial(trial func()) {
+	suite = suite.forCurrentGoroutine()
+	suite.selectiveLock.Lock()
+	suite.runningPropertyTrial = true
+	suite.selectiveLock.Unlock()
+	writer, _ := suite.writer.(*Writer)
+	if writer != nil {
+		writer.setDiscarding(true)
+	}
+	defer func() {
+		if writer != nil {
+			writer.setDiscarding(false)
+		}
+		suite.selectiveLock.Lock()
+		suite.runningPropertyTrial = false
+		suite.selectiveLock.Unlock()
+	}()
+	trial()
+}
+
+func (suite *Suite) isRunningPropertyTrial() bool {
+	suite.selectiveLock.Lock()
+	defer suite.selectiveLock.Unlock()
+	return suite.runningPropertyTrial
+}
+
 func (suite *Suite) generateProgressReport(fullReport bool) types.ProgressReport {
 	timelineLocation := suite.generateTimelineLocation()
 	suite.selectiveLock.Lock()
@@ -352,7 +414,9 @@ func (suite *Suite) generateProgressReport(fullReport bool) types.ProgressReport
 	}
 	additionalReports = append(additionalReports, suite.QueryProgressReporters(deadline, suite.failer)...)
 	gwOutput := suite.currentSpecReport.CapturedGinkgoWriterOutput + string(suite.writer.Bytes())
-	pr, err := NewProgressReport(suite.isRunningInParallel(), suite.currentSpecReport, suite.currentNode, suite.currentNodeStartTime, suite.currentByStep, gwOutput, timelineLocation, additionalReports, suite.config.SourceRoots, fullReport)
+	pr, err := NewProgressReport(suite.isRunningInParallel() || suite.isRunningConcurrentSpec(), suite.currentSpecReport, suite.currentNode, suite.currentNodeStartTime, suite.currentByStep, gwOutput, timelineLocation, additionalReports, suite.config.SourceRoots, fullReport)
+	pr.WaitingForExclusiveResources = suite.waitingForExclusiveResources
+	pr.ExclusiveResourcesWaitStartTime = suite.exclusiveResourcesWaitStartTime
 
 	if err != nil {
 		fmt.Printf("{{red}}Failed to generate progress report:{{/}}\n%s\n", err.Error())
@@ -361,6 +425,14 @@ func (suite *Suite) generateProgressReport(fullReport bool) types.ProgressReport
 }
 
 func (suite *Suite) handleProgressSignal() {
+	if suite.concurrentSpecs != nil && !suite.isRunningConcurrentSpec() {
+		if concurrentSuites := suite.concurrentSpecs.runningSuites(); len(concurrentSuites) > 0 {
+			for _, concurrentSuite := range concurrentSuites {
+				concurrentSuite.handleProgressSignal()
+			}
+			return
+		}
+	}
 	report := suite.generateProgressReport(false)
 	report.Message = "{{bold}}You've requested a progress report:{{/}}"
 	suite.emitProgressReport(report)
@@ -380,21 +452,146 @@ func (suite *Suite) emitProgressReport(report types.ProgressReport) {
 	}
 }
 
+/*
+acquireExclusiveResources blocks until the suite holds a lock on all of resources and returns the lock's token along with the time spent waiting.  Requests wait in line and are granted in the order they were made.
+
+The parallel_support server hands out the locks when there is a client - this is how specs in different processes exclude one another.  Otherwise the suite's own exclusiveResourceLocks are used (and shared with any Concurrent specs).
+
+If the suite is interrupted while waiting acquireExclusiveResources leaves the line and returns a zero token.
+*/
+func (suite *Suite) acquireExclusiveResources(resources []string) (int, time.Duration, error) {
+	startTime := time.Now()
+	suite.selectiveLock.Lock()
+	suite.waitingForExclusiveResources = resources
+	suite.exclusiveResourcesWaitStartTime = startTime
+	suite.selectiveLock.Unlock()
+
+	defer func() {
+		suite.selectiveLock.Lock()
+		suite.waitingForExclusiveResources = nil
+		suite.exclusiveResourcesWaitStartTime = time.Time{}
+		suite.selectiveLock.Unlock()
+	}()
+
+	request := parallel_support.ExclusiveResourcesRequest{Proc: suite.config.ParallelProcess, Resources: resources}
+	for {
+		interruptStatus := suite.interruptHandler.Status()
+		if interruptStatus.Interrupted() {
+			if request.Token != 0 {
+				suite.releaseExclusiveResources(request.Token)
+			}
+			return 0, time.Since(startTime), nil
+		}
+
+		lock, err := suite.awaitExclusiveResources(request, interruptStatus.Channel)
+		if err != nil || lock.Acquired {
+			return lock.Token, time.Since(startTime), err
+		}
+		request.Token = lock.Token
+	}
+}
+
+// awaitExclusiveResources waits for request to be granted until stop is closed.  When there is a client the server holds the request open for a while, so the wait can end early with the request still in line.
+func (suite *Suite) awaitExclusiveResources(request parallel_support.ExclusiveResourcesRequest, stop chan any) (parallel_support.ExclusiveResourcesLock, error) {
+	if suite.client == nil {
+		return suite.exclusiveResourceLocks.Acquire(request, nil, stop), nil
+	}
+	if request.Token == 0 {
+		return suite.client.AcquireExclusiveResources(request)
+	}
+
+	type result struct {
+		lock parallel_support.ExclusiveResourcesLock
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		lock, err := suite.client.AcquireExclusiveResources(request)
+		results <- result{lock, err}
+	}()
+	select {
+	case r := <-results:
+		return r.lock, r.err
+	case <-stop:
+		return parallel_support.ExclusiveResourcesLock{Token: request.Token}, nil
+	}
+}
+
+func (suite *Suite) releaseExclusiveResources(token int) {
+	if suite.client != nil {
+		err := suite.client.ReleaseExclusiveResources(token)
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+		return
+	}
+	suite.exclusiveResourceLocks.Release(token)
+}
+
 func (suite *Suite) isRunningInParallel() bool {
 	return suite.config.ParallelTotal > 1
 }
 
+func (suite *Suite) isRunningConcurrentSpec() bool {
+	return suite.primary != nil
+}
+
+// forCurrentGoroutine returns the copy of the suite running the Concurrent spec that owns the calling goroutine.  It returns suite itself if there isn't one.
+func (suite *Suite) forCurrentGoroutine() *Suite {
+	if suite.concurrentSpecs == nil || suite.isRunningConcurrentSpec() {
+		return suite
+	}
+	if concurrentSuite := suite.concurrentSpecs.suiteForCurrentGoroutine(); concurrentSuite != nil {
+		return concurrentSuite
+	}
+	return suite
+}
+
+// Go runs body on a new goroutine.  When called from a Concurrent spec the new goroutine belongs to that spec, so calls to Fail and GinkgoWriter made on it are attributed to the spec.
+func (suite *Suite) Go(body func()) {
+	owner := suite.forCurrentGoroutine()
+	go func() {
+		if owner.isRunningConcurrentSpec() {
+			defer owner.concurrentSpecs.claimCurrentGoroutine(owner)()
+		}
+		body()
+	}()
+}
+
+func (suite *Suite) shouldSkipAll() bool {
+	if suite.concurrentSpecs == nil {
+		return suite.skipAll
+	}
+	suite.concurrentSpecs.reportLock.Lock()
+	defer suite.concurrentSpecs.reportLock.Unlock()
+	return suite.concurrentSpecs.primary.skipAll
+}
+
+func (suite *Suite) waitForConcurrentSpecs() {
+	if suite.concurrentSpecs != nil {
+		suite.concurrentSpecs.wait()
+	}
+}
+
 func (suite *Suite) processCurrentSpecReport() {
 	suite.reporter.DidRun(suite.currentSpecReport)
 	if suite.isRunningInParallel() {
 		suite.client.PostDidRun(suite.currentSpecReport)
 	}
-	suite.report.SpecReports = append(suite.report.SpecReports, suite.currentSpecReport)
+
+	// Concurrent specs record their results in the primary suite's report
+	primary := suite
+	if suite.concurrentSpecs != nil {
+		primary = suite.concurrentSpecs.primary
+		suite.concurrentSpecs.reportLock.Lock()
+		defer suite.concurrentSpecs.reportLock.Unlock()
+	}
+	primary.report.SpecReports = append(primary.report.SpecReports, suite.currentSpecReport)
 
 	if suite.currentSpecReport.State.Is(types.SpecStateFailureStates) {
-		suite.report.SuiteSucceeded = false
+		primary.report.SuiteSucceeded = false
 		if suite.config.FailFast || suite.currentSpecReport.State.Is(types.SpecStateAborted) {
-			suite.skipAll = true
+			primary.skipAll = true
 			if suite.isRunningInParallel() {
 				suite.client.PostAbort()
 			}
@@ -403,7 +600,22 @@ func (suite *Suite) processCurrentSpecReport() {
 }
 
 func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath string, hasProgrammaticFocus bool, specs Specs) bool {
-	numSpecsThatWillBeRun := specs.CountWithoutSkip()
+	// the quarantine file, shard and duration report were vetted before the suite started but we still surface any errors loading them as a suite failure
+	specs, configErr := ApplyQuarantineToSpecs(specs, suite.config)
+	var groupedSpecIndices, serialGroupedSpecIndices GroupedSpecIndices
+	if configErr == nil {
+		groupedSpecIndices, serialGroupedSpecIndices, configErr = OrderSpecs(specs, suite.config)
+	}
+
+	// when sharding, OrderSpecs only returns the groups assigned to this shard - the remaining specs are reported via PreRunStats.SpecsInOtherShards
+	specsInShard := Specs{}
+	for _, specIndices := range append(append(GroupedSpecIndices{}, groupedSpecIndices...), serialGroupedSpecIndices...) {
+		specsInShard = append(specsInShard, specs.AtIndices(specIndices)...)
+	}
+	numSpecsThatWillBeRun := specsInShard.CountWithoutSkip()
+	if specsInShard.HasAnySpecsMarkedConcurrent() {
+		suite.concurrentSpecs = newConcurrentSpecs(suite)
+	}
 
 	suite.report = types.Report{
 		SuitePath:                 suitePath,
@@ -412,8 +624,9 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 		SuiteConfig:               suite.config,
 		SuiteHasProgrammaticFocus: hasProgrammaticFocus,
 		PreRunStats: types.PreRunStats{
-			TotalSpecs:       len(specs),
-			SpecsThatWillRun: numSpecsThatWillBeRun,
+			TotalSpecs:         len(specs),
+			SpecsThatWillRun:   numSpecsThatWillBeRun,
+			SpecsInOtherShards: len(specs) - len(specsInShard),
 		},
 		StartTime: time.Now(),
 	}
@@ -424,6 +637,10 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 	}
 
 	suite.report.SuiteSucceeded = true
+	if configErr != nil {
+		suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, configErr.Error())
+		suite.report.SuiteSucceeded = false
+	}
 
 	suite.runReportSuiteNodesIfNeedBe(types.NodeTypeReportBeforeSuite)
 
@@ -433,7 +650,6 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 	}
 
 	if suite.report.SuiteSucceeded {
-		groupedSpecIndices, serialGroupedSpecIndices := OrderSpecs(specs, suite.config)
 		nextIndex := MakeIncrementingIndexCounter()
 		if suite.isRunningInParallel() {
 			nextIndex = suite.client.FetchNextCounter
@@ -442,12 +658,14 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 		for {
 			groupedSpecIdx, err := nextIndex()
 			if err != nil {
+				suite.waitForConcurrentSpecs()
 				suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, fmt.Sprintf("Failed to iterate over specs:\n%s", err.Error()))
 				suite.report.SuiteSucceeded = false
 				break
 			}
 
 			if groupedSpecIdx >= len(groupedSpecIndices) {
+				suite.waitForConcurrentSpecs()
 				if suite.config.ParallelProcess == 1 && len(serialGroupedSpecIndices) > 0 {
 					groupedSpecIndices, serialGroupedSpecIndices, nextIndex = serialGroupedSpecIndices, GroupedSpecIndices{}, MakeIncrementingIndexCounter()
 					suite.client.BlockUntilNonprimaryProcsHaveFinished()
@@ -460,10 +678,20 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 			// we encapsulate that complexity in the notion of a Group that can run
 			// Group is really just an extension of suite so it gets passed a suite and has access to all its internals
 			// Note that group is stateful and intended for single use!
-			newGroup(suite).run(specs.AtIndices(groupedSpecIndices[groupedSpecIdx]))
+			groupSpecs := specs.AtIndices(groupedSpecIndices[groupedSpecIdx])
+			if suite.concurrentSpecs != nil && suite.concurrentSpecs.canRun(groupSpecs) {
+				suite.concurrentSpecs.run(groupSpecs[0])
+				continue
+			}
+			// all other specs wait for any running Concurrent specs to finish and then run on their own
+			suite.waitForConcurrentSpecs()
+			newGroup(suite).run(groupSpecs)
+		}
+		if suite.concurrentSpecs != nil {
+			suite.concurrentSpecs.stop()
 		}
 
-		if specs.HasAnySpecsMarkedPending() && suite.config.FailOnPending {
+		if specsInShard.HasAnySpecsMarkedPending() && suite.config.FailOnPending {
 			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Detected pending specs and --fail-on-pending is set")
 			suite.report.SuiteSucceeded = false
 		}
@@ -485,6 +713,8 @@ func (suite *Suite) runSpecs(description string, suiteLabels Labels, suitePath s
 		suite.report.SuiteSucceeded = false
 	}
 
+	suite.processMeasurementBaselineIfNeedBe()
+
 	suite.runReportSuiteNodesIfNeedBe(types.NodeTypeReportAfterSuite)
 	suite.reporter.SuiteDidEnd(suite.report)
 	if suite.isRunningInParallel() {
@@ -564,6 +794,11 @@ func (suite *Suite) reportEach(spec Spec, nodeType types.NodeType) {
 		return
 	}
 
+	if suite.concurrentSpecs != nil {
+		suite.concurrentSpecs.reportEachLock.Lock()
+		defer suite.concurrentSpecs.reportEachLock.Unlock()
+	}
+
 	for i := range nodes {
 		suite.writer.Truncate()
 		suite.outputInterceptor.StartInterceptingOutput()
@@ -678,6 +913,54 @@ func (suite *Suite) runSuiteNode(node Node) {
 	suite.currentSpecReport.CapturedStdOutErr += suite.outputInterceptor.StopInterceptingAndReturnOutput()
 }
 
+// processMeasurementBaselineIfNeedBe compares the suite's Measurements against --measurement-baseline and saves them to --save-measurement-baseline
+// when running in parallel this only happens on proc 1, which waits for the other procs to finish so that it can process the aggregated report
+func (suite *Suite) processMeasurementBaselineIfNeedBe() {
+	if suite.config.MeasurementBaseline == "" && suite.config.SaveMeasurementBaseline == "" {
+		return
+	}
+	if suite.config.ParallelProcess != 1 || suite.config.DryRun {
+		return
+	}
+
+	report := suite.report
+	if suite.isRunningInParallel() {
+		aggregatedReport, err := suite.client.BlockUntilAggregatedNonprimaryProcsReport()
+		if err != nil {
+			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, fmt.Sprintf("Failed to process measurements:\n%s", err.Error()))
+			suite.report.SuiteSucceeded = false
+			return
+		}
+		report = report.Add(aggregatedReport)
+	}
+
+	if suite.config.MeasurementBaseline != "" {
+		baseline, err := types.LoadMeasurementBaseline(suite.config.MeasurementBaseline)
+		if err != nil {
+			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, err.Error())
+			suite.report.SuiteSucceeded = false
+		} else {
+			tolerance := suite.config.MeasurementTolerance
+			if tolerance == 0 {
+				tolerance = types.DefaultMeasurementTolerance
+			}
+			suite.report.MeasurementComparisons = baseline.Compare(report, tolerance)
+			if len(suite.report.MeasurementComparisons.Regressions()) > 0 {
+				suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, "Measurements regressed relative to the baseline")
+				suite.report.SuiteSucceeded = false
+			}
+		}
+	}
+
+	if suite.config.SaveMeasurementBaseline != "" {
+		err := types.NewMeasurementBaseline(report).Save(suite.config.SaveMeasurementBaseline)
+		if err != nil {
+			suite.report.SpecialSuiteFailureReasons = append(suite.report.SpecialSuiteFailureReasons, fmt.Sprintf("Failed to save measurement baseline:\n%s", err.Error()))
+			suite.report.SuiteSucceeded = false
+		}
+	}
+}
+
 func (suite *Suite) runReportSuiteNodesIfNeedBe(nodeType types.NodeType) {
 	nodes := suite.suiteNodes.WithType(nodeType)
 	// only run ReportAfterSuite on proc 1
@@ -848,6 +1131,9 @@ func (suite *Suite) runNode(node Node, specDeadline time.Time, text string) (typ
 	failureC := make(chan types.Failure)
 
 	go func() {
+		if suite.isRunningConcurrentSpec() {
+			defer suite.concurrentSpecs.claimCurrentGoroutine(suite)()
+		}
 		finished := false
 		defer func() {
 			if e := recover(); e != nil || !finished {
@@ -1,8 +1,11 @@
 package testingtproxy_test
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"runtime"
+	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -385,4 +388,140 @@ var _ = Describe("Testingtproxy", func() {
 		Ω(attachProgressReporterCancelCalled).Should(BeTrue())
 	})
 
+	Describe("GinkgoTB", func() {
+		var tb testing.TB
+
+		BeforeEach(func() {
+			tb = &GinkgoTBWrapper{GinkgoT: t}
+		})
+
+		It("returns a testing.TB", func() {
+			var _ testing.TB = GinkgoTB()
+			Ω(GinkgoTB().Name()).Should(ContainSubstring("returns a testing.TB"))
+		})
+
+		It("routes failures through GinkgoT", func() {
+			tb.Errorf("%s %d!", "a", 17)
+			Ω(failFuncCall.message).Should(Equal("a 17!"))
+			Ω(failFuncCall.callerSkip).Should(Equal([]int{offset}))
+
+			tb.Fatal("b", 18)
+			Ω(failFuncCall.message).Should(Equal("b 18\n"))
+		})
+
+		It("routes skips through GinkgoT", func() {
+			tb.Skipf("%s %d!", "a", 17)
+			Ω(skipFuncCall.message).Should(Equal("a 17!"))
+			Ω(skipFuncCall.callerSkip).Should(Equal([]int{offset}))
+		})
+
+		It("routes logs to the GinkgoWriter", func() {
+			tb.Log("a", 17)
+			Ω(string(buf.Contents())).Should(Equal("  a 17\n"))
+		})
+
+		It("reports the state of the spec", func() {
+			reportToReturn.State = types.SpecStateFailed
+			Ω(tb.Failed()).Should(BeTrue())
+			Ω(tb.Skipped()).Should(BeFalse())
+		})
+
+		It("honors Helper", func() {
+			helper := func(tb testing.TB) types.CodeLocation {
+				tb.Helper()
+				return types.NewCodeLocation(0)
+			}
+			cl := helper(GinkgoTB()) // this is the expected line
+			_, fname, lnumber, _ := runtime.Caller(0)
+			Ω(cl).Should(Equal(types.CodeLocation{
+				FileName:   fname,
+				LineNumber: lnumber - 1,
+			}))
+		})
+
+		Describe("Cleanup", Ordered, func() {
+			var didCleanupAfter bool
+			It("routes cleanup through DeferCleanup", func() {
+				GinkgoTB().Cleanup(func() {
+					didCleanupAfter = true
+				})
+				Ω(didCleanupAfter).Should(BeFalse())
+			})
+
+			It("ran cleanup after the previous spec", func() {
+				Ω(didCleanupAfter).Should(BeTrue())
+			})
+		})
+
+		It("returns the GinkgoWriter as its Output", func() {
+			Ω(GinkgoTB().Output()).Should(BeIdenticalTo(GinkgoWriter))
+		})
+
+		Describe("Context", Ordered, func() {
+			var ctx context.Context
+			var errDuringCleanup error
+			It("returns a context that is live during the spec", func() {
+				wrapper := GinkgoTB()
+				ctx = wrapper.Context()
+				Ω(wrapper.Context()).Should(BeIdenticalTo(ctx))
+				Ω(ctx.Err()).ShouldNot(HaveOccurred())
+				wrapper.Cleanup(func() {
+					errDuringCleanup = ctx.Err()
+				})
+			})
+
+			It("cancelled the context before running cleanup", func() {
+				Ω(errDuringCleanup).Should(Equal(context.Canceled))
+				Ω(ctx.Err()).Should(Equal(context.Canceled))
+			})
+		})
+
+		Describe("Chdir", Ordered, func() {
+			var originalDir, tempDir string
+			It("changes the working directory and PWD", func() {
+				var err error
+				originalDir, err = os.Getwd()
+				Ω(err).ShouldNot(HaveOccurred())
+				tempDir, err = filepath.EvalSymlinks(GinkgoT().TempDir())
+				Ω(err).ShouldNot(HaveOccurred())
+
+				GinkgoTB().Chdir(tempDir)
+				Ω(os.Getwd()).Should(Equal(tempDir))
+				if runtime.GOOS != "windows" {
+					Ω(os.Getenv("PWD")).Should(Equal(tempDir))
+				}
+			})
+
+			It("restored the working directory after the previous spec", func() {
+				Ω(os.Getwd()).Should(Equal(originalDir))
+			})
+		})
+
+		Describe("Attr", func() {
+			It("records the attribute as a report entry", func() {
+				GinkgoTB().Attr("owner", "team-a")
+				entries := CurrentSpecReport().ReportEntries
+				Ω(entries).Should(HaveLen(1))
+				Ω(entries[0].Name).Should(Equal("owner"))
+				Ω(entries[0].StringRepresentation()).Should(Equal("team-a"))
+				Ω(entries[0].Visibility).Should(Equal(types.ReportEntryVisibilityFailureOrVerbose))
+			})
+
+			It("fails when the key contains whitespace or the value contains a newline", func() {
+				wrapper := &GinkgoTBWrapper{GinkgoT: t}
+				wrapper.Attr("bad key", "value")
+				Ω(failFuncCall.message).Should(ContainSubstring("contains whitespace"))
+				wrapper.Attr("key", "bad\nvalue")
+				Ω(failFuncCall.message).Should(ContainSubstring("contains a newline"))
+				Ω(CurrentSpecReport().ReportEntries).Should(BeEmpty())
+			})
+		})
+
+		It("returns the same temporary ArtifactDir on repeated calls", func() {
+			wrapper := GinkgoTB()
+			dir := wrapper.ArtifactDir()
+			Ω(dir).Should(BeADirectory())
+			Ω(wrapper.ArtifactDir()).Should(Equal(dir))
+		})
+	})
 })
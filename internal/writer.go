@@ -36,6 +36,12 @@ type Writer struct {
 	indentNext   bool
 
 	teeWriters []io.Writer
+
+	// discarding, when set, drops everything written to the Writer
+	discarding bool
+
+	// route, when set, returns the Writer belonging to the Concurrent spec running on the calling goroutine (or nil if there isn't one)
+	route func() *Writer
 }
 
 func NewWriter(outWriter io.Writer) *Writer {
@@ -49,13 +55,49 @@ func NewWriter(outWriter io.Writer) *Writer {
 	}
 }
 
+func (w *Writer) routeTo(route func() *Writer) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.route = route
+}
+
+func (w *Writer) routed() *Writer {
+	w.lock.Lock()
+	route := w.route
+	w.lock.Unlock()
+	if route == nil {
+		return w
+	}
+	if routed := route(); routed != nil {
+		return routed
+	}
+	return w
+}
+
+// newConcurrentSpecWriter returns a buffer-only Writer for a Concurrent spec.  It inherits w's tee writers.
+func (w *Writer) newConcurrentSpecWriter() *Writer {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	writer := NewWriter(w.outWriter)
+	writer.mode = WriterModeBufferOnly
+	writer.teeWriters = append([]io.Writer{}, w.teeWriters...)
+	return writer
+}
+
 func (w *Writer) SetMode(mode WriterMode) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	w.mode = mode
 }
 
+func (w *Writer) setDiscarding(discarding bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.discarding = discarding
+}
+
 func (w *Writer) Len() int {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	return w.buffer.Len()
@@ -64,9 +106,14 @@ func (w *Writer) Len() int {
 var newline = []byte("\n")
 
 func (w *Writer) Write(b []byte) (n int, err error) {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
+	if w.discarding {
+		return len(b), nil
+	}
+
 	for _, teeWriter := range w.teeWriters {
 		teeWriter.Write(b)
 	}
@@ -92,12 +139,14 @@ func (w *Writer) Write(b []byte) (n int, err error) {
 }
 
 func (w *Writer) Truncate() {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	w.buffer.Reset()
 }
 
 func (w *Writer) Bytes() []byte {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 	b := w.buffer.Bytes()
@@ -108,6 +157,7 @@ func (w *Writer) Bytes() []byte {
 
 // GinkgoWriterInterface
 func (w *Writer) TeeTo(writer io.Writer) {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -115,6 +165,7 @@ func (w *Writer) TeeTo(writer io.Writer) {
 }
 
 func (w *Writer) ClearTeeWriters() {
+	w = w.routed()
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
@@ -0,0 +1,143 @@
+package ginkgo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/global"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+Measurement captures the samples recorded by SampleDuration and SampleValue along with their statistics.
+It is documented here: https://pkg.go.dev/github.com/onsi/ginkgo/v2/types#Measurement
+*/
+type Measurement = types.Measurement
+
+/*
+MeasurementStats captures the statistics (min, max, mean, median, standard deviation, and percentiles) computed for a Measurement.
+It is documented here: https://pkg.go.dev/github.com/onsi/ginkgo/v2/types#MeasurementStats
+*/
+type MeasurementStats = types.MeasurementStats
+
+/*
+SamplingConfig controls how many samples SampleDuration and SampleValue record.
+
+Sampling stops after N samples or once Duration has elapsed, whichever comes first.  At least one of N or Duration must be set.
+*/
+type SamplingConfig struct {
+	N        int
+	Duration time.Duration
+}
+
+/*
+MeasurementUnits can be passed to SampleValue to specify the units of the recorded values.  The units are used when rendering the Measurement.
+*/
+type MeasurementUnits string
+
+/*
+HigherIsBetter can be passed to SampleValue to declare that larger values are improvements - e.g. for throughput or cache hit rates.  By default Ginkgo considers a Measurement to have regressed when its median grows relative to the --measurement-baseline.  Measurements recorded with HigherIsBetter regress when their median shrinks instead.
+
+HigherIsBetter can't be passed to SampleDuration - longer durations are always worse.
+*/
+const HigherIsBetter = higherIsBetterType(true)
+
+type higherIsBetterType bool
+
+/*
+SampleDuration times repeated calls to body and attaches the resulting Measurement to the current spec as a ReportEntry named name.
+
+body is passed the (zero-indexed) sample number and is called until config.N samples have been recorded or config.Duration has elapsed.  The returned Measurement includes the recorded durations (in nanoseconds) and their statistics - so you can make assertions on them:
+
+	It("sorts quickly", func() {
+	    m := SampleDuration("sorting", func(_ int) {
+	        books.Sort(library)
+	    }, SamplingConfig{N: 100, Duration: time.Second})
+	    Ω(time.Duration(m.Stats.Median)).Should(BeNumerically("<", 10*time.Millisecond))
+	})
+
+SampleDuration also accepts a ReportEntryVisibility and an Offset to control the attached ReportEntry.  Ginkgo's console reporter renders Measurements as tables and the JSON report includes the samples and statistics.
+
+You can learn more about SampleDuration here: https://onsi.github.io/ginkgo/#measuring-performance
+*/
+func SampleDuration(name string, body func(idx int), config SamplingConfig, args ...any) Measurement {
+	_, higherIsBetter, cl, reportEntryArgs := partitionMeasurementArgs(args)
+	if higherIsBetter {
+		Fail("HigherIsBetter can only be passed to SampleValue", 1)
+	}
+	samples := sample(config, func(idx int) float64 {
+		t := time.Now()
+		body(idx)
+		return float64(time.Since(t))
+	})
+	return addMeasurement(types.NewMeasurement(types.MeasurementTypeDuration, name, "", samples), cl, reportEntryArgs)
+}
+
+/*
+SampleValue records the values returned by repeated calls to body and attaches the resulting Measurement to the current spec as a ReportEntry named name.
+
+body is passed the (zero-indexed) sample number and is called until config.N samples have been recorded or config.Duration has elapsed.  Pass in MeasurementUnits to specify the units of the recorded values:
+
+	m := SampleValue("memory usage", func(_ int) float64 {
+	    return float64(cache.Fill().Size()) / 1e6
+	}, SamplingConfig{N: 10}, MeasurementUnits("MB"))
+
+Pass in HigherIsBetter if larger values are improvements - this matters when comparing against a --measurement-baseline.  SampleValue also accepts a ReportEntryVisibility and an Offset to control the attached ReportEntry.
+
+You can learn more about SampleValue here: https://onsi.github.io/ginkgo/#measuring-performance
+*/
+func SampleValue(name string, body func(idx int) float64, config SamplingConfig, args ...any) Measurement {
+	units, higherIsBetter, cl, reportEntryArgs := partitionMeasurementArgs(args)
+	samples := sample(config, body)
+	measurement := types.NewMeasurement(types.MeasurementTypeValue, name, units, samples)
+	measurement.HigherIsBetter = higherIsBetter
+	return addMeasurement(measurement, cl, reportEntryArgs)
+}
+
+// partitionMeasurementArgs must be called directly by SampleDuration and SampleValue so that the computed code location points at their caller
+func partitionMeasurementArgs(args []any) (string, bool, types.CodeLocation, []any) {
+	units, higherIsBetter, offset := "", false, 0
+	reportEntryArgs := []any{}
+	for _, arg := range args {
+		switch x := arg.(type) {
+		case MeasurementUnits:
+			units = string(x)
+		case higherIsBetterType:
+			higherIsBetter = bool(x)
+		case Offset:
+			offset = int(x)
+		default:
+			reportEntryArgs = append(reportEntryArgs, arg)
+		}
+	}
+	return units, higherIsBetter, types.NewCodeLocation(2 + offset), reportEntryArgs
+}
+
+func sample(config SamplingConfig, body func(idx int) float64) []float64 {
+	if config.N <= 0 && config.Duration <= 0 {
+		Fail("SamplingConfig must specify a positive N or Duration", 2)
+	}
+	samples := []float64{}
+	start := time.Now()
+	for idx := 0; config.N <= 0 || idx < config.N; idx++ {
+		if config.Duration > 0 && time.Since(start) >= config.Duration {
+			break
+		}
+		samples = append(samples, body(idx))
+	}
+	return samples
+}
+
+func addMeasurement(measurement Measurement, cl types.CodeLocation, args []any) Measurement {
+	args = append([]any{measurement}, args...)
+	reportEntry, err := internal.NewReportEntry(measurement.Name, cl, args...)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to generate Report Entry:\n%s", err.Error()), 2)
+	}
+	err = global.Suite.AddReportEntry(reportEntry)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to add Report Entry:\n%s", err.Error()), 2)
+	}
+	return measurement
+}
@@ -0,0 +1,472 @@
+package ginkgo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/internal"
+	"github.com/onsi/ginkgo/v2/internal/global"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const defaultPropertyIterations = 100
+const maxPropertyShrinkAttempts = 1000
+
+/*
+ItForAll is a property-based subject node.  It runs its body many times, passing in a fresh set of randomly generated inputs each time:
+
+	ItForAll("reversing twice is the identity", GenSliceOf(GenInt(-100, 100), 20), func(s []int) {
+	    Ω(reverse(reverse(s))).Should(Equal(s))
+	})
+
+ItForAll accepts any number of Generators followed by a body function that accepts one parameter per Generator (optionally preceded by a SpecContext or context.Context).  Any other arguments are treated as decorators for the generated It.
+
+Inputs are derived from GinkgoRandomSeed() and the spec's full text so running with the same --seed reproduces the same inputs.  When the body fails Ginkgo shrinks the inputs to find a minimal counterexample, records it as a ReportEntry named "Counterexample", and then reruns the body with the counterexample to report the failure.
+
+Only the final run against the counterexample is reported: report entries, By steps, and GinkgoWriter output from the runs that search for it are discarded.  DeferCleanup cannot be called from the body - use defer instead.
+
+ItForAll runs the body 100 times by default.  You can change this with the PropertyIterations decorator or, for the whole suite, with the --property-iterations flag.
+
+You can learn more about ItForAll here: https://onsi.github.io/ginkgo/#property-based-specs
+*/
+func ItForAll(text string, args ...any) bool {
+	GinkgoHelper()
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, text, propertyNodeArgs(args)...))
+}
+
+/*
+You can focus a property-based spec with `FItForAll`.  This is equivalent to `FIt`.
+*/
+func FItForAll(text string, args ...any) bool {
+	GinkgoHelper()
+	args = append(args, internal.Focus)
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, text, propertyNodeArgs(args)...))
+}
+
+/*
+You can mark a property-based spec as pending with `PItForAll`.  This is equivalent to `PIt`.
+*/
+func PItForAll(text string, args ...any) bool {
+	GinkgoHelper()
+	args = append(args, internal.Pending)
+	return pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, text, propertyNodeArgs(args)...))
+}
+
+/*
+You can mark a property-based spec as pending with `XItForAll`.  This is equivalent to `XIt`.
+*/
+var XItForAll = PItForAll
+
+/*
+PropertyIterations(uint N) is a decorator that sets the number of randomly generated inputs an ItForAll spec is run against.  The --property-iterations flag overrides this decorator.
+
+You can learn more here: https://onsi.github.io/ginkgo/#property-based-specs
+You can learn more about decorators here: https://onsi.github.io/ginkgo/#decorator-reference
+*/
+type PropertyIterations = internal.PropertyIterations
+
+/*
+PropertyCounterexample is attached as a ReportEntry named "Counterexample" to ItForAll specs that fail.
+
+Inputs holds the (shrunk) inputs that caused the failure, formatted with %#v.  Iteration is the iteration (starting at 1) that first failed and Shrinks is the number of times the inputs were successfully shrunk.
+*/
+type PropertyCounterexample struct {
+	Inputs    []string
+	Iteration int
+	Shrinks   int
+}
+
+func (c PropertyCounterexample) String() string {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "failed on iteration %d, shrunk %d times:", c.Iteration, c.Shrinks)
+	for i, input := range c.Inputs {
+		fmt.Fprintf(out, "\n  #%d: %s", i+1, input)
+	}
+	return out.String()
+}
+
+/*
+Generator generates random inputs for ItForAll and, optionally, shrinks failing inputs into smaller candidates.
+
+You generally use one of the provided generators (e.g. GenInt, GenString, GenSliceOf) or construct your own with NewGenerator.
+*/
+type Generator struct {
+	valueType reflect.Type
+	generate  func(r *rand.Rand) any
+	shrink    func(value any) []any
+}
+
+/*
+NewGenerator constructs a Generator from a function that generates values of type T.
+
+shrink is optional (it can be nil) and should return candidate values that are "smaller" than the passed-in value, ordered from most to least aggressive.  Ginkgo uses these candidates to find a minimal counterexample when an ItForAll spec fails.
+*/
+func NewGenerator[T any](generate func(r *rand.Rand) T, shrink func(value T) []T) Generator {
+	g := Generator{
+		valueType: reflect.TypeOf(new(T)).Elem(),
+		generate:  func(r *rand.Rand) any { return generate(r) },
+	}
+	if shrink != nil {
+		g.shrink = func(value any) []any {
+			out := []any{}
+			typed, _ := value.(T)
+			for _, candidate := range shrink(typed) {
+				out = append(out, candidate)
+			}
+			return out
+		}
+	}
+	return g
+}
+
+/*
+GenInt generates ints in the closed range [min, max].  Failing inputs shrink towards zero (or whichever bound is closest to zero).
+*/
+func GenInt(min, max int) Generator {
+	if max < min {
+		min, max = max, min
+	}
+	target := 0
+	if min > 0 {
+		target = min
+	} else if max < 0 {
+		target = max
+	}
+	return NewGenerator(func(r *rand.Rand) int {
+		return min + int(r.Int63n(int64(max)-int64(min)+1))
+	}, func(value int) []int {
+		out := []int{}
+		for delta := value - target; delta != 0; delta /= 2 {
+			out = append(out, value-delta)
+		}
+		return out
+	})
+}
+
+/*
+GenFloat64 generates float64s in the half-open range [min, max).  Failing inputs shrink towards zero (or whichever bound is closest to zero) and towards whole numbers.
+*/
+func GenFloat64(min, max float64) Generator {
+	if max < min {
+		min, max = max, min
+	}
+	target := 0.0
+	if min > 0 {
+		target = min
+	} else if max < 0 {
+		target = max
+	}
+	return NewGenerator(func(r *rand.Rand) float64 {
+		return min + r.Float64()*(max-min)
+	}, func(value float64) []float64 {
+		out := []float64{}
+		if value != target {
+			out = append(out, target, target+(value-target)/2)
+		}
+		if whole := float64(int64(value)); whole != value && whole >= min && whole < max {
+			out = append(out, whole)
+		}
+		return out
+	})
+}
+
+/*
+GenBool generates bools.  Failing inputs shrink towards false.
+*/
+func GenBool() Generator {
+	return NewGenerator(func(r *rand.Rand) bool {
+		return r.Intn(2) == 1
+	}, func(value bool) []bool {
+		if value {
+			return []bool{false}
+		}
+		return nil
+	})
+}
+
+/*
+GenString generates strings of printable ASCII characters with up to maxLength characters.  Failing inputs shrink towards shorter strings and towards the character 'a'.
+*/
+func GenString(maxLength int) Generator {
+	if maxLength < 0 {
+		exitIfErr(types.GinkgoErrors.InvalidGeneratorArguments("GenString", fmt.Sprintf("maxLength must not be negative but was %d.", maxLength), types.NewCodeLocation(1)))
+	}
+	return NewGenerator(func(r *rand.Rand) string {
+		out := make([]byte, r.Intn(maxLength+1))
+		for i := range out {
+			out[i] = byte(' ' + r.Intn('~'-' '+1))
+		}
+		return string(out)
+	}, func(value string) []string {
+		out := []string{}
+		for _, s := range shrinkSlice([]byte(value)) {
+			out = append(out, string(s))
+		}
+		for i := range value {
+			if value[i] != 'a' {
+				out = append(out, value[:i]+"a"+value[i+1:])
+			}
+		}
+		return out
+	})
+}
+
+/*
+GenSliceOf generates slices with up to maxLength elements, each generated by g.  Failing inputs shrink towards shorter slices and then by shrinking individual elements.
+*/
+func GenSliceOf(g Generator, maxLength int) Generator {
+	if maxLength < 0 {
+		exitIfErr(types.GinkgoErrors.InvalidGeneratorArguments("GenSliceOf", fmt.Sprintf("maxLength must not be negative but was %d.", maxLength), types.NewCodeLocation(1)))
+	}
+	sliceType := reflect.SliceOf(g.valueType)
+	toSlice := func(elements []any) any {
+		out := reflect.MakeSlice(sliceType, len(elements), len(elements))
+		for i, element := range elements {
+			out.Index(i).Set(computeValue(element, g.valueType))
+		}
+		return out.Interface()
+	}
+	fromSlice := func(value any) []any {
+		v := reflect.ValueOf(value)
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = v.Index(i).Interface()
+		}
+		return out
+	}
+	return Generator{
+		valueType: sliceType,
+		generate: func(r *rand.Rand) any {
+			elements := make([]any, r.Intn(maxLength+1))
+			for i := range elements {
+				elements[i] = g.generate(r)
+			}
+			return toSlice(elements)
+		},
+		shrink: func(value any) []any {
+			elements := fromSlice(value)
+			out := []any{}
+			for _, candidate := range shrinkSlice(elements) {
+				out = append(out, toSlice(candidate))
+			}
+			if g.shrink != nil {
+				for i := range elements {
+					for _, shrunk := range g.shrink(elements[i]) {
+						candidate := append([]any{}, elements...)
+						candidate[i] = shrunk
+						out = append(out, toSlice(candidate))
+					}
+				}
+			}
+			return out
+		},
+	}
+}
+
+/*
+GenOneOf generates values by picking from the passed-in values.  Failing inputs shrink towards values earlier in the list.
+*/
+func GenOneOf[T any](values ...T) Generator {
+	if len(values) == 0 {
+		exitIfErr(types.GinkgoErrors.InvalidGeneratorArguments("GenOneOf", "GenOneOf must be passed at least one value to pick from.", types.NewCodeLocation(1)))
+	}
+	return NewGenerator(func(r *rand.Rand) T {
+		return values[r.Intn(len(values))]
+	}, func(value T) []T {
+		for i := range values {
+			if reflect.DeepEqual(values[i], value) {
+				return values[:i]
+			}
+		}
+		return nil
+	})
+}
+
+// shrinkSlice returns candidates with elements removed: the empty slice, then each half, then the slice with each single element removed
+func shrinkSlice[T any](s []T) [][]T {
+	if len(s) == 0 {
+		return nil
+	}
+	out := [][]T{{}}
+	if len(s) > 2 {
+		out = append(out, s[:len(s)/2], s[len(s)/2:])
+	}
+	if len(s) > 1 {
+		for i := range s {
+			out = append(out, append(append([]T{}, s[:i]...), s[i+1:]...))
+		}
+	}
+	return out
+}
+
+type property struct {
+	cl         types.CodeLocation
+	generators []Generator
+	body       any
+	hasContext bool
+	iterations int
+}
+
+func propertyNodeArgs(args []any) []any {
+	GinkgoHelper()
+	cl := types.NewCodeLocation(0)
+	p := property{cl: cl}
+	nodeArgs := []any{cl}
+	for _, arg := range args {
+		switch t := arg.(type) {
+		case Generator:
+			p.generators = append(p.generators, t)
+		case PropertyIterations:
+			p.iterations = int(t)
+		default:
+			if reflect.TypeOf(arg) != nil && reflect.TypeOf(arg).Kind() == reflect.Func {
+				if p.body != nil {
+					exitIfErr(types.GinkgoErrors.InvalidBodyForItForAll(cl))
+				}
+				p.body = arg
+			} else {
+				nodeArgs = append(nodeArgs, arg)
+			}
+		}
+	}
+	if p.body == nil {
+		exitIfErr(types.GinkgoErrors.InvalidBodyForItForAll(cl))
+	}
+
+	bodyType := reflect.TypeOf(p.body)
+	offset := 0
+	if bodyType.NumIn() > 0 && (bodyType.In(0).Implements(specContextType) || bodyType.In(0).Implements(contextType)) {
+		p.hasContext = true
+		offset = 1
+	}
+	if bodyType.IsVariadic() || bodyType.NumIn()-offset != len(p.generators) {
+		exitIfErr(types.GinkgoErrors.GeneratorCountMismatchForItForAll(len(p.generators), bodyType.NumIn()-offset, cl))
+	}
+	for i, generator := range p.generators {
+		if !generator.valueType.AssignableTo(bodyType.In(i + offset)) {
+			exitIfErr(types.GinkgoErrors.GeneratorTypeMismatchForItForAll(i+1, bodyType.In(i+offset), generator.valueType, cl))
+		}
+	}
+
+	if p.hasContext {
+		nodeArgs = append(nodeArgs, func(ctx SpecContext) { p.run(ctx) })
+	} else {
+		nodeArgs = append(nodeArgs, func() { p.run(nil) })
+	}
+	return nodeArgs
+}
+
+func (p property) run(ctx SpecContext) {
+	iterations := defaultPropertyIterations
+	if suiteConfig.PropertyIterations > 0 {
+		iterations = suiteConfig.PropertyIterations
+	} else if p.iterations > 0 {
+		iterations = p.iterations
+	}
+
+	// the inputs depend on the spec's identity so that adding or reordering specs does not change them
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d:%s", GinkgoRandomSeed(), CurrentSpecReport().FullText())
+	r := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	for iteration := 1; iteration <= iterations; iteration++ {
+		if ctx != nil && ctx.Err() != nil {
+			return
+		}
+		inputs := make([]any, len(p.generators))
+		for i, generator := range p.generators {
+			inputs[i] = generator.generate(r)
+		}
+		if p.holds(ctx, inputs) {
+			continue
+		}
+		if p.stopped(ctx) {
+			return
+		}
+
+		inputs, shrinks := p.shrink(ctx, inputs)
+		counterexample := PropertyCounterexample{Iteration: iteration, Shrinks: shrinks}
+		for _, input := range inputs {
+			counterexample.Inputs = append(counterexample.Inputs, fmt.Sprintf("%#v", input))
+		}
+		AddReportEntry("Counterexample", counterexample)
+		p.invoke(ctx, inputs)
+		if global.Failer.GetState() == types.SpecStatePassed {
+			global.Failer.Fail("The property failed for this counterexample while searching for it but passed when the counterexample was replayed.  The property's body does not behave deterministically for the same inputs.", p.cl)
+		}
+		return
+	}
+}
+
+// shrink greedily replaces inputs with smaller candidates that still fail until no candidate fails or the shrink budget is exhausted
+func (p property) shrink(ctx SpecContext, inputs []any) ([]any, int) {
+	shrinks, attempts := 0, 0
+	for attempts < maxPropertyShrinkAttempts {
+		shrunk := false
+		for i, generator := range p.generators {
+			if generator.shrink == nil {
+				continue
+			}
+			for _, candidate := range generator.shrink(inputs[i]) {
+				attempts++
+				trial := append([]any{}, inputs...)
+				trial[i] = candidate
+				if !p.holds(ctx, trial) {
+					if p.stopped(ctx) {
+						return inputs, shrinks
+					}
+					inputs, shrunk = trial, true
+					shrinks++
+					break
+				}
+				if attempts >= maxPropertyShrinkAttempts || p.stopped(ctx) {
+					return inputs, shrinks
+				}
+			}
+			if shrunk {
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return inputs, shrinks
+}
+
+// holds runs the body with inputs as a property trial and reports whether it passed.  Failures are drained from the failer so that only the final counterexample fails the spec.
+func (p property) holds(ctx SpecContext, inputs []any) (holds bool) {
+	defer func() {
+		e := recover()
+		switch state := global.Failer.GetState(); state {
+		case types.SpecStatePassed:
+			holds = e == nil
+		case types.SpecStateFailed, types.SpecStatePanicked:
+			global.Failer.Drain()
+			holds = false
+		default:
+			if e != nil {
+				panic(e)
+			}
+			// the spec was skipped, aborted, or interrupted from another goroutine - run stops iterating once it sees the state
+			holds = false
+		}
+	}()
+	global.Suite.RunPropertyTrial(func() { p.invoke(ctx, inputs) })
+	return true
+}
+
+// stopped reports whether the spec has been interrupted or has reached an outcome (e.g. skipped or aborted) that should stop the search for a counterexample
+func (p property) stopped(ctx SpecContext) bool {
+	return (ctx != nil && ctx.Err() != nil) || global.Failer.GetState() != types.SpecStatePassed
+}
+
+func (p property) invoke(ctx SpecContext, inputs []any) {
+	if p.hasContext {
+		inputs = append([]any{ctx}, inputs...)
+	}
+	invokeFunction(p.body, inputs)
+}
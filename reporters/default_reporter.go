@@ -76,6 +76,9 @@ func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
 		if report.SuiteConfig.ParallelTotal > 1 {
 			r.emit(r.f("- %d procs ", report.SuiteConfig.ParallelTotal))
 		}
+		if report.SuiteConfig.Shard != "" {
+			r.emit(r.f("- shard %s ", report.SuiteConfig.Shard))
+		}
 	} else {
 		banner := r.f("Running Suite: %s - %s", report.SuiteDescription, report.SuitePath)
 		r.emitBlock(banner)
@@ -96,6 +99,9 @@ func (r *DefaultReporter) SuiteWillBegin(report types.Report) {
 		r.emitBlock(out)
 		r.emit("\n")
 		r.emitBlock(r.f("Will run {{bold}}%d{{/}} of {{bold}}%d{{/}} specs", report.PreRunStats.SpecsThatWillRun, report.PreRunStats.TotalSpecs))
+		if report.SuiteConfig.Shard != "" {
+			r.emitBlock(r.f("Running shard {{bold}}%s{{/}} - {{bold}}%d{{/}} specs are assigned to other shards", report.SuiteConfig.Shard, report.PreRunStats.SpecsInOtherShards))
+		}
 		if report.SuiteConfig.ParallelTotal > 1 {
 			r.emitBlock(r.f("Running in parallel across {{bold}}%d{{/}} processes", report.SuiteConfig.ParallelTotal))
 		}
@@ -128,6 +134,17 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 		}
 	}
 
+	if len(report.MeasurementComparisons) > 0 {
+		r.emitBlock("\n")
+		regressions := report.MeasurementComparisons.Regressions()
+		if len(regressions) > 0 {
+			r.emitBlock(r.f("{{red}}{{bold}}Comparing Measurements to Baseline - %d Regressed:{{/}}", len(regressions)))
+		} else {
+			r.emitBlock(r.f("{{bold}}Comparing Measurements to Baseline:{{/}}"))
+		}
+		r.emitBlock(r.fi(1, report.MeasurementComparisons.ColorableString()))
+	}
+
 	//summarize the suite
 	if r.conf.Verbosity().Is(types.VerbosityLevelSuccinct) && report.SuiteSucceeded {
 		r.emit(r.f(" {{green}}SUCCESS!{{/}} %s ", report.RunTime))
@@ -142,7 +159,7 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 
 	specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt) //exclude any suite setup nodes
 	r.emitBlock(r.f(color+"Ran %d of %d Specs in %.3f seconds{{/}}",
-		specs.CountWithState(types.SpecStatePassed)+specs.CountWithState(types.SpecStateFailureStates),
+		specs.CountWithState(types.SpecStatePassed)+specs.CountWithState(types.SpecStateFailureStates)+specs.CountWithState(types.SpecStateQuarantined),
 		report.PreRunStats.TotalSpecs,
 		report.RunTime.Seconds()),
 	)
@@ -167,8 +184,16 @@ func (r *DefaultReporter) SuiteDidEnd(report types.Report) {
 		if specs.CountOfRepeatedSpecs() > 0 {
 			r.emit(r.f("{{light-yellow}}{{bold}}%d Repeated{{/}} | ", specs.CountOfRepeatedSpecs()))
 		}
+		if specs.CountWithState(types.SpecStateQuarantined) > 0 {
+			r.emit(r.f("{{light-gray}}{{bold}}%d Quarantined{{/}} | ", specs.CountWithState(types.SpecStateQuarantined)))
+		}
 		r.emit(r.f("{{yellow}}{{bold}}%d Pending{{/}} | ", specs.CountWithState(types.SpecStatePending)))
-		r.emit(r.f("{{cyan}}{{bold}}%d Skipped{{/}}\n", specs.CountWithState(types.SpecStateSkipped)))
+		if report.SuiteConfig.Shard != "" {
+			r.emit(r.f("{{cyan}}{{bold}}%d Skipped{{/}} | ", specs.CountWithState(types.SpecStateSkipped)))
+			r.emit(r.f("{{gray}}{{bold}}%d In Other Shards{{/}}\n", report.PreRunStats.SpecsInOtherShards))
+		} else {
+			r.emit(r.f("{{cyan}}{{bold}}%d Skipped{{/}}\n", specs.CountWithState(types.SpecStateSkipped)))
+		}
 	}
 }
 
@@ -191,18 +216,19 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		header = fmt.Sprintf("[%s]", report.LeafNodeType)
 	}
 	highlightColor := r.highlightColorForState(report.State)
+	failedOrQuarantined := report.Failed() || report.State.Is(types.SpecStateQuarantined)
 
 	// have we already been streaming the timeline?
 	timelineHasBeenStreaming := v.GTE(types.VerbosityLevelVerbose) && !inParallel
 
 	// should we show the timeline?
 	var timeline types.Timeline
-	showTimeline := !timelineHasBeenStreaming && (v.GTE(types.VerbosityLevelVerbose) || report.Failed())
+	showTimeline := !timelineHasBeenStreaming && (v.GTE(types.VerbosityLevelVerbose) || failedOrQuarantined)
 	if showTimeline {
 		timeline = report.Timeline().WithoutHiddenReportEntries()
 		keepVeryVerboseSpecEvents := v.Is(types.VerbosityLevelVeryVerbose) ||
 			(v.Is(types.VerbosityLevelVerbose) && r.conf.ShowNodeEvents) ||
-			(report.Failed() && r.conf.ShowNodeEvents)
+			(failedOrQuarantined && r.conf.ShowNodeEvents)
 		if !keepVeryVerboseSpecEvents {
 			timeline = timeline.WithoutVeryVerboseSpecEvents()
 		}
@@ -226,7 +252,7 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 	showSeparateStdSection := inParallel && (report.CapturedStdOutErr != "")
 
 	// given all that - do we have any actual content to show? or are we a single denoter in a stream?
-	reportHasContent := v.Is(types.VerbosityLevelVeryVerbose) || showTimeline || showSeparateVisibilityAlwaysReportsSection || showSeparateStdSection || report.Failed() || (v.Is(types.VerbosityLevelVerbose) && !report.State.Is(types.SpecStateSkipped))
+	reportHasContent := v.Is(types.VerbosityLevelVeryVerbose) || showTimeline || showSeparateVisibilityAlwaysReportsSection || showSeparateStdSection || failedOrQuarantined || (v.Is(types.VerbosityLevelVerbose) && !report.State.Is(types.SpecStateSkipped))
 
 	// should we show a runtime?
 	includeRuntime := !report.State.Is(types.SpecStateSkipped|types.SpecStatePending) || (report.State.Is(types.SpecStateSkipped) && report.Failure.Message != "")
@@ -255,6 +281,11 @@ func (r *DefaultReporter) DidRun(report types.SpecReport) {
 		if v.Is(types.VerbosityLevelVeryVerbose) || (v.Is(types.VerbosityLevelVerbose) && report.Failure.Message != "") {
 			header, reportHasContent = "S [SKIPPED]", true
 		}
+	case types.SpecStateQuarantined:
+		header = fmt.Sprintf("%s [%s]", header, r.humanReadableState(report.State))
+		if report.QuarantineReason != "" {
+			header = fmt.Sprintf("%s - %s", header, report.QuarantineReason)
+		}
 	default:
 		header = fmt.Sprintf("%s [%s]", header, r.humanReadableState(report.State))
 		if report.MaxMustPassRepeatedly > 1 {
@@ -335,6 +366,8 @@ func (r *DefaultReporter) highlightColorForState(state types.SpecState) string {
 		return "{{orange}}"
 	case types.SpecStateAborted:
 		return "{{coral}}"
+	case types.SpecStateQuarantined:
+		return "{{light-gray}}"
 	default:
 		return "{{gray}}"
 	}
@@ -451,10 +484,17 @@ func (r *DefaultReporter) emitProgressReport(indent uint, emitGinkgoWriterOutput
 			r.emit(" ")
 			subjectIndent = 0
 		}
-		r.emit(r.fi(subjectIndent, "{{bold}}{{orange}}%s{{/}} (Spec Runtime: %s)\n", report.LeafNodeText, report.Time().Sub(report.SpecStartTime).Round(time.Millisecond)))
+		if report.SpecStartTime.IsZero() {
+			r.emit(r.fi(subjectIndent, "{{bold}}{{orange}}%s{{/}}\n", report.LeafNodeText))
+		} else {
+			r.emit(r.fi(subjectIndent, "{{bold}}{{orange}}%s{{/}} (Spec Runtime: %s)\n", report.LeafNodeText, report.Time().Sub(report.SpecStartTime).Round(time.Millisecond)))
+		}
 		r.emit(r.fi(indent+1, "{{gray}}%s{{/}}\n", report.LeafNodeLocation))
 		indent += 1
 	}
+	if len(report.WaitingForExclusiveResources) > 0 {
+		r.emit(r.fi(indent, "Waiting for {{bold}}{{orange}}Exclusive Resources: %s{{/}} (Wait Time: %s)\n", strings.Join(report.WaitingForExclusiveResources, ", "), report.Time().Sub(report.ExclusiveResourcesWaitStartTime).Round(time.Millisecond)))
+	}
 	if report.CurrentNodeType != types.NodeTypeInvalid {
 		r.emit(r.fi(indent, "In {{bold}}{{orange}}[%s]{{/}}", report.CurrentNodeType))
 		if report.CurrentNodeText != "" && !report.CurrentNodeType.Is(types.NodeTypeIt) {
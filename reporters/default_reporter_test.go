@@ -251,6 +251,7 @@ type CurrentNodeText string
 type CurrentStepText string
 type LeafNodeText string
 type AdditionalReports []string
+type WaitingForExclusiveResources []string
 
 func PR(options ...any) types.ProgressReport {
 	report := types.ProgressReport{
@@ -292,6 +293,10 @@ func PR(options ...any) types.ProgressReport {
 			report.Message = x
 		case AdditionalReports:
 			report.AdditionalReports = x
+		case WaitingForExclusiveResources:
+			report.WaitingForExclusiveResources = x
+			report.ExclusiveResourcesWaitStartTime = now.Add(-2 * time.Second)
+			report.SpecStartTime = time.Time{}
 		case types.TimelineLocation:
 			report.TimelineLocation = x
 		}
@@ -477,6 +482,20 @@ var _ = Describe("DefaultReporter", func() {
 			"Running in parallel across {{bold}}3{{/}} processes",
 			"",
 		),
+		Entry("when configured to run a shard",
+			C(),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 5, TotalSpecs: 20, SpecsInOtherShards: 13},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 1, Shard: "2/3"},
+			},
+			"Running Suite: My Suite - /path/to/suite",
+			"========================================",
+			"Random Seed: {{bold}}17{{/}}",
+			"",
+			"Will run {{bold}}5{{/}} of {{bold}}20{{/}} specs",
+			"Running shard {{bold}}2/3{{/}} - {{bold}}13{{/}} specs are assigned to other shards",
+			"",
+		),
 		Entry("when succinct and in series",
 			C(Succinct),
 			types.Report{
@@ -493,6 +512,14 @@ var _ = Describe("DefaultReporter", func() {
 			},
 			"[17] {{bold}}My Suite{{/}} - 15/20 specs - 3 procs ",
 		),
+		Entry("when succinct and running a shard",
+			C(Succinct),
+			types.Report{
+				SuiteDescription: "My Suite", SuitePath: "/path/to/suite", PreRunStats: types.PreRunStats{SpecsThatWillRun: 5, TotalSpecs: 20, SpecsInOtherShards: 13},
+				SuiteConfig: types.SuiteConfig{RandomSeed: 17, ParallelTotal: 3, Shard: "2/3"},
+			},
+			"[17] {{bold}}My Suite{{/}} - 5/20 specs - 3 procs - shard 2/3 ",
+		),
 		Entry("when succinct and with labels",
 			C(Succinct),
 			types.Report{
@@ -1071,6 +1098,22 @@ var _ = Describe("DefaultReporter", func() {
 				DELIMITER,
 				""),
 		),
+		Entry("a quarantined test",
+			S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateQuarantined,
+				F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt),
+			),
+			Case(Succinct, Succinct|Parallel, Normal, Normal|Parallel, Verbose|Parallel,
+				DELIMITER,
+				spr("{{light-gray}}%s [QUARANTINED] [1.000 seconds]{{/}}", DENOTER),
+				"{{/}}A {{gray}}B {{light-gray}}{{bold}}[It] C{{/}}",
+				"{{gray}}cl2.go:80{{/}}",
+				"",
+				"  {{light-gray}}[QUARANTINED] failure",
+				"  message{{/}}",
+				spr("  {{light-gray}}In {{bold}}[It]{{/}}{{light-gray}} at: {{bold}}cl3.go:103{{/}} {{gray}}@ %s{{/}}", FORMATTED_TIME),
+				DELIMITER,
+				""),
+		),
 		Entry("a failed test with a failure in an intermediate BeforeEach",
 			S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "C", cl2, types.SpecStateFailed,
 				F("failure\nmessage", cl3, types.FailureNodeInContainer, FailureNodeLocation(cl4), types.NodeTypeBeforeEach, 1),
@@ -1837,6 +1880,26 @@ var _ = Describe("DefaultReporter", func() {
 			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
 			"",
 		),
+		Entry("the suite passes and is running a shard",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				SuiteConfig:    types.SuiteConfig{Shard: "1/2"},
+				PreRunStats:    types.PreRunStats{TotalSpecs: 12, SpecsThatWillRun: 4, SpecsInOtherShards: 6},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.NodeTypeBeforeSuite),
+					S(types.SpecStatePassed), S(types.SpecStatePassed), S(types.SpecStatePassed),
+					S(types.SpecStatePending),
+					S(types.SpecStateSkipped),
+					S(types.NodeTypeAfterSuite),
+				},
+			},
+			"",
+			"{{green}}{{bold}}Ran 3 of 12 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}1 Pending{{/}} | {{cyan}}{{bold}}1 Skipped{{/}} | {{gray}}{{bold}}6 In Other Shards{{/}}",
+			"",
+		),
 		Entry("the suite passes and has flaky specs",
 			C(),
 			types.Report{
@@ -1858,6 +1921,68 @@ var _ = Describe("DefaultReporter", func() {
 			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}7 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{light-yellow}}{{bold}}2 Flaked{{/}} | {{yellow}}{{bold}}2 Pending{{/}} | {{cyan}}{{bold}}3 Skipped{{/}}",
 			"",
 		),
+		Entry("the suite passes and has quarantined specs",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 5, SpecsThatWillRun: 5},
+				RunTime:        time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.SpecStatePassed), S(types.SpecStatePassed), S(types.SpecStatePassed),
+					S(types.SpecStateQuarantined, F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, cl1)),
+					S(types.SpecStateQuarantined, F("FAILURE MESSAGE", types.FailureNodeIsLeafNode, types.NodeTypeIt, cl1)),
+				},
+			},
+			"",
+			"{{green}}{{bold}}Ran 5 of 5 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}3 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{light-gray}}{{bold}}2 Quarantined{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite passes and has measurement comparisons",
+			C(),
+			types.Report{
+				SuiteSucceeded: true,
+				PreRunStats:    types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2},
+				RunTime:        time.Minute,
+				SpecReports:    types.SpecReports{S(types.SpecStatePassed), S(types.SpecStatePassed)},
+				MeasurementComparisons: types.MeasurementComparisons{
+					{SpecText: "A sorts", Name: "sorting", Type: types.MeasurementTypeDuration, Baseline: float64(time.Millisecond), Current: float64(1050 * time.Microsecond), Change: 0.05},
+					{SpecText: "B fills", Name: "memory", Type: types.MeasurementTypeValue, Units: "MB", Baseline: 10, Current: 9, Change: -0.1},
+				},
+			},
+			"",
+			"{{bold}}Comparing Measurements to Baseline:{{/}}",
+			"  {{bold}}Spec     Name     Baseline  Current  Change{{/}}",
+			"  A sorts  sorting  1ms       1.05ms   +5.0%",
+			"  B fills  memory   10 MB     9 MB     -10.0%",
+			"",
+			"{{green}}{{bold}}Ran 2 of 2 Specs in 60.000 seconds{{/}}",
+			"{{green}}{{bold}}SUCCESS!{{/}} -- {{green}}{{bold}}2 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
+		Entry("the suite fails because measurements regressed",
+			C(),
+			types.Report{
+				SuiteSucceeded:             false,
+				SpecialSuiteFailureReasons: []string{"Measurements regressed relative to the baseline"},
+				PreRunStats:                types.PreRunStats{TotalSpecs: 2, SpecsThatWillRun: 2},
+				RunTime:                    time.Minute,
+				SpecReports:                types.SpecReports{S(types.SpecStatePassed), S(types.SpecStatePassed)},
+				MeasurementComparisons: types.MeasurementComparisons{
+					{SpecText: "A sorts", Name: "sorting", Type: types.MeasurementTypeDuration, Baseline: float64(time.Millisecond), Current: float64(2 * time.Millisecond), Change: 1, Regressed: true},
+					{SpecText: "B fills", Name: "memory", Type: types.MeasurementTypeValue, Units: "MB", Baseline: 10, Current: 9, Change: -0.1},
+				},
+			},
+			"",
+			"{{red}}{{bold}}Comparing Measurements to Baseline - 1 Regressed:{{/}}",
+			"  {{bold}}Spec     Name     Baseline  Current  Change{{/}}",
+			"  {{red}}A sorts  sorting  1ms       2ms      +100.0%  REGRESSED{{/}}",
+			"  B fills  memory   10 MB     9 MB     -10.0%",
+			"",
+			"{{red}}{{bold}}Ran 2 of 2 Specs in 60.000 seconds{{/}}",
+			"{{red}}{{bold}}FAIL! - Measurements regressed relative to the baseline{{/}} -- {{green}}{{bold}}2 Passed{{/}} | {{red}}{{bold}}0 Failed{{/}} | {{yellow}}{{bold}}0 Pending{{/}} | {{cyan}}{{bold}}0 Skipped{{/}}",
+			"",
+		),
 		Entry("the suite fails with one failed test",
 			C(),
 			types.Report{
@@ -2061,6 +2186,15 @@ var _ = Describe("DefaultReporter", func() {
 			"    {{gray}}"+cl0.String()+"{{/}}",
 			INDENTED_DELIMITER,
 			""),
+		Entry("With a spec that is waiting for exclusive resources",
+			C(),
+			PR(LeafNodeText("My Spec"), []string{"Container A"}, WaitingForExclusiveResources{"database", "cache"}),
+			INDENTED_DELIMITER,
+			"  {{/}}Container A{{/}} {{bold}}{{orange}}My Spec{{/}}",
+			"    {{gray}}"+cl0.String()+"{{/}}",
+			"    Waiting for {{bold}}{{orange}}Exclusive Resources: database, cache{{/}} (Wait Time: 2s)",
+			INDENTED_DELIMITER,
+			""),
 		Entry("With a current node that is not an It",
 			C(),
 			PR(LeafNodeText("My Spec"), []string{"Container A", "Container B", "Container C"}, types.NodeTypeBeforeEach),
@@ -0,0 +1,210 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+/*
+GithubOutputReporter emits GitHub Actions workflow commands as specs complete.  It is enabled via --github-output and runs alongside the DefaultReporter.
+
+Failed specs generate ::error annotations pointing at the failure's location, quarantined and flaky specs generate ::warning annotations, and the output of every spec that failed or wrote to stdout/stderr/GinkgoWriter is wrapped in a collapsible ::group::.  When the suite ends a Markdown summary is appended to the file at $GITHUB_STEP_SUMMARY (if set).
+
+Annotation paths are made relative to $GITHUB_WORKSPACE so that GitHub can attach them to the correct file.
+*/
+type GithubOutputReporter struct {
+	writer      io.Writer
+	workspace   string
+	stepSummary string
+	lock        *sync.Mutex
+}
+
+func NewGithubOutputReporter(writer io.Writer) *GithubOutputReporter {
+	return &GithubOutputReporter{
+		writer:      writer,
+		workspace:   os.Getenv("GITHUB_WORKSPACE"),
+		stepSummary: os.Getenv("GITHUB_STEP_SUMMARY"),
+		lock:        &sync.Mutex{},
+	}
+}
+
+func (r *GithubOutputReporter) SuiteWillBegin(report types.Report) {}
+func (r *GithubOutputReporter) WillRun(report types.SpecReport)    {}
+
+func (r *GithubOutputReporter) DidRun(report types.SpecReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	title := report.FullText()
+	if title == "" {
+		title = fmt.Sprintf("[%s]", report.LeafNodeType)
+	}
+
+	failedOrQuarantined := report.Failed() || report.State.Is(types.SpecStateQuarantined)
+	output := report.CombinedOutput()
+	if failedOrQuarantined || output != "" {
+		fmt.Fprintf(r.writer, "::group::[%s] %s\n", strings.ToUpper(report.State.String()), escapeWorkflowCommandData(title))
+		if output != "" {
+			fmt.Fprintln(r.writer, strings.TrimRight(output, "\n"))
+		}
+		if failedOrQuarantined {
+			fmt.Fprintln(r.writer, failureDetails(report))
+		}
+		fmt.Fprintln(r.writer, "::endgroup::")
+	}
+
+	switch {
+	case report.Failed():
+		r.emitAnnotation("error", failureAnnotationLocation(report.Failure), title, failureDetails(report))
+	case report.State.Is(types.SpecStateQuarantined):
+		message := "Quarantined spec failed"
+		if report.QuarantineReason != "" {
+			message += " (" + report.QuarantineReason + ")"
+		}
+		r.emitAnnotation("warning", failureAnnotationLocation(report.Failure), title, message+"\n"+failureDetails(report))
+	case report.State.Is(types.SpecStatePassed) && report.NumAttempts > 1 && report.MaxFlakeAttempts > 1:
+		r.emitAnnotation("warning", report.LeafNodeLocation, title, fmt.Sprintf("Flaky spec passed after %d attempts", report.NumAttempts))
+	}
+}
+
+func (r *GithubOutputReporter) SuiteDidEnd(report types.Report) {
+	if r.stepSummary == "" {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	f, err := os.OpenFile(r.stepSummary, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		fmt.Fprintf(r.writer, "::warning::Failed to write GitHub step summary: %s\n", escapeWorkflowCommandData(err.Error()))
+		return
+	}
+	defer f.Close()
+	fmt.Fprint(f, GithubStepSummary(report))
+}
+
+func (r *GithubOutputReporter) EmitFailure(state types.SpecState, failure types.Failure) {}
+func (r *GithubOutputReporter) EmitProgressReport(progressReport types.ProgressReport)   {}
+func (r *GithubOutputReporter) EmitReportEntry(entry types.ReportEntry)                  {}
+func (r *GithubOutputReporter) EmitSpecEvent(event types.SpecEvent)                      {}
+
+func (r *GithubOutputReporter) emitAnnotation(level string, location types.CodeLocation, title string, message string) {
+	properties := []string{}
+	if location.FileName != "" {
+		properties = append(properties, "file="+escapeWorkflowCommandProperty(r.relativePath(location.FileName)))
+		if location.LineNumber > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", location.LineNumber))
+		}
+	}
+	properties = append(properties, "title="+escapeWorkflowCommandProperty(title))
+	fmt.Fprintf(r.writer, "::%s %s::%s\n", level, strings.Join(properties, ","), escapeWorkflowCommandData(message))
+}
+
+func (r *GithubOutputReporter) relativePath(path string) string {
+	base := r.workspace
+	if base == "" {
+		base, _ = os.Getwd()
+	}
+	if base == "" {
+		return path
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// GithubStepSummary renders a Markdown summary of the report suitable for $GITHUB_STEP_SUMMARY
+func GithubStepSummary(report types.Report) string {
+	out := &strings.Builder{}
+	status := "passed"
+	if !report.SuiteSucceeded {
+		status = "failed"
+	}
+	specs := report.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+	fmt.Fprintf(out, "### %s %s\n\n", markdownCell(report.SuiteDescription), status)
+	fmt.Fprintf(out, "Ran %d of %d specs in %.3f seconds\n\n",
+		specs.CountWithState(types.SpecStatePassed|types.SpecStateFailureStates|types.SpecStateQuarantined),
+		report.PreRunStats.TotalSpecs,
+		report.RunTime.Seconds())
+	for _, reason := range report.SpecialSuiteFailureReasons {
+		fmt.Fprintf(out, "> %s\n\n", markdownCell(reason))
+	}
+
+	fmt.Fprintln(out, "| Passed | Failed | Flaked | Quarantined | Pending | Skipped |")
+	fmt.Fprintln(out, "| --- | --- | --- | --- | --- | --- |")
+	fmt.Fprintf(out, "| %d | %d | %d | %d | %d | %d |\n",
+		specs.CountWithState(types.SpecStatePassed),
+		specs.CountWithState(types.SpecStateFailureStates),
+		specs.CountOfFlakedSpecs(),
+		specs.CountWithState(types.SpecStateQuarantined),
+		specs.CountWithState(types.SpecStatePending),
+		specs.CountWithState(types.SpecStateSkipped),
+	)
+
+	failures := report.SpecReports.WithState(types.SpecStateFailureStates)
+	if len(failures) > 0 {
+		fmt.Fprintln(out, "\n#### Failures")
+		fmt.Fprintln(out, "")
+		fmt.Fprintln(out, "| Spec | State | Location | Failure |")
+		fmt.Fprintln(out, "| --- | --- | --- | --- |")
+		for _, failure := range failures {
+			title := failure.FullText()
+			if title == "" {
+				title = fmt.Sprintf("[%s]", failure.LeafNodeType)
+			}
+			fmt.Fprintf(out, "| %s | %s | `%s` | %s |\n",
+				markdownCell(title),
+				failure.State,
+				failureAnnotationLocation(failure.Failure),
+				markdownCell(failure.Failure.Message),
+			)
+		}
+	}
+	fmt.Fprintln(out, "")
+	return out.String()
+}
+
+func failureAnnotationLocation(failure types.Failure) types.CodeLocation {
+	if failure.Location.FileName != "" {
+		return failure.Location
+	}
+	return failure.FailureNodeLocation
+}
+
+func failureDetails(report types.SpecReport) string {
+	details := fmt.Sprintf("[%s] %s\nIn [%s] at: %s", strings.ToUpper(report.State.String()), report.Failure.Message, report.Failure.FailureNodeType, report.Failure.Location)
+	if report.Failure.ForwardedPanic != "" {
+		details += "\n\n" + report.Failure.ForwardedPanic
+	}
+	return details
+}
+
+func escapeWorkflowCommandData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+func escapeWorkflowCommandProperty(s string) string {
+	s = escapeWorkflowCommandData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	s = strings.ReplaceAll(s, ",", "%2C")
+	return s
+}
+
+func markdownCell(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return s
+}
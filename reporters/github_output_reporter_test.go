@@ -0,0 +1,149 @@
+package reporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("GithubOutputReporter", func() {
+	var buf *gbytes.Buffer
+	var reporter *reporters.GithubOutputReporter
+	var summary string
+	var location func(file string, line int) types.CodeLocation
+
+	BeforeEach(func() {
+		workspace := GinkgoT().TempDir()
+		summary = filepath.Join(GinkgoT().TempDir(), "summary.md")
+		GinkgoT().Setenv("GITHUB_WORKSPACE", workspace)
+		GinkgoT().Setenv("GITHUB_STEP_SUMMARY", summary)
+		location = func(file string, line int) types.CodeLocation {
+			return types.CodeLocation{FileName: filepath.Join(workspace, file), LineNumber: line}
+		}
+
+		buf = gbytes.NewBuffer()
+		reporter = reporters.NewGithubOutputReporter(buf)
+	})
+
+	Describe("DidRun", func() {
+		It("emits nothing for passing specs without output", func() {
+			reporter.DidRun(S(types.NodeTypeIt, "A", cl0))
+			Ω(buf.Contents()).Should(BeEmpty())
+		})
+
+		It("groups the output of passing specs", func() {
+			reporter.DidRun(S(types.NodeTypeIt, CTS("Books"), "A", cl0, STD("hello\n"), GW("there\n")))
+			Ω(string(buf.Contents())).Should(MatchLines(
+				"::group::[PASSED] Books A",
+				"hello",
+				"",
+				"there",
+				"::endgroup::",
+				"",
+			))
+		})
+
+		It("groups the failure and emits an error annotation at the failure location relative to the workspace", func() {
+			reporter.DidRun(S(types.NodeTypeIt, CTS("Books"), "can, be: read", location("books/books_test.go", 17), types.SpecStateFailed,
+				F("failure\n100% bad", location("books/helpers_test.go", 42), types.FailureNodeIsLeafNode, FailureNodeLocation(location("books/books_test.go", 17)), types.NodeTypeIt),
+			))
+			Ω(string(buf.Contents())).Should(MatchLines(
+				"::group::[FAILED] Books can, be: read",
+				"[FAILED] failure",
+				"100% bad",
+				spr("In [It] at: %s", location("books/helpers_test.go", 42)),
+				"::endgroup::",
+				spr("::error file=books/helpers_test.go,line=42,title=Books can%%2C be%%3A read::[FAILED] failure%%0A100%%25 bad%%0AIn [It] at: %s", location("books/helpers_test.go", 42)),
+				"",
+			))
+		})
+
+		It("falls back to the failure node location", func() {
+			reporter.DidRun(S(types.NodeTypeIt, "A", cl0, types.SpecStatePanicked,
+				F("boom", types.FailureNodeIsLeafNode, FailureNodeLocation(location("a_test.go", 3)), types.NodeTypeIt, ForwardedPanic("the panic")),
+			))
+			Ω(string(buf.Contents())).Should(ContainSubstring("::error file=a_test.go,line=3,title=A::[PANICKED] boom"))
+			Ω(string(buf.Contents())).Should(ContainSubstring("%0A%0Athe panic\n"))
+		})
+
+		It("emits warnings for quarantined and flaky specs", func() {
+			quarantined := S(types.NodeTypeIt, "Q", cl0, types.SpecStateQuarantined, F("nope", location("q_test.go", 8), types.FailureNodeIsLeafNode, types.NodeTypeIt))
+			quarantined.QuarantineReason = "tracked in #42"
+			reporter.DidRun(quarantined)
+			reporter.DidRun(S(types.NodeTypeIt, "F", location("f_test.go", 9), 3, FlakeAttempts(5)))
+
+			Ω(string(buf.Contents())).Should(ContainSubstring("::group::[QUARANTINED] Q"))
+			Ω(string(buf.Contents())).Should(ContainSubstring("::warning file=q_test.go,line=8,title=Q::Quarantined spec failed (tracked in #42)%0A[QUARANTINED] nope"))
+			Ω(string(buf.Contents())).Should(ContainSubstring("::warning file=f_test.go,line=9,title=F::Flaky spec passed after 3 attempts\n"))
+		})
+	})
+
+	Describe("SuiteDidEnd", func() {
+		var report types.Report
+		BeforeEach(func() {
+			report = types.Report{
+				SuiteDescription: "My Suite",
+				SuiteSucceeded:   false,
+				PreRunStats:      types.PreRunStats{TotalSpecs: 6, SpecsThatWillRun: 5},
+				RunTime:          time.Minute,
+				SpecReports: types.SpecReports{
+					S(types.NodeTypeIt, types.SpecStatePassed),
+					S(types.NodeTypeIt, types.SpecStatePassed, 2, FlakeAttempts(3)),
+					S(types.NodeTypeIt, types.SpecStatePending),
+					S(types.NodeTypeIt, types.SpecStateSkipped),
+					S(types.NodeTypeIt, CTS("Books"), "A | B", types.SpecStateFailed, F("line one\nline two", location("books_test.go", 12))),
+				},
+			}
+		})
+
+		It("appends a markdown summary to $GITHUB_STEP_SUMMARY", func() {
+			Ω(os.WriteFile(summary, []byte("previous step\n"), 0666)).Should(Succeed())
+			reporter.SuiteDidEnd(report)
+
+			content, err := os.ReadFile(summary)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(MatchLines(
+				"previous step",
+				"### My Suite failed",
+				"",
+				"Ran 3 of 6 specs in 60.000 seconds",
+				"",
+				"| Passed | Failed | Flaked | Quarantined | Pending | Skipped |",
+				"| --- | --- | --- | --- | --- | --- |",
+				"| 2 | 1 | 1 | 0 | 1 | 1 |",
+				"",
+				"#### Failures",
+				"",
+				"| Spec | State | Location | Failure |",
+				"| --- | --- | --- | --- |",
+				spr("| Books A \\| B | failed | `%s` | line one<br>line two |", location("books_test.go", 12)),
+				"",
+				"",
+			))
+		})
+
+		It("does nothing when $GITHUB_STEP_SUMMARY is not set", func() {
+			GinkgoT().Setenv("GITHUB_STEP_SUMMARY", "")
+			reporters.NewGithubOutputReporter(buf).SuiteDidEnd(report)
+			Ω(summary).ShouldNot(BeAnExistingFile())
+			Ω(buf.Contents()).Should(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("NewConsoleReporter", func() {
+	It("returns the DefaultReporter unless --github-output is set", func() {
+		Ω(reporters.NewConsoleReporter(types.ReporterConfig{}, gbytes.NewBuffer())).Should(BeAssignableToTypeOf(&reporters.DefaultReporter{}))
+
+		reporter := reporters.NewConsoleReporter(types.ReporterConfig{GithubOutput: true}, gbytes.NewBuffer())
+		Ω(reporter).Should(HaveLen(2))
+		Ω(reporter.(reporters.CompositeReporter)[0]).Should(BeAssignableToTypeOf(&reporters.DefaultReporter{}))
+		Ω(reporter.(reporters.CompositeReporter)[1]).Should(BeAssignableToTypeOf(&reporters.GithubOutputReporter{}))
+	})
+})
@@ -0,0 +1,209 @@
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// GoTestJSONEvent mirrors the events emitted by `go test -json` (see `go doc test2json`)
+type GoTestJSONEvent struct {
+	Time    *time.Time `json:",omitempty"`
+	Action  string
+	Package string   `json:",omitempty"`
+	Test    string   `json:",omitempty"`
+	Elapsed *float64 `json:",omitempty"`
+	Output  string   `json:",omitempty"`
+}
+
+/*
+GoTestJSONReporter emits `go test -json` compatible events in place of Ginkgo's usual console output.  It is enabled via --go-test-json.
+
+The suite is reported as a single top-level test named after the suite description and each spec is reported as a subtest named after its full text.  Spec names are rewritten the way the testing package rewrites subtest names (spaces become underscores and duplicates are suffixed with #01, #02, ...).  The spec's captured output and failure are emitted as output events followed by a pass, fail, or skip event.
+*/
+type GoTestJSONReporter struct {
+	writer  io.Writer
+	pkg     string
+	lock    *sync.Mutex
+	suite   string
+	names   map[string]int
+	running map[string]string
+}
+
+// NewGoTestJSONReporter returns a GoTestJSONReporter that reports specs under the passed-in package import path.  If pkg is empty the suite's path is used instead.
+func NewGoTestJSONReporter(writer io.Writer, pkg string) *GoTestJSONReporter {
+	return &GoTestJSONReporter{
+		writer:  writer,
+		pkg:     pkg,
+		lock:    &sync.Mutex{},
+		names:   map[string]int{},
+		running: map[string]string{},
+	}
+}
+
+func (r *GoTestJSONReporter) SuiteWillBegin(report types.Report) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pkg == "" {
+		r.pkg = report.SuitePath
+	}
+	r.suite = r.uniqueName(goTestName(report.SuiteDescription))
+	r.emit(GoTestJSONEvent{Action: "start"})
+	r.emit(GoTestJSONEvent{Action: "run", Test: r.suite})
+	r.emit(GoTestJSONEvent{Action: "output", Test: r.suite, Output: fmt.Sprintf("=== RUN   %s\n", r.suite)})
+}
+
+func (r *GoTestJSONReporter) WillRun(report types.SpecReport) {
+	if !reportsAsGoSubtest(report) {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.startSubtest(report)
+}
+
+func (r *GoTestJSONReporter) DidRun(report types.SpecReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !reportsAsGoSubtest(report) {
+		if output := report.CombinedOutput(); output != "" {
+			r.emitOutput(r.suite, output)
+		}
+		return
+	}
+
+	key := goTestSpecKey(report)
+	name, ok := r.running[key]
+	if !ok {
+		name = r.startSubtest(report)
+	}
+	delete(r.running, key)
+
+	if output := report.CombinedOutput(); output != "" {
+		r.emitOutput(name, output)
+	}
+
+	action := "pass"
+	switch {
+	case report.Failed():
+		action = "fail"
+		r.emitOutput(name, failureDetails(report))
+	case report.State.Is(types.SpecStateQuarantined):
+		action = "skip"
+		r.emitOutput(name, failureDetails(report))
+	case report.State.Is(types.SpecStatePending | types.SpecStateSkipped):
+		action = "skip"
+		if report.Failure.Message != "" {
+			r.emitOutput(name, report.Failure.Message)
+		}
+	}
+
+	elapsed := report.RunTime.Seconds()
+	r.emit(GoTestJSONEvent{Action: "output", Test: name, Output: fmt.Sprintf("    --- %s: %s (%.2fs)\n", strings.ToUpper(action), name, elapsed)})
+	r.emit(GoTestJSONEvent{Action: action, Test: name, Elapsed: &elapsed})
+}
+
+func (r *GoTestJSONReporter) SuiteDidEnd(report types.Report) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, reason := range report.SpecialSuiteFailureReasons {
+		r.emitOutput(r.suite, reason)
+	}
+
+	action := "pass"
+	if !report.SuiteSucceeded {
+		action = "fail"
+	}
+	elapsed := report.RunTime.Seconds()
+	r.emit(GoTestJSONEvent{Action: "output", Test: r.suite, Output: fmt.Sprintf("--- %s: %s (%.2fs)\n", strings.ToUpper(action), r.suite, elapsed)})
+	r.emit(GoTestJSONEvent{Action: action, Test: r.suite, Elapsed: &elapsed})
+	r.emit(GoTestJSONEvent{Action: "output", Output: strings.ToUpper(action) + "\n"})
+	r.emit(GoTestJSONEvent{Action: action, Elapsed: &elapsed})
+}
+
+func (r *GoTestJSONReporter) EmitFailure(state types.SpecState, failure types.Failure) {}
+func (r *GoTestJSONReporter) EmitProgressReport(progressReport types.ProgressReport)   {}
+func (r *GoTestJSONReporter) EmitReportEntry(entry types.ReportEntry)                  {}
+func (r *GoTestJSONReporter) EmitSpecEvent(event types.SpecEvent)                      {}
+
+func (r *GoTestJSONReporter) startSubtest(report types.SpecReport) string {
+	text := report.FullText()
+	if !report.LeafNodeType.Is(types.NodeTypeIt) {
+		text = strings.TrimSpace(fmt.Sprintf("[%s] %s", report.LeafNodeType, text))
+	}
+	name := r.uniqueName(r.suite + "/" + goTestName(text))
+	r.running[goTestSpecKey(report)] = name
+	r.emit(GoTestJSONEvent{Action: "run", Test: name})
+	r.emit(GoTestJSONEvent{Action: "output", Test: name, Output: fmt.Sprintf("=== RUN   %s\n", name)})
+	return name
+}
+
+func (r *GoTestJSONReporter) uniqueName(name string) string {
+	count := r.names[name]
+	r.names[name] = count + 1
+	if count == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s#%02d", name, count)
+}
+
+func (r *GoTestJSONReporter) emitOutput(test string, output string) {
+	for _, line := range strings.SplitAfter(strings.TrimRight(output, "\n")+"\n", "\n") {
+		if line == "" {
+			continue
+		}
+		r.emit(GoTestJSONEvent{Action: "output", Test: test, Output: line})
+	}
+}
+
+func (r *GoTestJSONReporter) emit(event GoTestJSONEvent) {
+	t := time.Now()
+	event.Time = &t
+	event.Package = r.pkg
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	r.writer.Write(append(encoded, '\n'))
+}
+
+// only specs are reported as subtests - suite-level nodes are only reported if they fail
+func reportsAsGoSubtest(report types.SpecReport) bool {
+	return report.LeafNodeType.Is(types.NodeTypeIt) || report.Failed()
+}
+
+// WillRun and DidRun receive copies of the same spec, so we pair them up by node type, location, and text
+func goTestSpecKey(report types.SpecReport) string {
+	return fmt.Sprintf("%s %s %s", report.LeafNodeType, report.LeafNodeLocation, report.FullText())
+}
+
+// goTestName rewrites name the way the testing package rewrites subtest names
+func goTestName(name string) string {
+	if name == "" {
+		return "_"
+	}
+	b := &strings.Builder{}
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteRune('_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b.WriteString(s[1 : len(s)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
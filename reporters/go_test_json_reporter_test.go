@@ -0,0 +1,151 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("GoTestJSONReporter", func() {
+	var buf *gbytes.Buffer
+	var reporter *reporters.GoTestJSONReporter
+
+	BeforeEach(func() {
+		buf = gbytes.NewBuffer()
+		reporter = reporters.NewGoTestJSONReporter(buf, "example.com/pkg")
+	})
+
+	events := func() []reporters.GoTestJSONEvent {
+		out := []reporters.GoTestJSONEvent{}
+		for _, line := range strings.Split(strings.TrimSuffix(string(buf.Contents()), "\n"), "\n") {
+			event := reporters.GoTestJSONEvent{}
+			Ω(json.Unmarshal([]byte(line), &event)).Should(Succeed())
+			Ω(event.Time).ShouldNot(BeNil())
+			Ω(event.Package).Should(Equal("example.com/pkg"))
+			out = append(out, event)
+		}
+		return out
+	}
+
+	summarize := func(events []reporters.GoTestJSONEvent) []string {
+		out := []string{}
+		for _, event := range events {
+			fields := []string{event.Action}
+			for _, field := range []string{event.Test, strings.TrimSpace(event.Output)} {
+				if field != "" {
+					fields = append(fields, field)
+				}
+			}
+			out = append(out, strings.Join(fields, " "))
+		}
+		return out
+	}
+
+	run := func(report types.Report) {
+		reporter.SuiteWillBegin(report)
+		for _, spec := range report.SpecReports {
+			reporter.WillRun(spec)
+			reporter.DidRun(spec)
+		}
+		reporter.SuiteDidEnd(report)
+	}
+
+	It("reports the suite as a test and each spec as a subtest", func() {
+		run(types.Report{
+			SuiteDescription: "My Suite",
+			SuiteSucceeded:   false,
+			RunTime:          time.Minute,
+			SpecReports: types.SpecReports{
+				S(types.NodeTypeBeforeSuite, cl0, STD("before suite output\n")),
+				S(types.NodeTypeIt, CTS("A"), "passes", cl1, GW("some output\n")),
+				S(types.NodeTypeIt, CTS("A"), "fails", cl2, types.SpecStateFailed, F("boom", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt)),
+				S(types.NodeTypeIt, CTS("A"), "is pending", cl3, types.SpecStatePending),
+			},
+		})
+
+		emitted := events()
+		Ω(summarize(emitted)).Should(Equal([]string{
+			"start",
+			"run My_Suite",
+			"output My_Suite === RUN   My_Suite",
+			"output My_Suite before suite output",
+			"run My_Suite/A_passes",
+			"output My_Suite/A_passes === RUN   My_Suite/A_passes",
+			"output My_Suite/A_passes some output",
+			"output My_Suite/A_passes --- PASS: My_Suite/A_passes (1.00s)",
+			"pass My_Suite/A_passes",
+			"run My_Suite/A_fails",
+			"output My_Suite/A_fails === RUN   My_Suite/A_fails",
+			"output My_Suite/A_fails [FAILED] boom",
+			"output My_Suite/A_fails In [It] at: cl3.go:103",
+			"output My_Suite/A_fails --- FAIL: My_Suite/A_fails (1.00s)",
+			"fail My_Suite/A_fails",
+			"run My_Suite/A_is_pending",
+			"output My_Suite/A_is_pending === RUN   My_Suite/A_is_pending",
+			"output My_Suite/A_is_pending --- SKIP: My_Suite/A_is_pending (1.00s)",
+			"skip My_Suite/A_is_pending",
+			"output My_Suite --- FAIL: My_Suite (60.00s)",
+			"fail My_Suite",
+			"output FAIL",
+			"fail",
+		}))
+
+		for _, event := range emitted {
+			if event.Action == "output" {
+				Ω(event.Output).Should(HaveSuffix("\n"))
+			}
+			if event.Action == "pass" || event.Action == "fail" || event.Action == "skip" {
+				Ω(event.Elapsed).ShouldNot(BeNil())
+			}
+		}
+		Ω(*emitted[len(emitted)-1].Elapsed).Should(Equal(60.0))
+	})
+
+	It("reports failed suite-level nodes as subtests", func() {
+		run(types.Report{
+			SuiteDescription: "My Suite",
+			SpecReports: types.SpecReports{
+				S(types.NodeTypeBeforeSuite, cl0, types.SpecStateFailed, F("setup failed", cl1, types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeBeforeSuite)),
+			},
+		})
+
+		Ω(summarize(events())).Should(ContainElements(
+			"run My_Suite/[BeforeSuite]",
+			"output My_Suite/[BeforeSuite] [FAILED] setup failed",
+			"fail My_Suite/[BeforeSuite]",
+		))
+	})
+
+	It("de-duplicates subtest names the way the testing package does", func() {
+		run(types.Report{
+			SuiteDescription: "My Suite",
+			SuiteSucceeded:   true,
+			SpecReports: types.SpecReports{
+				S(types.NodeTypeIt, "same\tname", cl0),
+				S(types.NodeTypeIt, "same name", cl1),
+				S(types.NodeTypeIt, "same name", cl2),
+			},
+		})
+
+		Ω(summarize(events())).Should(ContainElements(
+			"pass My_Suite/same_name",
+			"pass My_Suite/same_name#01",
+			"pass My_Suite/same_name#02",
+			"pass My_Suite",
+			"pass",
+		))
+	})
+
+	It("falls back to the suite path when no package is provided", func() {
+		reporter = reporters.NewGoTestJSONReporter(buf, "")
+		reporter.SuiteWillBegin(types.Report{SuiteDescription: "My Suite", SuitePath: "/path/to/suite"})
+		Ω(string(buf.Contents())).Should(ContainSubstring(`"Package":"/path/to/suite"`))
+	})
+})
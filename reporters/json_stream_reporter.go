@@ -0,0 +1,101 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// The names of the events emitted by the JSONStreamReporter.  Each corresponds to the Reporter method that emitted it.
+const (
+	JSONStreamEventSuiteWillBegin     = "SuiteWillBegin"
+	JSONStreamEventWillRun            = "WillRun"
+	JSONStreamEventDidRun             = "DidRun"
+	JSONStreamEventSuiteDidEnd        = "SuiteDidEnd"
+	JSONStreamEventEmitFailure        = "EmitFailure"
+	JSONStreamEventEmitProgressReport = "EmitProgressReport"
+	JSONStreamEventEmitReportEntry    = "EmitReportEntry"
+	JSONStreamEventEmitSpecEvent      = "EmitSpecEvent"
+)
+
+// JSONStreamEvent is a single line in the stream emitted by the JSONStreamReporter.  Only the fields relevant to the Event are populated.
+type JSONStreamEvent struct {
+	Event           string
+	Time            time.Time
+	ParallelProcess int
+
+	Report         *types.Report         `json:",omitempty"`
+	SpecReport     *types.SpecReport     `json:",omitempty"`
+	State          types.SpecState       `json:",omitempty"`
+	Failure        *types.Failure        `json:",omitempty"`
+	ProgressReport *types.ProgressReport `json:",omitempty"`
+	ReportEntry    *types.ReportEntry    `json:",omitempty"`
+	SpecEvent      *types.SpecEvent      `json:",omitempty"`
+}
+
+/*
+JSONStreamReporter emits every reporter event as a newline-delimited JSON object (see JSONStreamEvent) as soon as it happens.  It is enabled via --json-stream.
+
+Each event is written with a single call to Write.  This allows the events of multiple parallel processes to be funneled through the parallel server into a single stream without interleaving.
+*/
+type JSONStreamReporter struct {
+	writer          io.Writer
+	parallelProcess int
+	lock            *sync.Mutex
+}
+
+func NewJSONStreamReporter(writer io.Writer, parallelProcess int) *JSONStreamReporter {
+	return &JSONStreamReporter{
+		writer:          writer,
+		parallelProcess: parallelProcess,
+		lock:            &sync.Mutex{},
+	}
+}
+
+func (r *JSONStreamReporter) SuiteWillBegin(report types.Report) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventSuiteWillBegin, Report: &report})
+}
+
+func (r *JSONStreamReporter) WillRun(report types.SpecReport) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventWillRun, SpecReport: &report})
+}
+
+func (r *JSONStreamReporter) DidRun(report types.SpecReport) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventDidRun, SpecReport: &report})
+}
+
+func (r *JSONStreamReporter) SuiteDidEnd(report types.Report) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventSuiteDidEnd, Report: &report})
+}
+
+func (r *JSONStreamReporter) EmitFailure(state types.SpecState, failure types.Failure) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventEmitFailure, State: state, Failure: &failure})
+}
+
+func (r *JSONStreamReporter) EmitProgressReport(progressReport types.ProgressReport) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventEmitProgressReport, ProgressReport: &progressReport})
+}
+
+func (r *JSONStreamReporter) EmitReportEntry(entry types.ReportEntry) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventEmitReportEntry, ReportEntry: &entry})
+}
+
+func (r *JSONStreamReporter) EmitSpecEvent(event types.SpecEvent) {
+	r.emit(JSONStreamEvent{Event: JSONStreamEventEmitSpecEvent, SpecEvent: &event})
+}
+
+func (r *JSONStreamReporter) emit(event JSONStreamEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	event.Time = time.Now()
+	event.ParallelProcess = r.parallelProcess
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	r.writer.Write(append(encoded, '\n'))
+}
@@ -0,0 +1,91 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("JSONStreamReporter", func() {
+	var buf *gbytes.Buffer
+	var reporter *reporters.JSONStreamReporter
+
+	BeforeEach(func() {
+		buf = gbytes.NewBuffer()
+		reporter = reporters.NewJSONStreamReporter(buf, 3)
+	})
+
+	events := func() []reporters.JSONStreamEvent {
+		out := []reporters.JSONStreamEvent{}
+		for _, line := range strings.Split(strings.TrimSuffix(string(buf.Contents()), "\n"), "\n") {
+			event := reporters.JSONStreamEvent{}
+			Ω(json.Unmarshal([]byte(line), &event)).Should(Succeed())
+			out = append(out, event)
+		}
+		return out
+	}
+
+	It("emits each event as a single line of JSON as it happens", func() {
+		spec := S(types.NodeTypeIt, "A", cl0, types.SpecStateFailed, F("boom", cl1, types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeIt))
+
+		reporter.SuiteWillBegin(types.Report{SuiteDescription: "My Suite"})
+		Ω(buf.Contents()).Should(HaveSuffix("\n"))
+		Ω(strings.Count(string(buf.Contents()), "\n")).Should(Equal(1))
+
+		reporter.WillRun(spec)
+		reporter.EmitSpecEvent(types.SpecEvent{SpecEventType: types.SpecEventByStart, Message: "a step", CodeLocation: cl1})
+		reporter.EmitReportEntry(types.ReportEntry{Name: "an entry", Location: cl2})
+		reporter.EmitProgressReport(types.ProgressReport{LeafNodeText: "A"})
+		reporter.EmitFailure(types.SpecStateFailed, spec.Failure)
+		reporter.DidRun(spec)
+		reporter.SuiteDidEnd(types.Report{SuiteDescription: "My Suite", SuiteSucceeded: false})
+
+		emitted := events()
+		Ω(emitted).Should(HaveLen(8))
+		names := []string{}
+		for _, event := range emitted {
+			names = append(names, event.Event)
+			Ω(event.ParallelProcess).Should(Equal(3))
+			Ω(event.Time.IsZero()).Should(BeFalse())
+		}
+		Ω(names).Should(Equal([]string{
+			reporters.JSONStreamEventSuiteWillBegin,
+			reporters.JSONStreamEventWillRun,
+			reporters.JSONStreamEventEmitSpecEvent,
+			reporters.JSONStreamEventEmitReportEntry,
+			reporters.JSONStreamEventEmitProgressReport,
+			reporters.JSONStreamEventEmitFailure,
+			reporters.JSONStreamEventDidRun,
+			reporters.JSONStreamEventSuiteDidEnd,
+		}))
+
+		Ω(emitted[0].Report.SuiteDescription).Should(Equal("My Suite"))
+		Ω(emitted[0].SpecReport).Should(BeNil())
+		Ω(emitted[1].SpecReport.LeafNodeText).Should(Equal("A"))
+		Ω(emitted[2].SpecEvent.Message).Should(Equal("a step"))
+		Ω(emitted[3].ReportEntry.Name).Should(Equal("an entry"))
+		Ω(emitted[4].ProgressReport.LeafNodeText).Should(Equal("A"))
+		Ω(emitted[5].State).Should(Equal(types.SpecStateFailed))
+		Ω(emitted[5].Failure.Message).Should(Equal("boom"))
+		Ω(emitted[6].SpecReport.State).Should(Equal(types.SpecStateFailed))
+		Ω(emitted[7].Report.SuiteSucceeded).Should(BeFalse())
+	})
+
+	It("omits the fields that aren't relevant to the event", func() {
+		reporter.WillRun(S(types.NodeTypeIt, "A", cl0))
+		Ω(string(buf.Contents())).Should(HavePrefix(`{"Event":"WillRun",`))
+		Ω(string(buf.Contents())).Should(ContainSubstring(`,"ParallelProcess":3,"SpecReport":{`))
+		Ω(string(buf.Contents())).ShouldNot(ContainSubstring(`"Report":`))
+
+		var fields map[string]json.RawMessage
+		Ω(json.Unmarshal(buf.Contents(), &fields)).Should(Succeed())
+		Ω(fields).Should(HaveLen(4))
+		Ω(fields).Should(HaveKey("SpecReport"))
+	})
+})
@@ -125,7 +125,7 @@ type JUnitTestCase struct {
 }
 
 type JUnitSkipped struct {
-	// Message maps onto "pending" if the test was marked pending, "skipped" if the test was marked skipped, and "skipped - REASON" if the user called Skip(REASON)
+	// Message maps onto "pending" if the test was marked pending, "skipped" if the test was marked skipped, "skipped - REASON" if the user called Skip(REASON), and "quarantined - REASON - FAILURE" if the test failed while quarantined
 	Message string `xml:"message,attr"`
 }
 
@@ -175,6 +175,8 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 				{"FlakeAttempts", fmt.Sprintf("%d", report.SuiteConfig.FlakeAttempts)},
 				{"DryRun", fmt.Sprintf("%t", report.SuiteConfig.DryRun)},
 				{"ParallelTotal", fmt.Sprintf("%d", report.SuiteConfig.ParallelTotal)},
+				{"Shard", report.SuiteConfig.Shard},
+				{"SpecsInOtherShards", fmt.Sprintf("%d", report.PreRunStats.SpecsInOtherShards)},
 				{"OutputInterceptorMode", report.SuiteConfig.OutputInterceptorMode},
 			},
 		},
@@ -221,6 +223,16 @@ func GenerateJUnitReportWithConfig(report types.Report, dst string, config Junit
 		case types.SpecStatePending:
 			test.Skipped = &JUnitSkipped{Message: "pending"}
 			suite.Disabled += 1
+		case types.SpecStateQuarantined:
+			message := "quarantined"
+			if spec.QuarantineReason != "" {
+				message += " - " + spec.QuarantineReason
+			}
+			if spec.Failure.Message != "" {
+				message += " - " + spec.Failure.Message
+			}
+			test.Skipped = &JUnitSkipped{Message: message}
+			suite.Skipped += 1
 		case types.SpecStateFailed:
 			test.Failure = &JUnitFailure{
 				Message:     spec.Failure.Message,
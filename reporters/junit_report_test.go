@@ -372,4 +372,35 @@ var _ = Describe("JunitReport", func() {
 			))
 		})
 	})
+
+	Describe("quarantined specs", func() {
+		It("reports them as skipped, including the reason and the failure", func() {
+			quarantined := S(types.NodeTypeIt, "A", cl0, types.SpecStateQuarantined,
+				F("failure\nmessage", cl1, types.FailureNodeIsLeafNode, FailureNodeLocation(cl0), types.NodeTypeIt),
+			)
+			quarantined.IsQuarantined = true
+			quarantined.QuarantineReason = "tracked in #42"
+			report := types.Report{
+				SuitePath:      "/path/to/suite",
+				SuiteSucceeded: true,
+				SpecReports:    types.SpecReports{quarantined},
+			}
+
+			fname := fmt.Sprintf("./report-%d", GinkgoParallelProcess())
+			Ω(reporters.GenerateJUnitReport(report, fname)).Should(Succeed())
+			DeferCleanup(os.Remove, fname)
+
+			generated := reporters.JUnitTestSuites{}
+			f, err := os.Open(fname)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(xml.NewDecoder(f).Decode(&generated)).Should(Succeed())
+
+			suite := generated.TestSuites[0]
+			Ω(suite.Skipped).Should(Equal(1))
+			Ω(suite.Failures).Should(Equal(0))
+			Ω(suite.TestCases[0].Status).Should(Equal("quarantined"))
+			Ω(suite.TestCases[0].Skipped.Message).Should(Equal("quarantined - tracked in #42 - failure\nmessage"))
+			Ω(suite.TestCases[0].Failure).Should(BeNil())
+		})
+	})
 })
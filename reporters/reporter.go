@@ -1,6 +1,8 @@
 package reporters
 
 import (
+	"io"
+
 	"github.com/onsi/ginkgo/v2/types"
 )
 
@@ -27,3 +29,62 @@ func (n NoopReporter) EmitFailure(state types.SpecState, failure types.Failure)
 func (n NoopReporter) EmitProgressReport(progressReport types.ProgressReport)   {}
 func (n NoopReporter) EmitReportEntry(entry types.ReportEntry)                  {}
 func (n NoopReporter) EmitSpecEvent(event types.SpecEvent)                      {}
+
+// CompositeReporter forwards every event to each of its Reporters, in order
+type CompositeReporter []Reporter
+
+func (c CompositeReporter) SuiteWillBegin(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteWillBegin(report)
+	}
+}
+
+func (c CompositeReporter) WillRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.WillRun(report)
+	}
+}
+
+func (c CompositeReporter) DidRun(report types.SpecReport) {
+	for _, reporter := range c {
+		reporter.DidRun(report)
+	}
+}
+
+func (c CompositeReporter) SuiteDidEnd(report types.Report) {
+	for _, reporter := range c {
+		reporter.SuiteDidEnd(report)
+	}
+}
+
+func (c CompositeReporter) EmitFailure(state types.SpecState, failure types.Failure) {
+	for _, reporter := range c {
+		reporter.EmitFailure(state, failure)
+	}
+}
+
+func (c CompositeReporter) EmitProgressReport(progressReport types.ProgressReport) {
+	for _, reporter := range c {
+		reporter.EmitProgressReport(progressReport)
+	}
+}
+
+func (c CompositeReporter) EmitReportEntry(entry types.ReportEntry) {
+	for _, reporter := range c {
+		reporter.EmitReportEntry(entry)
+	}
+}
+
+func (c CompositeReporter) EmitSpecEvent(event types.SpecEvent) {
+	for _, reporter := range c {
+		reporter.EmitSpecEvent(event)
+	}
+}
+
+// NewConsoleReporter returns the reporter Ginkgo uses to emit output to the console: the DefaultReporter, along with the GithubOutputReporter if --github-output is set
+func NewConsoleReporter(conf types.ReporterConfig, writer io.Writer) Reporter {
+	if !conf.GithubOutput {
+		return NewDefaultReporter(conf, writer)
+	}
+	return CompositeReporter{NewDefaultReporter(conf, writer), NewGithubOutputReporter(writer)}
+}
@@ -0,0 +1,270 @@
+/*
+
+TAP Reporter for Ginkgo
+
+Generates TAP version 14 reports (which are backwards compatible with TAP version 13 consumers that ignore indented subtests)
+https://testanything.org/tap-version-14-specification.html
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const tapVersionLine = "TAP version 14"
+
+var tapTopLevelTestPointRegExp = regexp.MustCompile(`^(not ok|ok) \d+`)
+var tapTopLevelPlanRegExp = regexp.MustCompile(`^1\.\.\d+$`)
+
+// tapNode is a node in the tree of containers and specs rendered as TAP subtests and test points
+type tapNode struct {
+	name     string
+	key      string
+	spec     *types.SpecReport
+	children []*tapNode
+}
+
+func (n *tapNode) child(name string, key string) *tapNode {
+	for _, child := range n.children {
+		if child.spec == nil && child.key == key {
+			return child
+		}
+	}
+	child := &tapNode{name: name, key: key}
+	n.children = append(n.children, child)
+	return child
+}
+
+// failed returns true if the node - or any of its descendants - failed
+func (n *tapNode) failed() bool {
+	if n.spec != nil {
+		return n.spec.Failed()
+	}
+	for _, child := range n.children {
+		if child.failed() {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+GenerateTAPReport generates a TAP version 14 report for the passed-in suite report.
+
+The suite is rendered as a single top-level subtest.  Each container is rendered as a nested subtest and each spec as a test point with a YAML diagnostic block that includes its state, location, run time, labels, and any failure.  Skipped and pending specs are marked with a SKIP directive and quarantined specs with a TODO directive.
+*/
+func GenerateTAPReport(report types.Report, dst string) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	root := &tapNode{}
+	for i := range report.SpecReports {
+		spec := report.SpecReports[i]
+		parent := root
+		for j, text := range spec.ContainerHierarchyTexts {
+			key := text
+			if j < len(spec.ContainerHierarchyLocations) {
+				key = fmt.Sprintf("%s %s", text, spec.ContainerHierarchyLocations[j])
+			}
+			parent = parent.child(text, key)
+		}
+		name := spec.LeafNodeText
+		if name == "" || spec.LeafNodeType != types.NodeTypeIt {
+			name = strings.TrimSpace(fmt.Sprintf("[%s] %s", spec.LeafNodeType, spec.LeafNodeText))
+		}
+		parent.children = append(parent.children, &tapNode{name: name, spec: &spec})
+	}
+
+	name := report.SuiteDescription
+	if len(report.SuiteLabels) > 0 {
+		name = name + " [" + strings.Join(report.SuiteLabels, ", ") + "]"
+	}
+	suite := &tapNode{name: name, children: root.children}
+
+	out := &strings.Builder{}
+	fmt.Fprintln(out, tapVersionLine)
+	writeTAPTestPoint(out, 0, 1, suite, !report.SuiteSucceeded, suiteDiagnostics(report))
+	fmt.Fprintln(out, "1..1")
+
+	if _, err := f.WriteString(out.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+/*
+MergeAndCleanupTAPReports produces a single TAP report at the passed-in destination by merging the TAP reports provided in sources.
+Each source's suite becomes a top-level subtest of the merged report.  It skips over reports that fail to open but reports on them via the returned messages []string.
+*/
+func MergeAndCleanupTAPReports(sources []string, dst string) ([]string, error) {
+	messages := []string{}
+	out := &strings.Builder{}
+	fmt.Fprintln(out, tapVersionLine)
+	count := 0
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("Could not open %s:\n%s", source, err.Error()))
+			continue
+		}
+		os.Remove(source)
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			if line == tapVersionLine || tapTopLevelPlanRegExp.MatchString(line) {
+				continue
+			}
+			if match := tapTopLevelTestPointRegExp.FindStringSubmatchIndex(line); match != nil {
+				count += 1
+				line = fmt.Sprintf("%s %d%s", line[match[2]:match[3]], count, line[match[1]:])
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+	fmt.Fprintf(out, "1..%d\n", count)
+	return messages, os.WriteFile(dst, []byte(out.String()), 0666)
+}
+
+func writeTAPTestPoint(out *strings.Builder, indent int, number int, node *tapNode, failed bool, diagnostics [][2]string) {
+	pad := strings.Repeat("    ", indent)
+	if node.spec == nil {
+		fmt.Fprintf(out, "%s# Subtest: %s\n", pad, tapEscape(node.name))
+		for i, child := range node.children {
+			writeTAPTestPoint(out, indent+1, i+1, child, child.failed(), specDiagnostics(child))
+		}
+		fmt.Fprintf(out, "%s    1..%d\n", pad, len(node.children))
+	}
+
+	status := "ok"
+	if failed {
+		status = "not ok"
+	}
+	fmt.Fprintf(out, "%s%s %d - %s%s\n", pad, status, number, tapEscape(node.name), tapDirective(node))
+	if len(diagnostics) > 0 {
+		fmt.Fprintf(out, "%s  ---\n", pad)
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintf(out, "%s  %s: %s\n", pad, diagnostic[0], tapYAMLValue(pad+"    ", diagnostic[1]))
+		}
+		fmt.Fprintf(out, "%s  ...\n", pad)
+	}
+}
+
+func tapDirective(node *tapNode) string {
+	if node.spec == nil {
+		return ""
+	}
+	switch node.spec.State {
+	case types.SpecStatePending:
+		return " # SKIP pending"
+	case types.SpecStateSkipped:
+		message := "skipped"
+		if node.spec.Failure.Message != "" {
+			message += " - " + node.spec.Failure.Message
+		}
+		return " # SKIP " + tapEscape(firstLine(message))
+	case types.SpecStateQuarantined:
+		message := "quarantined"
+		if node.spec.QuarantineReason != "" {
+			message += " - " + node.spec.QuarantineReason
+		}
+		return " # TODO " + tapEscape(firstLine(message))
+	}
+	return ""
+}
+
+func suiteDiagnostics(report types.Report) [][2]string {
+	diagnostics := [][2]string{
+		{"path", report.SuitePath},
+		{"duration_ms", fmt.Sprintf("%.3f", report.RunTime.Seconds()*1000.0)},
+	}
+	if len(report.SpecialSuiteFailureReasons) > 0 {
+		diagnostics = append(diagnostics, [2]string{"message", strings.Join(report.SpecialSuiteFailureReasons, "\n")})
+	}
+	return diagnostics
+}
+
+func specDiagnostics(node *tapNode) [][2]string {
+	if node.spec == nil {
+		return nil
+	}
+	spec := node.spec
+	diagnostics := [][2]string{
+		{"state", spec.State.String()},
+		{"location", spec.LeafNodeLocation.String()},
+		{"duration_ms", fmt.Sprintf("%.3f", spec.RunTime.Seconds()*1000.0)},
+	}
+	if labels := spec.Labels(); len(labels) > 0 {
+		diagnostics = append(diagnostics, [2]string{"labels", "[" + strings.Join(tapQuoteAll(labels), ", ") + "]"})
+	}
+	if spec.NumAttempts > 1 {
+		diagnostics = append(diagnostics, [2]string{"attempts", fmt.Sprintf("%d", spec.NumAttempts)})
+	}
+	if spec.Failed() || spec.State.Is(types.SpecStateQuarantined) {
+		diagnostics = append(diagnostics,
+			[2]string{"message", spec.Failure.Message},
+			[2]string{"at", spec.Failure.Location.String()},
+			[2]string{"failure_node", spec.Failure.FailureNodeType.String()},
+		)
+		if spec.Failure.ForwardedPanic != "" {
+			diagnostics = append(diagnostics, [2]string{"panic", spec.Failure.ForwardedPanic})
+		}
+	}
+	return diagnostics
+}
+
+// tapYAMLValue renders multi-line values as YAML literal blocks and everything else as-is (for numbers and flow sequences) or double-quoted
+func tapYAMLValue(blockIndent string, value string) string {
+	if strings.HasPrefix(value, "[") || isTAPNumber(value) {
+		return value
+	}
+	if strings.Contains(value, "\n") {
+		lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+		return "|-\n" + blockIndent + strings.Join(lines, "\n"+blockIndent)
+	}
+	return tapQuote(value)
+}
+
+func isTAPNumber(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func tapQuote(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
+
+func tapQuoteAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = tapQuote(value)
+	}
+	return out
+}
+
+// tapEscape escapes characters that TAP would otherwise interpret as directives
+func tapEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "#", "\\#")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
@@ -0,0 +1,166 @@
+package reporters_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+var _ = Describe("TAPReport", func() {
+	var report types.Report
+
+	BeforeEach(func() {
+		quarantined := S(types.NodeTypeIt, CTS("A"), CLS(cl0), "Q", cl1, types.SpecStateQuarantined,
+			F("flaky failure", cl2, types.FailureNodeIsLeafNode, FailureNodeLocation(cl1), types.NodeTypeIt),
+		)
+		quarantined.IsQuarantined = true
+		quarantined.QuarantineReason = "tracked in #42"
+
+		report = types.Report{
+			SuiteDescription: "My Suite",
+			SuitePath:        "/path/to/suite",
+			SuiteLabels:      []string{"suite-label"},
+			RunTime:          time.Minute,
+			SpecReports: types.SpecReports{
+				S(types.NodeTypeBeforeSuite, cl0, types.SpecStatePassed),
+				S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "C", cl2, Label("cat", "dog"), types.SpecStateFailed,
+					F("failure\nmessage", cl3, types.FailureNodeIsLeafNode, FailureNodeLocation(cl2), types.NodeTypeIt, ForwardedPanic("the panic!")),
+				),
+				S(types.NodeTypeIt, CTS("A", "B"), CLS(cl0, cl1), "D #1", cl3, 2, FlakeAttempts(3)),
+				S(types.NodeTypeIt, CTS("A"), CLS(cl0), "E", cl4, types.SpecStatePending),
+				quarantined,
+				S(types.NodeTypeIt, "F", cl1, types.SpecStateSkipped, F("skipped by focus")),
+			},
+		}
+	})
+
+	It("renders the suite as a subtest, containers as nested subtests, and specs as test points with YAML diagnostics", func() {
+		fname := fmt.Sprintf("./report-%d.tap", GinkgoParallelProcess())
+		Ω(reporters.GenerateTAPReport(report, fname)).Should(Succeed())
+		DeferCleanup(os.Remove, fname)
+
+		content, err := os.ReadFile(fname)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(MatchLines(
+			"TAP version 14",
+			"# Subtest: My Suite [suite-label]",
+			"    ok 1 - [BeforeSuite]",
+			"      ---",
+			`      state: "passed"`,
+			`      location: "cl0.go:12"`,
+			"      duration_ms: 1000.000",
+			"      ...",
+			"    # Subtest: A",
+			"        # Subtest: B",
+			"            not ok 1 - C",
+			"              ---",
+			`              state: "failed"`,
+			`              location: "cl2.go:80"`,
+			"              duration_ms: 1000.000",
+			`              labels: ["cat", "dog"]`,
+			"              message: |-",
+			"                failure",
+			"                message",
+			`              at: "cl3.go:103"`,
+			`              failure_node: "It"`,
+			`              panic: "the panic!"`,
+			"              ...",
+			`            ok 2 - D \#1`,
+			"              ---",
+			`              state: "passed"`,
+			`              location: "cl3.go:103"`,
+			"              duration_ms: 1000.000",
+			"              attempts: 2",
+			"              ...",
+			"            1..2",
+			"        not ok 1 - B",
+			"        ok 2 - E # SKIP pending",
+			"          ---",
+			`          state: "pending"`,
+			`          location: "cl4.go:144"`,
+			"          duration_ms: 1000.000",
+			"          ...",
+			`        ok 3 - Q # TODO quarantined - tracked in \#42`,
+			"          ---",
+			`          state: "quarantined"`,
+			`          location: "cl1.go:37"`,
+			"          duration_ms: 1000.000",
+			`          message: "flaky failure"`,
+			`          at: "cl2.go:80"`,
+			`          failure_node: "It"`,
+			"          ...",
+			"        1..3",
+			"    not ok 2 - A",
+			"    ok 3 - F # SKIP skipped - skipped by focus",
+			"      ---",
+			`      state: "skipped"`,
+			`      location: "cl1.go:37"`,
+			"      duration_ms: 1000.000",
+			"      ...",
+			"    1..3",
+			"not ok 1 - My Suite [suite-label]",
+			"  ---",
+			`  path: "/path/to/suite"`,
+			"  duration_ms: 60000.000",
+			"  ...",
+			"1..1",
+			"",
+		))
+	})
+
+	It("marks the suite as ok when the suite succeeds", func() {
+		report.SuiteSucceeded = true
+		report.SpecReports = types.SpecReports{S(types.NodeTypeIt, "A", cl0)}
+
+		fname := fmt.Sprintf("./report-%d.tap", GinkgoParallelProcess())
+		Ω(reporters.GenerateTAPReport(report, fname)).Should(Succeed())
+		DeferCleanup(os.Remove, fname)
+
+		content, err := os.ReadFile(fname)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(content)).Should(ContainSubstring("    ok 1 - A\n"))
+		Ω(string(content)).Should(ContainSubstring("    1..1\nok 1 - My Suite [suite-label]\n"))
+	})
+
+	Describe("merging reports", func() {
+		It("renumbers each suite as a top-level test point and cleans up the sources", func() {
+			sources := []string{}
+			for i, description := range []string{"Suite A", "Suite B"} {
+				report.SuiteDescription = description
+				report.SuiteLabels = nil
+				report.SuiteSucceeded = i == 0
+				report.SpecReports = types.SpecReports{S(types.NodeTypeIt, "A", cl0)}
+				fname := fmt.Sprintf("./report-%d-%d.tap", GinkgoParallelProcess(), i)
+				Ω(reporters.GenerateTAPReport(report, fname)).Should(Succeed())
+				sources = append(sources, fname)
+			}
+			sources = append(sources, "./does-not-exist.tap")
+
+			dst := fmt.Sprintf("./merged-%d.tap", GinkgoParallelProcess())
+			messages, err := reporters.MergeAndCleanupTAPReports(sources, dst)
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.Remove, dst)
+			Ω(messages).Should(HaveLen(1))
+			Ω(messages[0]).Should(ContainSubstring("Could not open ./does-not-exist.tap"))
+			Ω(sources[0]).ShouldNot(BeAnExistingFile())
+			Ω(sources[1]).ShouldNot(BeAnExistingFile())
+
+			content, err := os.ReadFile(dst)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(HavePrefix("TAP version 14\n# Subtest: Suite A\n"))
+			Ω(string(content)).Should(ContainSubstring("    ok 1 - A\n"))
+			Ω(string(content)).Should(ContainSubstring("\nok 1 - Suite A\n"))
+			Ω(string(content)).Should(ContainSubstring("\n# Subtest: Suite B\n"))
+			Ω(string(content)).Should(ContainSubstring("\nnot ok 2 - Suite B\n"))
+			Ω(string(content)).Should(HaveSuffix("\n1..2\n"))
+			Ω(string(content)).ShouldNot(ContainSubstring("\n1..1\n"))
+			Ω(string(content)).ShouldNot(ContainSubstring("\nTAP version 14"))
+		})
+	})
+})
@@ -59,6 +59,15 @@ func GenerateTeamcityReport(report types.Report, dst string) error {
 				message += " - " + spec.Failure.Message
 			}
 			fmt.Fprintf(f, "##teamcity[testIgnored name='%s' message='%s']\n", name, tcEscape(message))
+		case types.SpecStateQuarantined:
+			message := "quarantined"
+			if spec.QuarantineReason != "" {
+				message += " - " + spec.QuarantineReason
+			}
+			if spec.Failure.Message != "" {
+				message += " - " + spec.Failure.Message
+			}
+			fmt.Fprintf(f, "##teamcity[testIgnored name='%s' message='%s']\n", name, tcEscape(message))
 		case types.SpecStateFailed:
 			details := failureDescriptionForUnstructuredReporters(spec)
 			fmt.Fprintf(f, "##teamcity[testFailed name='%s' message='failed - %s' details='%s']\n", name, tcEscape(spec.Failure.Message), tcEscape(details))
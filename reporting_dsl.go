@@ -128,7 +128,7 @@ ReportAftersuite nodes must be created at the top-level (i.e. not nested in a Co
 When running in parallel, Ginkgo ensures that only one of the parallel nodes runs the ReportAfterSuite and that it is passed a report that is aggregated across
 all parallel nodes
 
-In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, JUnit, and Teamcity formatted reports using the --json-report, --junit-report, and --teamcity-report ginkgo CLI flags.
+In addition to using ReportAfterSuite to programmatically generate suite reports, you can also generate JSON, JUnit, Teamcity, and TAP formatted reports using the --json-report, --junit-report, --teamcity-report, and --tap-report ginkgo CLI flags.
 
 You cannot nest any other Ginkgo nodes within a ReportAfterSuite node's closure.
 You can learn more about ReportAfterSuite here: https://onsi.github.io/ginkgo/#generating-reports-programmatically
@@ -161,6 +161,12 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 				Fail(fmt.Sprintf("Failed to generate Teamcity report:\n%s", err.Error()))
 			}
 		}
+		if reporterConfig.TAPReport != "" {
+			err := reporters.GenerateTAPReport(report, reporterConfig.TAPReport)
+			if err != nil {
+				Fail(fmt.Sprintf("Failed to generate TAP report:\n%s", err.Error()))
+			}
+		}
 	}
 
 	flags := []string{}
@@ -173,6 +179,9 @@ func registerReportAfterSuiteNodeForAutogeneratedReports(reporterConfig types.Re
 	if reporterConfig.TeamcityReport != "" {
 		flags = append(flags, "--teamcity-report")
 	}
+	if reporterConfig.TAPReport != "" {
+		flags = append(flags, "--tap-report")
+	}
 	pushNode(internal.NewNode(
 		deprecationTracker, types.NodeTypeReportAfterSuite,
 		fmt.Sprintf("Autogenerated ReportAfterSuite for %s", strings.Join(flags, " ")),
@@ -1,10 +1,18 @@
 package ginkgo
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/onsi/ginkgo/v2/internal"
 	"github.com/onsi/ginkgo/v2/types"
@@ -83,6 +91,7 @@ type TableEntry struct {
 	decorations  []any
 	parameters   []any
 	codeLocation types.CodeLocation
+	err          error
 }
 
 /*
@@ -128,6 +137,30 @@ You can mark a particular entry as pending with XEntry.  This is equivalent to X
 */
 var XEntry = PEntry
 
+/*
+TableEntrySource loads table entries from a data file.  You generally use the `EntriesFromFile` constructor.
+*/
+type TableEntrySource struct {
+	path         string
+	decorations  []any
+	codeLocation types.CodeLocation
+}
+
+/*
+EntriesFromFile constructs a TableEntrySource that generates one Entry per row in the JSON or CSV file at path.  Relative paths are resolved relative to the suite's directory.
+
+JSON files must contain an array of rows.  Each row is either an array of parameters or an object of the form {"description": "...", "parameters": [...]}.
+CSV files must begin with a header row.  A column named "description" provides the Entry description; all other columns are passed, in order, as the Entry parameters.
+
+Parameters are decoded into the types expected by the Table Body function.  Rows without a description use the table-level entry description.  Any decorators passed to EntriesFromFile are applied to every generated Entry and the code location of each Entry points at its row in the data file.
+
+You can learn more about EntriesFromFile here: https://onsi.github.io/ginkgo/#loading-table-entries-from-files
+*/
+func EntriesFromFile(path string, decorators ...any) TableEntrySource {
+	GinkgoHelper()
+	return TableEntrySource{path: path, decorations: decorators, codeLocation: types.NewCodeLocation(0)}
+}
+
 var contextType = reflect.TypeOf(new(context.Context)).Elem()
 var specContextType = reflect.TypeOf(new(SpecContext)).Elem()
 
@@ -137,6 +170,7 @@ func generateTable(description string, args ...any) {
 	containerNodeArgs := []any{cl}
 
 	entries := []TableEntry{}
+	entrySources := map[int][]TableEntrySource{}
 	var itBody any
 	var itBodyType reflect.Type
 
@@ -161,6 +195,8 @@ func generateTable(description string, args ...any) {
 			entries = append(entries, arg.(TableEntry))
 		case t == reflect.TypeOf([]TableEntry{}):
 			entries = append(entries, arg.([]TableEntry)...)
+		case t == reflect.TypeOf(TableEntrySource{}):
+			entrySources[len(entries)] = append(entrySources[len(entries)], arg.(TableEntrySource))
 		case t == reflect.TypeOf(EntryDescription("")):
 			tableLevelEntryDescription = arg.(EntryDescription).render
 		case t.Kind() == reflect.Func && t.NumOut() == 1 && t.Out(0) == reflect.TypeOf(""):
@@ -177,6 +213,9 @@ func generateTable(description string, args ...any) {
 	}
 
 	containerNodeArgs = append(containerNodeArgs, func() {
+		if len(entrySources) > 0 {
+			entries = expandTableEntrySources(entries, entrySources, itBodyType)
+		}
 		for _, entry := range entries {
 			var err error
 			entry := entry
@@ -199,6 +238,9 @@ func generateTable(description string, args ...any) {
 			default:
 				err = types.GinkgoErrors.InvalidEntryDescription(entry.codeLocation)
 			}
+			if entry.err != nil {
+				err = entry.err
+			}
 
 			itNodeArgs := []any{entry.codeLocation}
 			itNodeArgs = append(itNodeArgs, entry.decorations...)
@@ -307,3 +349,485 @@ func computeValue(parameter any, t reflect.Type) reflect.Value {
 		return reflect.ValueOf(parameter)
 	}
 }
+
+var anyType = reflect.TypeOf(new(any)).Elem()
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// expandTableEntrySources loads the entries in each source and splices them in at the position the source was passed to the table
+func expandTableEntrySources(entries []TableEntry, entrySources map[int][]TableEntrySource, itBodyType reflect.Type) []TableEntry {
+	out := []TableEntry{}
+	for i := 0; i <= len(entries); i++ {
+		for _, source := range entrySources[i] {
+			loaded, err := source.load(itBodyType)
+			exitIfErr(err)
+			out = append(out, loaded...)
+		}
+		if i < len(entries) {
+			out = append(out, entries[i])
+		}
+	}
+	return out
+}
+
+type tableEntryValue interface {
+	decode(t reflect.Type) (any, error)
+}
+
+type tableEntryRow struct {
+	line        int
+	description any
+	values      []tableEntryValue
+}
+
+func (source TableEntrySource) load(itBodyType reflect.Type) ([]TableEntry, error) {
+	path, err := filepath.Abs(source.path)
+	if err != nil {
+		return nil, types.GinkgoErrors.InvalidTableEntriesFile(source.path, err, source.codeLocation)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, types.GinkgoErrors.InvalidTableEntriesFile(source.path, err, source.codeLocation)
+	}
+
+	var rows []tableEntryRow
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		rows, err = parseJSONTableEntryRows(data)
+	case ".csv":
+		rows, err = parseCSVTableEntryRows(data)
+	default:
+		err = fmt.Errorf("unsupported file extension %q - use .json or .csv", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, types.GinkgoErrors.InvalidTableEntriesFile(source.path, err, source.codeLocation)
+	}
+
+	var parameterTypes []reflect.Type
+	var variadicType reflect.Type
+	if itBodyType != nil {
+		for i := 0; i < itBodyType.NumIn(); i++ {
+			parameterTypes = append(parameterTypes, itBodyType.In(i))
+		}
+		if itBodyType.IsVariadic() {
+			variadicType = parameterTypes[len(parameterTypes)-1].Elem()
+			parameterTypes = parameterTypes[:len(parameterTypes)-1]
+		}
+		if len(parameterTypes) > 0 && (parameterTypes[0].Implements(specContextType) || parameterTypes[0].Implements(contextType)) {
+			parameterTypes = parameterTypes[1:]
+		}
+	}
+
+	entries := []TableEntry{}
+	for _, row := range rows {
+		entry := TableEntry{
+			description:  row.description,
+			decorations:  source.decorations,
+			codeLocation: types.CodeLocation{FileName: path, LineNumber: row.line},
+		}
+		for i, value := range row.values {
+			// values that don't line up with the body's parameters are decoded loosely so that validateParameters can report the mismatch
+			t := anyType
+			if i < len(parameterTypes) {
+				t = parameterTypes[i]
+			} else if variadicType != nil {
+				t = variadicType
+			}
+			parameter, err := value.decode(t)
+			if err != nil {
+				entry.err = types.GinkgoErrors.InvalidTableEntryParameter(i+1, t, err, entry.codeLocation)
+				break
+			}
+			entry.parameters = append(entry.parameters, parameter)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+type jsonTableEntryValue json.RawMessage
+
+func (v jsonTableEntryValue) decode(t reflect.Type) (any, error) {
+	out := reflect.New(t)
+	if err := json.Unmarshal(v, out.Interface()); err != nil {
+		return nil, err
+	}
+	return out.Elem().Interface(), nil
+}
+
+func parseJSONTableEntryRows(data []byte) ([]tableEntryRow, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("expected a JSON array of rows")
+	}
+	rows := []tableEntryRow{}
+	for decoder.More() {
+		start := int(decoder.InputOffset())
+		for start < len(data) && strings.IndexByte(" \t\r\n,", data[start]) >= 0 {
+			start++
+		}
+		row := tableEntryRow{line: bytes.Count(data[:start], []byte("\n")) + 1}
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.line, err)
+		}
+		var parameters []json.RawMessage
+		if bytes.HasPrefix(raw, []byte("{")) {
+			var object struct {
+				Description *string           `json:"description"`
+				Parameters  []json.RawMessage `json:"parameters"`
+			}
+			objectDecoder := json.NewDecoder(bytes.NewReader(raw))
+			objectDecoder.DisallowUnknownFields()
+			if err := objectDecoder.Decode(&object); err != nil {
+				return nil, fmt.Errorf("line %d: %w", row.line, err)
+			}
+			if object.Description != nil {
+				row.description = *object.Description
+			}
+			parameters = object.Parameters
+		} else if err := json.Unmarshal(raw, &parameters); err != nil {
+			return nil, fmt.Errorf("line %d: rows must be arrays of parameters or objects with a description and parameters", row.line)
+		}
+		for _, parameter := range parameters {
+			row.values = append(row.values, jsonTableEntryValue(parameter))
+		}
+		rows = append(rows, row)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+type csvTableEntryValue string
+
+func (v csvTableEntryValue) decode(t reflect.Type) (any, error) {
+	s := string(v)
+	if s == "" {
+		return reflect.Zero(t).Interface(), nil
+	}
+	if t.Kind() == reflect.Interface && reflect.TypeOf(s).Implements(t) {
+		return s, nil
+	}
+	out := reflect.New(t).Elem()
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		out.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(s)
+			out.SetInt(int64(d))
+			break
+		}
+		var n int64
+		n, err = strconv.ParseInt(s, 0, t.Bits())
+		out.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 0, t.Bits())
+		out.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, t.Bits())
+		out.SetFloat(f)
+	default:
+		err = json.Unmarshal([]byte(s), out.Addr().Interface())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+func parseCSVTableEntryRows(data []byte) ([]tableEntryRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("expected a header row")
+	} else if err != nil {
+		return nil, err
+	}
+	descriptionColumn := -1
+	for i, column := range header {
+		if strings.TrimSpace(column) == "description" {
+			descriptionColumn = i
+		}
+	}
+
+	rows := []tableEntryRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		row := tableEntryRow{line: line}
+		for i, cell := range record {
+			if i == descriptionColumn {
+				if cell != "" {
+					row.description = cell
+				}
+				continue
+			}
+			row.values = append(row.values, csvTableEntryValue(cell))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+/*
+TableBodyT constrains the body functions accepted by DescribeTableT.  The body receives the entry's parameters and can optionally accept a SpecContext or context.Context as its first argument to generate interruptible specs.
+*/
+type TableBodyT[P any] interface {
+	func(P) | func(SpecContext, P) | func(context.Context, P)
+}
+
+/*
+DescribeTableT describes a table-driven spec whose entries are checked by the compiler.
+
+Each entry carries a single parameter of type P (typically a struct) that is passed to the table body:
+
+	type addition struct {
+	    a, b, expected int
+	}
+
+	DescribeTableT("adding numbers",
+	    func(c addition) {
+	        Ω(c.a + c.b).Should(Equal(c.expected))
+	    },
+	    EntryT("positive numbers", addition{1, 2, 3}),
+	    EntryT(EntryDescription("%d + %d = %d"), addition{-1, -2, -3}),
+	    EntryT(nil, addition{0, 0, 0}, Label("zero")),
+	)
+
+Unlike DescribeTable, passing an entry whose parameter does not match the body is a compile-time error.  Decorate individual entries via EntryT.  To decorate the table as a whole, or to provide a table-level entry description, pass a TableT in place of the description.
+
+You can learn more about DescribeTableT here: https://onsi.github.io/ginkgo/#typed-table-specs
+*/
+func DescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	GinkgoHelper()
+	generateTableT(description, body, entries, nil)
+	return true
+}
+
+/*
+You can focus a typed table with `FDescribeTableT`.  This is equivalent to `FDescribe`.
+*/
+func FDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	GinkgoHelper()
+	generateTableT(description, body, entries, []any{internal.Focus})
+	return true
+}
+
+/*
+You can mark a typed table as pending with `PDescribeTableT`.  This is equivalent to `PDescribe`.
+*/
+func PDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	GinkgoHelper()
+	generateTableT(description, body, entries, []any{internal.Pending})
+	return true
+}
+
+/*
+You can mark a typed table as pending with `XDescribeTableT`.  This is equivalent to `XDescribe`.
+*/
+func XDescribeTableT[P any, B TableBodyT[P]](description any, body B, entries ...TableEntryT[P]) bool {
+	GinkgoHelper()
+	generateTableT(description, body, entries, []any{internal.Pending})
+	return true
+}
+
+/*
+TableDescriptionT describes a typed table along with its table-level decorators and entry description.  You generally use the `TableT` constructor.
+*/
+type TableDescriptionT struct {
+	description string
+	args        []any
+}
+
+/*
+TableT decorates a typed table as a whole.  Pass it to DescribeTableT in place of the table's description:
+
+	DescribeTableT(TableT("adding numbers", Label("math"), EntryDescription("%d + %d = %d")),
+	    func(c addition) {
+	        Ω(c.a + c.b).Should(Equal(c.expected))
+	    },
+	    EntryT(nil, addition{1, 2, 3}),
+	    EntryT("negative numbers", addition{-1, -2, -3}),
+	)
+
+TableT accepts the same table-level arguments as DescribeTable: any Ginkgo decorators (e.g. Label, Ordered, Focus, Pending) are applied to the table's container and an EntryDescription (or a function that accepts P and returns a string) describes the entries that are passed a nil description.
+
+You can learn more about TableT here: https://onsi.github.io/ginkgo/#typed-table-specs
+*/
+func TableT(description string, args ...any) TableDescriptionT {
+	return TableDescriptionT{description: description, args: args}
+}
+
+/*
+TableEntryT represents an entry in a typed table test.  You generally use the `EntryT` constructor.
+*/
+type TableEntryT[P any] struct {
+	description  any
+	decorations  []any
+	parameters   P
+	codeLocation types.CodeLocation
+}
+
+/*
+EntryT constructs a TableEntryT for use with DescribeTableT.
+
+The first argument is a description.  This can be a string, a function that accepts P and returns a string, an EntryDescription format string, or nil.  When an EntryDescription is used and P is a struct, the struct's fields are passed to the format string in order - otherwise P itself is.  If nil is provided the description is generated from the parameters.
+
+The second argument is the entry's parameters and subsequent arguments accept any Ginkgo decorators.
+
+You can learn more about EntryT here: https://onsi.github.io/ginkgo/#typed-table-specs
+*/
+func EntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	GinkgoHelper()
+	return TableEntryT[P]{description: description, decorations: decorators, parameters: parameters, codeLocation: types.NewCodeLocation(0)}
+}
+
+/*
+You can focus a particular typed entry with FEntryT.  This is equivalent to FIt.
+*/
+func FEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	GinkgoHelper()
+	decorators = append(decorators, internal.Focus)
+	return TableEntryT[P]{description: description, decorations: decorators, parameters: parameters, codeLocation: types.NewCodeLocation(0)}
+}
+
+/*
+You can mark a particular typed entry as pending with PEntryT.  This is equivalent to PIt.
+*/
+func PEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	GinkgoHelper()
+	decorators = append(decorators, internal.Pending)
+	return TableEntryT[P]{description: description, decorations: decorators, parameters: parameters, codeLocation: types.NewCodeLocation(0)}
+}
+
+/*
+You can mark a particular typed entry as pending with XEntryT.  This is equivalent to XIt.
+*/
+func XEntryT[P any](description any, parameters P, decorators ...any) TableEntryT[P] {
+	GinkgoHelper()
+	decorators = append(decorators, internal.Pending)
+	return TableEntryT[P]{description: description, decorations: decorators, parameters: parameters, codeLocation: types.NewCodeLocation(0)}
+}
+
+func generateTableT[P any, B TableBodyT[P]](description any, body B, entries []TableEntryT[P], decorations []any) {
+	GinkgoHelper()
+	cl := types.NewCodeLocation(0)
+	containerNodeArgs := []any{cl}
+
+	var tableDescription string
+	var tableLevelEntryDescription any
+	switch d := description.(type) {
+	case string:
+		tableDescription = d
+	case TableDescriptionT:
+		tableDescription = d.description
+		for i, arg := range d.args {
+			switch t := arg.(type) {
+			case nil:
+				exitIfErr(types.GinkgoErrors.IncorrectParameterTypeForTable(i+1, "nil", cl))
+			case EntryDescription, func(P) string:
+				tableLevelEntryDescription = t
+			default:
+				if reflect.TypeOf(arg).Kind() == reflect.Func {
+					exitIfErr(types.GinkgoErrors.IncorrectParameterTypeForTable(i+1, reflect.TypeOf(arg).String(), cl))
+				}
+				containerNodeArgs = append(containerNodeArgs, arg)
+			}
+		}
+	default:
+		exitIfErr(types.GinkgoErrors.IncorrectParameterTypeForTable(0, reflect.TypeOf(description).String(), cl))
+	}
+	containerNodeArgs = append(containerNodeArgs, decorations...)
+
+	containerNodeArgs = append(containerNodeArgs, func() {
+		for _, entry := range entries {
+			var err error
+			entry := entry
+			entryDescription := entry.description
+			if entryDescription == nil {
+				entryDescription = tableLevelEntryDescription
+			}
+			var description string
+			switch d := entryDescription.(type) {
+			case nil:
+				description = "Entry: " + strings.Join(entryParameterStrings(entry.parameters), ", ")
+			case EntryDescription:
+				description = d.render(entryParameters(entry.parameters)...)
+			case string:
+				description = d
+			case func(P) string:
+				description = d(entry.parameters)
+			default:
+				err = types.GinkgoErrors.InvalidEntryDescription(entry.codeLocation)
+			}
+
+			itNodeArgs := []any{entry.codeLocation}
+			itNodeArgs = append(itNodeArgs, entry.decorations...)
+
+			switch b := any(body).(type) {
+			case func(P):
+				itNodeArgs = append(itNodeArgs, func() {
+					if err != nil {
+						panic(err)
+					}
+					b(entry.parameters)
+				})
+			case func(SpecContext, P):
+				itNodeArgs = append(itNodeArgs, func(c SpecContext) {
+					if err != nil {
+						panic(err)
+					}
+					b(c, entry.parameters)
+				})
+			case func(context.Context, P):
+				itNodeArgs = append(itNodeArgs, func(c SpecContext) {
+					if err != nil {
+						panic(err)
+					}
+					b(c, entry.parameters)
+				})
+			}
+
+			pushNode(internal.NewNode(deprecationTracker, types.NodeTypeIt, description, itNodeArgs...))
+		}
+	})
+
+	pushNode(internal.NewNode(deprecationTracker, types.NodeTypeContainer, tableDescription, containerNodeArgs...))
+}
+
+// entryParameters spreads struct parameters into their fields so they can be rendered by EntryDescription format strings
+func entryParameters(parameters any) []any {
+	v := reflect.ValueOf(parameters)
+	if v.Kind() != reflect.Struct {
+		return []any{parameters}
+	}
+	out := make([]any, v.NumField())
+	for i := range out {
+		out[i] = v.Field(i)
+	}
+	return out
+}
+
+func entryParameterStrings(parameters any) []string {
+	out := []string{}
+	for _, parameter := range entryParameters(parameters) {
+		out = append(out, fmt.Sprint(parameter))
+	}
+	return out
+}
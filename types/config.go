@@ -7,6 +7,7 @@ package types
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -23,10 +24,13 @@ type SuiteConfig struct {
 	SkipStrings           []string
 	FocusFiles            []string
 	SkipFiles             []string
+	FocusSpecs            []string
 	LabelFilter           string
+	SemVerFilter          string
 	FailOnPending         bool
 	FailFast              bool
 	FlakeAttempts         int
+	PropertyIterations    int
 	DryRun                bool
 	PollProgressAfter     time.Duration
 	PollProgressInterval  time.Duration
@@ -35,6 +39,14 @@ type SuiteConfig struct {
 	OutputInterceptorMode string
 	SourceRoots           []string
 	GracePeriod           time.Duration
+	DurationReport        string
+	Shard                 string
+	Concurrency           int
+	QuarantineFile        string
+
+	MeasurementBaseline     string
+	SaveMeasurementBaseline string
+	MeasurementTolerance    float64
 
 	ParallelProcess int
 	ParallelTotal   int
@@ -88,10 +100,15 @@ type ReporterConfig struct {
 	VeryVerbose    bool
 	FullTrace      bool
 	ShowNodeEvents bool
+	GithubOutput   bool
+	GoTestJSON     bool
 
 	JSONReport     string
 	JUnitReport    string
 	TeamcityReport string
+	TAPReport      string
+
+	JSONStream string
 }
 
 func (rc ReporterConfig) Verbosity() VerbosityLevel {
@@ -106,7 +123,7 @@ func (rc ReporterConfig) Verbosity() VerbosityLevel {
 }
 
 func (rc ReporterConfig) WillGenerateReport() bool {
-	return rc.JSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != ""
+	return rc.JSONReport != "" || rc.JUnitReport != "" || rc.TeamcityReport != "" || rc.TAPReport != ""
 }
 
 func NewDefaultReporterConfig() ReporterConfig {
@@ -124,20 +141,30 @@ type CLIConfig struct {
 	//for run and watch only
 	Procs                     int
 	Parallel                  bool
+	ParallelTransport         string
 	AfterRunHook              string
 	OutputDir                 string
 	KeepSeparateCoverprofiles bool
 	KeepSeparateReports       bool
+	History                   bool
 
 	//for run only
 	KeepGoing       bool
 	UntilItFails    bool
 	Repeat          int
 	RandomizeSuites bool
+	RerunFailed     string
+	Serve           string
+	RemoteProcs     int
+
+	//for worker only
+	Connect string
 
 	//for watch only
-	Depth       int
-	WatchRegExp string
+	Depth          int
+	WatchRegExp    string
+	Interactive    bool
+	ImpactAnalysis bool
 }
 
 func NewDefaultCLIConfig() CLIConfig {
@@ -266,6 +293,15 @@ var SuiteConfigFlags = GinkgoFlags{
 		Usage: "The seed used to randomize the spec suite."},
 	{KeyPath: "S.RandomizeAllSpecs", Name: "randomize-all", SectionKey: "order", DeprecatedName: "randomizeAllSpecs", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will randomize all specs together.  By default, ginkgo only randomizes the top level Describe, Context and When containers."},
+	{KeyPath: "S.PropertyIterations", Name: "property-iterations", SectionKey: "order", UsageDefaultValue: "0 - use the PropertyIterations decorator or 100",
+		Usage: "If set, ginkgo will run each ItForAll property-based spec against this many randomly generated inputs.  Overrides the PropertyIterations decorator.  Inputs are derived from --seed so failures can be reproduced."},
+	{KeyPath: "S.DurationReport", Name: "order-by-duration", SectionKey: "order", UsageArgument: "filename.json",
+		Usage: "If set, ginkgo will use the spec run times recorded in this JSON report (generated by a previous run via --json-report) to run the longest specs and Ordered containers first.  This helps balance the work across parallel processes.  Specs with no recorded run time are assumed to take the average run time."},
+
+	{KeyPath: "S.Shard", Name: "shard", SectionKey: "parallel", UsageArgument: "i/N",
+		Usage: "If set, ginkgo will split the suite into N disjoint shards and only run the specs in shard i (one-indexed).  Specs are assigned to shards deterministically, independent of the random seed, so running each of 1/N through N/N (e.g. on separate CI machines) runs every spec exactly once."},
+	{KeyPath: "S.Concurrency", Name: "concurrency", SectionKey: "parallel", UsageDefaultValue: "0 - GOMAXPROCS or 4, whichever is larger",
+		Usage: "The maximum number of specs marked Concurrent that each process will run at once.  Concurrent specs run alongside one another on separate goroutines within a single process."},
 
 	{KeyPath: "S.FailOnPending", Name: "fail-on-pending", SectionKey: "failure", DeprecatedName: "failOnPending", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will mark the test suite as failed if any specs are pending."},
@@ -273,6 +309,15 @@ var SuiteConfigFlags = GinkgoFlags{
 		Usage: "If set, ginkgo will stop running a test suite after a failure occurs."},
 	{KeyPath: "S.FlakeAttempts", Name: "flake-attempts", SectionKey: "failure", UsageDefaultValue: "0 - failed tests are not retried", DeprecatedName: "flakeAttempts", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "Make up to this many attempts to run each spec. If any of the attempts succeed, the suite will not be failed."},
+	{KeyPath: "S.QuarantineFile", Name: "quarantine-file", SectionKey: "failure", UsageArgument: "filename.json",
+		Usage: "If set, ginkgo will load a list of known-flaky specs from this JSON file.  Listed specs are either retried (if the entry sets FlakeAttempts) or quarantined: their failures are reported as quarantined and do not fail the suite."},
+
+	{KeyPath: "S.MeasurementBaseline", Name: "measurement-baseline", SectionKey: "failure", UsageArgument: "filename.json",
+		Usage: "If set, ginkgo will compare the median of each Measurement recorded via SampleDuration and SampleValue against the baseline stored in this file and fail the suite if any Measurement regresses by more than --measurement-tolerance."},
+	{KeyPath: "S.SaveMeasurementBaseline", Name: "save-measurement-baseline", SectionKey: "failure", UsageArgument: "filename.json",
+		Usage: "If set, ginkgo will save the Measurements recorded by passing specs to this file so that they can be used as a baseline by future runs via --measurement-baseline."},
+	{KeyPath: "S.MeasurementTolerance", Name: "measurement-tolerance", SectionKey: "failure", UsageDefaultValue: "0 - tolerate a 10 percent increase",
+		Usage: "The fractional increase in a Measurement's median, relative to the --measurement-baseline, that is tolerated before the Measurement is considered to have regressed.  e.g. 0.25 tolerates a 25 percent increase.  For Measurements recorded with HigherIsBetter this is the tolerated decrease."},
 
 	{KeyPath: "S.DryRun", Name: "dry-run", SectionKey: "debug", DeprecatedName: "dryRun", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will walk the test hierarchy without actually running anything.  Best paired with -v."},
@@ -291,6 +336,8 @@ var SuiteConfigFlags = GinkgoFlags{
 
 	{KeyPath: "S.LabelFilter", Name: "label-filter", SectionKey: "filter", UsageArgument: "expression",
 		Usage: "If set, ginkgo will only run specs with labels that match the label-filter.  The passed-in expression can include boolean operations (!, &&, ||, ','), groupings via '()', and regular expressions '/regexp/'.  e.g. '(cat || dog) && !fruit'"},
+	{KeyPath: "S.SemVerFilter", Name: "sem-ver-filter", SectionKey: "filter", UsageArgument: "version",
+		Usage: "If set, ginkgo will only run specs whose SemVerConstraint decorators are satisfied by the passed-in semantic version.  Specs without constraints always run.  e.g. '2.3.1'"},
 	{KeyPath: "S.FocusStrings", Name: "focus", SectionKey: "filter",
 		Usage: "If set, ginkgo will only run specs that match this regular expression. Can be specified multiple times, values are ORed."},
 	{KeyPath: "S.SkipStrings", Name: "skip", SectionKey: "filter",
@@ -299,6 +346,8 @@ var SuiteConfigFlags = GinkgoFlags{
 		Usage: "If set, ginkgo will only run specs in matching files. Can be specified multiple times, values are ORed."},
 	{KeyPath: "S.SkipFiles", Name: "skip-file", SectionKey: "filter", UsageArgument: "file (regexp) | file:line | file:lineA-lineB | file:line,line,line",
 		Usage: "If set, ginkgo will skip specs in matching files. Can be specified multiple times, values are ORed."},
+	{KeyPath: "S.FocusSpecs", Name: "focus-spec", SectionKey: "filter", UsageArgument: `{"FileName": file, "LineNumber": line, "FullText": text}`,
+		Usage: "If set, ginkgo will only run specs with exactly this file, line, and full text.  Can be specified multiple times, values are ORed.  This is generated by --rerun-failed and is rarely set by hand."},
 
 	{KeyPath: "D.RegexScansFilePath", DeprecatedName: "regexScansFilePath", DeprecatedDocLink: "removed--regexscansfilepath", DeprecatedVersion: "2.0.0"},
 	{KeyPath: "D.DebugParallel", DeprecatedName: "debug", DeprecatedDocLink: "removed--debug", DeprecatedVersion: "2.0.0"},
@@ -330,6 +379,10 @@ var ReporterConfigFlags = GinkgoFlags{
 		Usage: "If set, default reporter prints out the full stack trace when a failure occurs"},
 	{KeyPath: "R.ShowNodeEvents", Name: "show-node-events", SectionKey: "output",
 		Usage: "If set, default reporter prints node > Enter and < Exit events when specs fail"},
+	{KeyPath: "R.GithubOutput", Name: "github-output", SectionKey: "output",
+		Usage: "If set, Ginkgo also emits GitHub Actions workflow commands: ::error annotations for failures, ::group:: blocks around spec output, and a Markdown run summary in $GITHUB_STEP_SUMMARY."},
+	{KeyPath: "R.GoTestJSON", Name: "go-test-json", SectionKey: "output",
+		Usage: "If set, Ginkgo emits `go test -json` compatible events in place of its usual console output.  Each spec is reported as a subtest so that tools that understand test2json see individual specs.  Only JSON is written to stdout - all other output goes to stderr."},
 
 	{KeyPath: "R.JSONReport", Name: "json-report", UsageArgument: "filename.json", SectionKey: "output",
 		Usage: "If set, Ginkgo will generate a JSON-formatted test report at the specified location."},
@@ -337,6 +390,10 @@ var ReporterConfigFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo will generate a conformant junit test report in the specified file."},
 	{KeyPath: "R.TeamcityReport", Name: "teamcity-report", UsageArgument: "filename", SectionKey: "output",
 		Usage: "If set, Ginkgo will generate a Teamcity-formatted test report at the specified location."},
+	{KeyPath: "R.TAPReport", Name: "tap-report", UsageArgument: "filename.tap", SectionKey: "output",
+		Usage: "If set, Ginkgo will generate a TAP (version 14) test report at the specified location."},
+	{KeyPath: "R.JSONStream", Name: "json-stream", UsageArgument: "filename", SectionKey: "output",
+		Usage: "If set, Ginkgo will stream newline-delimited JSON events to the specified file (or named pipe) as the suite runs.  Events are appended to the file."},
 
 	{KeyPath: "D.SlowSpecThresholdWithFLoatUnits", DeprecatedName: "slowSpecThreshold", DeprecatedDocLink: "changed--slowspecthreshold",
 		Usage: "use --slow-spec-threshold instead and pass in a duration string (e.g. '5s', not '5.0')"},
@@ -413,6 +470,13 @@ func VetConfig(flagSet GinkgoFlagSet, suiteConfig SuiteConfig, reporterConfig Re
 		}
 	}
 
+	if len(suiteConfig.FocusSpecs) > 0 {
+		_, err := ParseSpecIdentities(suiteConfig.FocusSpecs)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
 	if suiteConfig.LabelFilter != "" {
 		_, err := ParseLabelFilter(suiteConfig.LabelFilter)
 		if err != nil {
@@ -420,6 +484,45 @@ func VetConfig(flagSet GinkgoFlagSet, suiteConfig SuiteConfig, reporterConfig Re
 		}
 	}
 
+	if suiteConfig.SemVerFilter != "" {
+		_, err := ParseSemVerFilter(suiteConfig.SemVerFilter)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.Shard != "" {
+		_, err := ParseShard(suiteConfig.Shard)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.QuarantineFile != "" {
+		_, err := LoadQuarantine(suiteConfig.QuarantineFile)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.MeasurementBaseline != "" {
+		_, err := LoadMeasurementBaseline(suiteConfig.MeasurementBaseline)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if suiteConfig.MeasurementTolerance < 0 {
+		errors = append(errors, GinkgoErrors.InvalidMeasurementTolerance(suiteConfig.MeasurementTolerance))
+	}
+
+	if suiteConfig.DurationReport != "" {
+		_, err := LoadSpecRunTimes(suiteConfig.DurationReport)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
 	switch strings.ToLower(suiteConfig.OutputInterceptorMode) {
 	case "", "dup", "swap", "none":
 	default:
@@ -460,6 +563,8 @@ var GinkgoCLIRunAndWatchFlags = GinkgoFlags{
 		Usage: "--nodes is an alias for --procs"},
 	{KeyPath: "C.Parallel", Name: "p", SectionKey: "parallel",
 		Usage: "If set, ginkgo will run in parallel with an auto-detected number of nodes."},
+	{KeyPath: "C.ParallelTransport", Name: "parallel-transport", SectionKey: "parallel", UsageArgument: "auto, unix, or tcp", UsageDefaultValue: "auto",
+		Usage: "The transport parallel processes use to communicate with the Ginkgo CLI.  unix uses a Unix domain socket, tcp uses a randomly selected port on the loopback interface.  auto prefers a Unix domain socket and falls back to tcp if one can't be created."},
 	{KeyPath: "C.AfterRunHook", Name: "after-run-hook", SectionKey: "misc", DeprecatedName: "afterSuiteHook", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "Command to run when a test suite completes."},
 	{KeyPath: "C.OutputDir", Name: "output-dir", SectionKey: "output", UsageArgument: "directory", DeprecatedName: "outputdir", DeprecatedDocLink: "improved-profiling-support",
@@ -468,6 +573,8 @@ var GinkgoCLIRunAndWatchFlags = GinkgoFlags{
 		Usage: "If set, Ginkgo does not merge coverprofiles into one monolithic coverprofile.  The coverprofiles will remain in their respective package directories or in -output-dir if set."},
 	{KeyPath: "C.KeepSeparateReports", Name: "keep-separate-reports", SectionKey: "output",
 		Usage: "If set, Ginkgo does not merge per-suite reports (e.g. -json-report) into one monolithic report for the entire testrun.  The reports will remain in their respective package directories or in -output-dir if set."},
+	{KeyPath: "C.History", Name: "history", SectionKey: "output",
+		Usage: "If set, Ginkgo appends the outcome of every spec to ginkgo-history.jsonl in -output-dir (or the current directory if -output-dir is not set).  Use ginkgo flakes to analyze the history and identify flaky specs."},
 
 	{KeyPath: "D.Stream", DeprecatedName: "stream", DeprecatedDocLink: "removed--stream", DeprecatedVersion: "2.0.0"},
 	{KeyPath: "D.Notify", DeprecatedName: "notify", DeprecatedDocLink: "removed--notify", DeprecatedVersion: "2.0.0"},
@@ -483,6 +590,18 @@ var GinkgoCLIRunFlags = GinkgoFlags{
 		Usage: "The number of times to re-run a test-suite.  Useful for debugging flaky tests.  If set to N the suite will be run N+1 times and will be required to pass each time."},
 	{KeyPath: "C.RandomizeSuites", Name: "randomize-suites", SectionKey: "order", DeprecatedName: "randomizeSuites", DeprecatedDocLink: "changed-command-line-flags",
 		Usage: "If set, ginkgo will randomize the order in which test suites run."},
+	{KeyPath: "C.RerunFailed", Name: "rerun-failed", SectionKey: "filter", UsageArgument: "filename.json",
+		Usage: "If set, ginkgo will only run the specs that failed in the passed-in JSON report (generated by a previous run via --json-report).  Suites that had no failures are skipped."},
+	{KeyPath: "C.Serve", Name: "serve", SectionKey: "parallel", UsageArgument: "address",
+		Usage: "If set, ginkgo will listen on the passed-in address (e.g. ':7777') and coordinate a parallel run that includes --remote-procs processes run by `ginkgo worker --connect` on other machines.  The local processes (set via -p or -procs) and the remote processes share a single spec queue and report.  Workers must present the token in the GINKGO_PARALLEL_TOKEN environment variable, which must be set.  Traffic is not encrypted: anyone who can observe it can read the token, the specs' output, and SynchronizedBeforeSuite data - only serve on networks you trust."},
+	{KeyPath: "C.RemoteProcs", Name: "remote-procs", SectionKey: "parallel", UsageArgument: "n",
+		Usage: "The number of processes ginkgo will wait for workers on other machines to run.  Must be used with --serve."},
+}
+
+// GinkgoCLIWorkerFlags provides flags for Ginkgo CLI's worker command that aren't shared by any other commands
+var GinkgoCLIWorkerFlags = GinkgoFlags{
+	{KeyPath: "C.Connect", Name: "connect", SectionKey: "parallel", UsageArgument: "address",
+		Usage: "The address of the ginkgo run --serve process to fetch work from (e.g. 'ci-agent-1:7777').  The worker authenticates with the token in the GINKGO_PARALLEL_TOKEN environment variable, which must be set."},
 }
 
 // GinkgoCLIRunFlags provides flags for Ginkgo CLI's watch command that aren't shared by any other commands
@@ -493,6 +612,10 @@ var GinkgoCLIWatchFlags = GinkgoFlags{
 		UsageArgument:     "Regular Expression",
 		UsageDefaultValue: `\.go$`,
 		Usage:             "Only files matching this regular expression will be watched for changes."},
+	{KeyPath: "C.Interactive", Name: "interactive", SectionKey: "watch",
+		Usage: "If set, ginkgo watch will accept single-key commands (press ? for help) to rerun suites or just the specs that failed, and to change the focus, label filter, verbosity, and seed without restarting."},
+	{KeyPath: "C.ImpactAnalysis", Name: "impact-analysis", SectionKey: "watch",
+		Usage: "If set, ginkgo watch will record the functions each spec covers and, when a change only touches functions, rerun just the specs that covered them.  Recording coverage runs the suite once per spec - so BeforeSuite and AfterSuite run once per spec - and can take much longer than a typical run."},
 }
 
 // GoBuildFlags provides flags for the Ginkgo CLI build, run, and watch commands that capture go's build-time flags.  These are passed to go test -c by the ginkgo CLI
@@ -581,6 +704,24 @@ func VetAndInitializeCLIAndGoConfig(cliConfig CLIConfig, goFlagsConfig GoFlagsCo
 		errors = append(errors, GinkgoErrors.BothRepeatAndUntilItFails())
 	}
 
+	if (cliConfig.Serve == "") != (cliConfig.RemoteProcs < 1) {
+		errors = append(errors, GinkgoErrors.ServeAndRemoteProcsMustBeUsedTogether())
+	}
+
+	if cliConfig.Serve != "" && (cliConfig.Repeat > 0 || cliConfig.UntilItFails) {
+		errors = append(errors, GinkgoErrors.ServeCannotRepeat())
+	}
+
+	if (cliConfig.Serve != "" || cliConfig.Connect != "") && os.Getenv(PARALLEL_TOKEN_ENV_VAR) == "" {
+		errors = append(errors, GinkgoErrors.ServeRequiresToken())
+	}
+
+	switch cliConfig.ParallelTransport {
+	case "", "auto", "unix", "tcp":
+	default:
+		errors = append(errors, GinkgoErrors.InvalidParallelTransport(cliConfig.ParallelTransport))
+	}
+
 	//initialize the output directory
 	if cliConfig.OutputDir != "" {
 		err := os.MkdirAll(cliConfig.OutputDir, 0777)
@@ -655,6 +796,73 @@ func GenerateGinkgoTestRunArgs(suiteConfig SuiteConfig, reporterConfig ReporterC
 	return GenerateFlagArgs(flags, bindings)
 }
 
+/*
+remoteProcFlags are the only flags the Ginkgo CLI passes to the processes it hands off to workers on other machines, and the only Ginkgo and go test flags workers will pass on to the test binary.
+
+This is an allowlist.  Flags that make the test binary write files (reports, profiles, measurement baselines), read files that only exist on the Ginkgo CLI's machine, or report to a different server are deliberately left off it.
+*/
+var remoteProcFlags = SuiteConfigFlags.SubsetWithNames(
+	"seed", "randomize-all", "property-iterations", "shard", "concurrency", "fail-on-pending", "fail-fast", "flake-attempts", "measurement-tolerance", "dry-run",
+	"poll-progress-after", "poll-progress-interval", "source-root", "timeout", "grace-period", "output-interceptor-mode",
+	"label-filter", "sem-ver-filter", "focus", "skip", "focus-file", "skip-file", "focus-spec",
+).CopyAppend(
+	ParallelConfigFlags.SubsetWithNames("parallel.process", "parallel.total")...,
+).CopyAppend(
+	ReporterConfigFlags.SubsetWithNames("no-color", "v", "vv", "succinct", "trace", "show-node-events", "github-output", "go-test-json", "json-stream")...,
+).WithPrefix("ginkgo").CopyAppend(
+	GoRunFlags.SubsetWithNames("blockprofilerate", "memprofilerate", "mutexprofilefraction").WithPrefix("test")...,
+)
+
+// GenerateRemoteProcTestRunArgs is used by the Ginkgo CLI to generate command line arguments for the processes it hands off to workers.  Only the remoteProcFlags are passed along, so remote procs never write reports or profiles on the worker's machine.
+func GenerateRemoteProcTestRunArgs(suiteConfig SuiteConfig, reporterConfig ReporterConfig, goFlagsConfig GoFlagsConfig) ([]string, error) {
+	bindings := map[string]any{
+		"S":  &suiteConfig,
+		"R":  &reporterConfig,
+		"Go": &goFlagsConfig,
+	}
+
+	args, err := GenerateFlagArgs(remoteProcFlags, bindings)
+	if err != nil {
+		return args, err
+	}
+	return append([]string{"--test.timeout=0"}, args...), nil
+}
+
+/*
+ValidateRemoteProcTestRunArgs is used by workers to check the arguments the Ginkgo CLI asked them to pass to the test binary.  Every Ginkgo and go test flag must be one GenerateRemoteProcTestRunArgs could have generated.  Other flags are left alone as they belong to the suite.
+
+The process must also be one of the non-primary parallel processes: proc 1 processes measurement baselines and, if the suite weren't running in parallel, the test binary would write its JSON stream to disk.
+*/
+func ValidateRemoteProcTestRunArgs(args []string) error {
+	process, total := 1, 1
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(name, "ginkgo.") && !strings.HasPrefix(name, "test.") {
+			continue
+		}
+		if name != "test.timeout" && len(remoteProcFlags.SubsetWithNames(name)) == 0 {
+			return fmt.Errorf("the Ginkgo CLI asked the worker to pass --%s to the test binary, which the worker does not allow", name)
+		}
+		var err error
+		switch name {
+		case "ginkgo.parallel.process":
+			process, err = strconv.Atoi(value)
+		case "ginkgo.parallel.total":
+			total, err = strconv.Atoi(value)
+		}
+		if err != nil {
+			return fmt.Errorf("the Ginkgo CLI asked the worker to pass an invalid --%s to the test binary: %s", name, value)
+		}
+	}
+	if process < 2 || total < process {
+		return fmt.Errorf("the Ginkgo CLI asked the worker to run process %d of %d, but workers only run the non-primary processes of a parallel run", process, total)
+	}
+	return nil
+}
+
 // GenerateGoTestRunArgs is used by the Ginkgo CLI to generate command line arguments to pass to the compiled non-Ginkgo test binary
 func GenerateGoTestRunArgs(goFlagsConfig GoFlagsConfig) ([]string, error) {
 	flags := GoRunFlags.WithPrefix("test")
@@ -738,6 +946,21 @@ func BuildBuildCommandFlagSet(cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig
 	return NewGinkgoFlagSet(flags, bindings, flagSections)
 }
 
+// BuildWorkerCommandFlagSet builds the FlagSet for the `ginkgo worker` command
+func BuildWorkerCommandFlagSet(cliConfig *CLIConfig, goFlagsConfig *GoFlagsConfig) (GinkgoFlagSet, error) {
+	flags := GinkgoCLIWorkerFlags
+	flags = flags.CopyAppend(GinkgoCLIRunAndWatchFlags.SubsetWithNames("procs", "nodes", "p")...)
+	flags = flags.CopyAppend(GoBuildFlags...)
+
+	bindings := map[string]any{
+		"C":  cliConfig,
+		"Go": goFlagsConfig,
+		"D":  &deprecatedConfig{},
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
+
 func BuildLabelsCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
 	flags := GinkgoCLISharedFlags.SubsetWithNames("r", "skip-package")
 
@@ -755,3 +978,14 @@ func BuildLabelsCommandFlagSet(cliConfig *CLIConfig) (GinkgoFlagSet, error) {
 
 	return NewGinkgoFlagSet(flags, bindings, flagSections)
 }
+
+// BuildReportCommandFlagSet builds the FlagSet for the `ginkgo report` command
+func BuildReportCommandFlagSet(reporterConfig *ReporterConfig) (GinkgoFlagSet, error) {
+	flags := ReporterConfigFlags.SubsetWithNames("json-report", "junit-report", "teamcity-report", "tap-report")
+
+	bindings := map[string]any{
+		"R": reporterConfig,
+	}
+
+	return NewGinkgoFlagSet(flags, bindings, FlagSections)
+}
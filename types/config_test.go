@@ -210,6 +210,44 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("validating --shard", func() {
+			It("errors if an invalid shard is specified", func() {
+				suiteConf.Shard = "3/2"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidShard("3/2")))
+
+				suiteConf.Shard = "2/2"
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+			})
+		})
+
+		Describe("validating --quarantine-file", func() {
+			It("errors if the quarantine file can't be loaded", func() {
+				suiteConf.QuarantineFile = "/no/such/quarantine.json"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(HaveLen(1))
+				Ω(errors[0].(types.GinkgoError).DocLink).Should(Equal("quarantining-flaky-specs"))
+			})
+		})
+
+		Describe("validating --measurement-baseline and --measurement-tolerance", func() {
+			It("errors if the baseline can't be loaded", func() {
+				suiteConf.MeasurementBaseline = "/no/such/baseline.json"
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(HaveLen(1))
+				Ω(errors[0].(types.GinkgoError).DocLink).Should(Equal("comparing-measurements-against-a-baseline"))
+			})
+
+			It("errors if the tolerance is negative", func() {
+				suiteConf.MeasurementTolerance = -0.1
+				errors := types.VetConfig(flagSet, suiteConf, repConf)
+				Ω(errors).Should(ConsistOf(types.GinkgoErrors.InvalidMeasurementTolerance(-0.1)))
+
+				suiteConf.MeasurementTolerance = 0.5
+				Ω(types.VetConfig(flagSet, suiteConf, repConf)).Should(BeEmpty())
+			})
+		})
+
 		Describe("validating --output-interceptor-mode", func() {
 			It("errors if an invalid output interceptor mode is specified", func() {
 				suiteConf.OutputInterceptorMode = "DURP"
@@ -244,4 +282,69 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+	Describe("arguments for remote procs", func() {
+		var suiteConf types.SuiteConfig
+		var repConf types.ReporterConfig
+		var goFlagsConf types.GoFlagsConfig
+
+		BeforeEach(func() {
+			suiteConf = types.NewDefaultSuiteConfig()
+			suiteConf.RandomSeed = 17
+			suiteConf.LabelFilter = "fast"
+			suiteConf.ParallelProcess, suiteConf.ParallelTotal, suiteConf.ParallelHost = 3, 4, "127.0.0.1:7777"
+			suiteConf.QuarantineFile, suiteConf.DurationReport = "/coordinator/quarantine.json", "/coordinator/durations.json"
+			suiteConf.MeasurementBaseline, suiteConf.SaveMeasurementBaseline = "/coordinator/baseline.json", "/coordinator/new-baseline.json"
+			repConf = types.NewDefaultReporterConfig()
+			repConf.Verbose = true
+			repConf.JSONReport, repConf.JUnitReport, repConf.JSONStream = "report.json", "report.xml", "/coordinator/stream.jsonl"
+			goFlagsConf = types.NewDefaultGoFlagsConfig()
+			goFlagsConf.CoverProfile, goFlagsConf.CPUProfile, goFlagsConf.Trace = "cover.out", "cpu.out", "trace.out"
+			goFlagsConf.MemProfileRate = 10
+		})
+
+		It("only passes along flags that are safe to run on another machine", func() {
+			args, err := types.GenerateRemoteProcTestRunArgs(suiteConf, repConf, goFlagsConf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(args).Should(ConsistOf(
+				"--test.timeout=0",
+				"--ginkgo.seed=17",
+				"--ginkgo.label-filter=fast",
+				"--ginkgo.timeout=1h0m0s",
+				"--ginkgo.grace-period=30s",
+				"--ginkgo.parallel.process=3",
+				"--ginkgo.parallel.total=4",
+				"--ginkgo.v",
+				"--ginkgo.json-stream=/coordinator/stream.jsonl",
+				"--test.memprofilerate=10",
+			))
+			Ω(types.ValidateRemoteProcTestRunArgs(args)).Should(Succeed())
+		})
+
+		It("allows the suite's own flags", func() {
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=2", "--ginkgo.parallel.total=2", "--my-flag=/some/path", "positional"})).Should(Succeed())
+		})
+
+		It("rejects every other Ginkgo and go test flag", func() {
+			allArgs, err := types.GenerateGinkgoTestRunArgs(suiteConf, repConf, goFlagsConf)
+			Ω(err).ShouldNot(HaveOccurred())
+			remoteArgs, err := types.GenerateRemoteProcTestRunArgs(suiteConf, repConf, goFlagsConf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			allArgs = append(allArgs, "--test.gocoverdir=/tmp/cover", "-test.outputdir=/tmp", "--ginkgo.some-future-flag")
+			for _, arg := range allArgs {
+				if isRemoteArg, _ := ContainElement(arg).Match(remoteArgs); isRemoteArg {
+					continue
+				}
+				Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=2", "--ginkgo.parallel.total=2", arg})).ShouldNot(Succeed(), arg)
+			}
+		})
+
+		It("rejects the primary process and processes outside of a parallel run", func() {
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.total=2"})).ShouldNot(Succeed())
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=1", "--ginkgo.parallel.total=2"})).ShouldNot(Succeed())
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=2", "--ginkgo.parallel.total=2", "--ginkgo.parallel.process=1"})).ShouldNot(Succeed())
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=2"})).ShouldNot(Succeed())
+			Ω(types.ValidateRemoteProcTestRunArgs([]string{"--ginkgo.parallel.process=two", "--ginkgo.parallel.total=2"})).ShouldNot(Succeed())
+		})
+	})
 })
@@ -390,6 +390,14 @@ func (g ginkgoErrors) InvalidFileFilterRegularExpression(filter string, err erro
 	}
 }
 
+func (g ginkgoErrors) InvalidSpecIdentity(identity string) error {
+	return GinkgoError{
+		Heading: "Invalid Spec Identity",
+		Message: fmt.Sprintf(`The provided spec identity: "%s" is invalid.  --focus-spec expects a JSON object with the FileName, LineNumber, and FullText of a spec.`, identity),
+		DocLink: "repeating-spec-runs-and-managing-flaky-specs",
+	}
+}
+
 /* Label Errors */
 func (g ginkgoErrors) SyntaxErrorParsingLabelFilter(input string, location int, error string) error {
 	var message string
@@ -432,6 +440,70 @@ func (g ginkgoErrors) InvalidEmptyLabel(cl CodeLocation) error {
 	}
 }
 
+func (g ginkgoErrors) InvalidEmptyExclusiveResource(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid Empty Exclusive Resource",
+		Message:      "Exclusive resources cannot be empty",
+		CodeLocation: cl,
+		DocLink:      "exclusive-resources",
+	}
+}
+
+func (g ginkgoErrors) InvalidSemVerConstraint(constraint string, reason string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid SemVerConstraint",
+		Message:      fmt.Sprintf("'%s' is an invalid semantic version constraint: %s", constraint, reason),
+		CodeLocation: cl,
+		DocLink:      "filtering-specs-by-semantic-version",
+	}
+}
+
+/* ItForAll errors */
+func (g ginkgoErrors) InvalidBodyForItForAll(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "ItForAll must be passed exactly one body function",
+		Message:      "ItForAll expects a list of Generators followed by a single body function that accepts one parameter per Generator.",
+		CodeLocation: cl,
+		DocLink:      "property-based-specs",
+	}
+}
+
+func (g ginkgoErrors) GeneratorCountMismatchForItForAll(generators int, parameters int, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "ItForAll passed the wrong number of Generators",
+		Message:      fmt.Sprintf("ItForAll was passed %d Generators but its body function accepts %d parameters.  The body must accept exactly one (non-variadic) parameter per Generator, optionally preceded by a SpecContext or context.Context.", generators, parameters),
+		CodeLocation: cl,
+		DocLink:      "property-based-specs",
+	}
+}
+
+func (g ginkgoErrors) GeneratorTypeMismatchForItForAll(i int, expected, actual reflect.Type, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "ItForAll passed a Generator of the wrong type",
+		Message:      fmt.Sprintf("ItForAll's body function expects parameter #%d to be of type <%s> but the Generator produces <%s>", i, expected, actual),
+		CodeLocation: cl,
+		DocLink:      "property-based-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidGeneratorArguments(generator string, message string, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      fmt.Sprintf("%s passed invalid arguments", generator),
+		Message:      message,
+		CodeLocation: cl,
+		DocLink:      "property-based-specs",
+	}
+}
+
+func (g ginkgoErrors) PushingCleanupInPropertyBody(cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "DeferCleanup cannot be called in an ItForAll body",
+		Message:      "ItForAll runs its body many times while it searches for a counterexample so cleanup registered in the body would pile up until the spec ends.  Please use defer in the body or register your cleanup in a setup node instead.",
+		CodeLocation: cl,
+		DocLink:      "property-based-specs",
+	}
+}
+
 /* Table errors */
 func (g ginkgoErrors) MultipleEntryBodyFunctionsForTable(cl CodeLocation) error {
 	return GinkgoError{
@@ -451,6 +523,24 @@ func (g ginkgoErrors) InvalidEntryDescription(cl CodeLocation) error {
 	}
 }
 
+func (g ginkgoErrors) InvalidTableEntriesFile(path string, err error, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid table entries file",
+		Message:      fmt.Sprintf("Ginkgo could not load table entries from %s:\n%s", path, err),
+		CodeLocation: cl,
+		DocLink:      "loading-table-entries-from-files",
+	}
+}
+
+func (g ginkgoErrors) InvalidTableEntryParameter(i int, expected reflect.Type, err error, cl CodeLocation) error {
+	return GinkgoError{
+		Heading:      "Invalid table entry parameter",
+		Message:      fmt.Sprintf("Ginkgo could not decode parameter #%d of this row into <%s>:\n%s", i, expected, err),
+		CodeLocation: cl,
+		DocLink:      "loading-table-entries-from-files",
+	}
+}
+
 func (g ginkgoErrors) MissingParametersForTableFunction(cl CodeLocation) error {
 	return GinkgoError{
 		Heading:      fmt.Sprintf("No parameters have been passed to the Table Function"),
@@ -585,6 +675,54 @@ func (g ginkgoErrors) GracePeriodCannotBeZero() error {
 	}
 }
 
+func (g ginkgoErrors) InvalidShard(shard string) error {
+	return GinkgoError{
+		Heading: "Invalid Shard",
+		Message: fmt.Sprintf(`The provided shard "%s" is invalid.  Shards must be of the form "i/N" where N is the total number of shards and i is between 1 and N.`, shard),
+		DocLink: "sharding-specs-across-machines",
+	}
+}
+
+func (g ginkgoErrors) InvalidSemVerFilter(version string, err error) error {
+	return GinkgoError{
+		Heading: "Invalid SemVer Filter",
+		Message: fmt.Sprintf(`The provided --sem-ver-filter "%s" is not a valid semantic version: %s`, version, err),
+		DocLink: "filtering-specs-by-semantic-version",
+	}
+}
+
+func (g ginkgoErrors) InvalidQuarantineFile(path string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Could not load quarantine file %s", path),
+		Message: fmt.Sprintf("--quarantine-file must point to a JSON file listing the specs to quarantine.\n%s", err),
+		DocLink: "quarantining-flaky-specs",
+	}
+}
+
+func (g ginkgoErrors) InvalidDurationReport(path string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Could not load spec durations from %s", path),
+		Message: fmt.Sprintf("--order-by-duration must point to a JSON report generated by a previous run via --json-report.\n%s", err),
+		DocLink: "spec-parallelization",
+	}
+}
+
+func (g ginkgoErrors) InvalidMeasurementBaseline(path string, err error) error {
+	return GinkgoError{
+		Heading: fmt.Sprintf("Could not load measurement baseline %s", path),
+		Message: fmt.Sprintf("--measurement-baseline must point to a baseline saved by a previous run via --save-measurement-baseline.\n%s", err),
+		DocLink: "comparing-measurements-against-a-baseline",
+	}
+}
+
+func (g ginkgoErrors) InvalidMeasurementTolerance(tolerance float64) error {
+	return GinkgoError{
+		Heading: "Invalid --measurement-tolerance",
+		Message: fmt.Sprintf("--measurement-tolerance must not be negative.  You passed in %g.", tolerance),
+		DocLink: "comparing-measurements-against-a-baseline",
+	}
+}
+
 func (g ginkgoErrors) ConflictingVerbosityConfiguration() error {
 	return GinkgoError{
 		Heading: "Conflicting reporter verbosity settings.",
@@ -613,6 +751,46 @@ func (g ginkgoErrors) InvalidGoFlagParallel() error {
 	}
 }
 
+func (g ginkgoErrors) InvalidParallelTransport(transport string) error {
+	return GinkgoError{
+		Heading: "Invalid Parallel Transport",
+		Message: fmt.Sprintf(`The provided parallel transport "%s" is invalid.  --parallel-transport must be one of auto, unix, or tcp.`, transport),
+		DocLink: "spec-parallelization",
+	}
+}
+
+func (g ginkgoErrors) ServeAndRemoteProcsMustBeUsedTogether() error {
+	return GinkgoError{
+		Heading: "--serve and --remote-procs must be used together",
+		Message: "--serve directs Ginkgo to coordinate a parallel run with workers on other machines and --remote-procs tells Ginkgo how many processes those workers will run.  Please set both, or neither.",
+		DocLink: "running-specs-across-multiple-machines",
+	}
+}
+
+func (g ginkgoErrors) ServeCannotRepeat() error {
+	return GinkgoError{
+		Heading: "--serve can't be combined with --repeat or --until-it-fails",
+		Message: "Workers connected to a Ginkgo CLI running with --serve run their share of the suite once and then exit.  Please drop --repeat and --until-it-fails.",
+		DocLink: "running-specs-across-multiple-machines",
+	}
+}
+
+func (g ginkgoErrors) ServeRequiresToken() error {
+	return GinkgoError{
+		Heading: "--serve and --connect require a token",
+		Message: fmt.Sprintf("Anyone who can reach a Ginkgo CLI running with --serve could otherwise take part in the run.  Please set the %s environment variable to the same secret value on the coordinating machine and on each worker.", PARALLEL_TOKEN_ENV_VAR),
+		DocLink: "running-specs-across-multiple-machines",
+	}
+}
+
+func (g ginkgoErrors) ServeCanOnlyRunOneSuite(numSuites int) error {
+	return GinkgoError{
+		Heading: "--serve can only run one suite",
+		Message: fmt.Sprintf("Ginkgo found %d suites.  Workers connected to a Ginkgo CLI running with --serve run a single suite, please pass in just one package.", numSuites),
+		DocLink: "running-specs-across-multiple-machines",
+	}
+}
+
 func (g ginkgoErrors) BothRepeatAndUntilItFails() error {
 	return GinkgoError{
 		Heading: "--repeat and --until-it-fails are both set",
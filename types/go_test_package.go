@@ -0,0 +1,14 @@
+package types
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// GoTestPackage returns the import path of the package under test given the build info of its compiled test binary.  Test binaries are built with a main package path of "<import path>.test".  GoTestPackage returns "" if info is nil.
+func GoTestPackage(info *debug.BuildInfo) string {
+	if info == nil {
+		return ""
+	}
+	return strings.TrimSuffix(info.Path, ".test")
+}
@@ -0,0 +1,29 @@
+package types_test
+
+import (
+	"runtime/debug"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoTestPackage", func() {
+	It("strips the .test suffix from the test binary's main package path", func() {
+		Ω(types.GoTestPackage(&debug.BuildInfo{Path: "github.com/foo/bar.test"})).Should(Equal("github.com/foo/bar"))
+	})
+
+	It("returns the path unchanged when there is no .test suffix", func() {
+		Ω(types.GoTestPackage(&debug.BuildInfo{Path: "github.com/foo/bar"})).Should(Equal("github.com/foo/bar"))
+	})
+
+	It("returns the empty string when there is no build info", func() {
+		Ω(types.GoTestPackage(nil)).Should(BeEmpty())
+	})
+
+	It("works with the build info of the running test binary", func() {
+		info, ok := debug.ReadBuildInfo()
+		Ω(ok).Should(BeTrue())
+		Ω(types.GoTestPackage(info)).Should(Equal("github.com/onsi/ginkgo/v2/types"))
+	})
+})
@@ -33,6 +33,65 @@ func matchLabelRegexAction(regex *regexp.Regexp) LabelFilter {
 	}
 }
 
+func matchLabelSetAction(key string, operation string, values []string) LabelFilter {
+	expected := map[string]bool{}
+	for _, value := range values {
+		expected[strings.ToLower(value)] = true
+	}
+	return func(labels []string) bool {
+		set := labelSetFor(key, labels)
+		switch operation {
+		case "isEmpty":
+			return len(set) == 0
+		case "containsAny":
+			for value := range expected {
+				if set[value] {
+					return true
+				}
+			}
+			return false
+		case "containsAll":
+			for value := range expected {
+				if !set[value] {
+					return false
+				}
+			}
+			return true
+		case "consistsOf":
+			if len(set) != len(expected) {
+				return false
+			}
+			for value := range expected {
+				if !set[value] {
+					return false
+				}
+			}
+			return true
+		case "isSubsetOf":
+			for value := range set {
+				if !expected[value] {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	}
+}
+
+// labelSetFor returns the (lower-cased) values of all the key:value labels with the passed-in key
+func labelSetFor(key string, labels []string) map[string]bool {
+	key = strings.ToLower(key)
+	set := map[string]bool{}
+	for _, label := range labels {
+		labelKey, value, isKeyValue := ParseKeyValueLabel(label)
+		if isKeyValue && strings.ToLower(labelKey) == key {
+			set[strings.ToLower(value)] = true
+		}
+	}
+	return set
+}
+
 func notAction(filter LabelFilter) LabelFilter {
 	return func(labels []string) bool { return !filter(labels) }
 }
@@ -58,9 +117,12 @@ const (
 	lfTokenOr
 	lfTokenRegexp
 	lfTokenLabel
+	lfTokenSetQuery
 	lfTokenEOF
 )
 
+var labelSetOperations = []string{"isEmpty", "containsAny", "containsAll", "consistsOf", "isSubsetOf"}
+
 func (l lfToken) Precedence() int {
 	switch l {
 	case lfTokenRoot, lfTokenOpenGroup:
@@ -93,6 +155,8 @@ func (l lfToken) String() string {
 		return "/regexp/"
 	case lfTokenLabel:
 		return "label"
+	case lfTokenSetQuery:
+		return "key: operation {set}"
 	case lfTokenEOF:
 		return "EOF"
 	}
@@ -104,6 +168,10 @@ type treeNode struct {
 	location int
 	value    string
 
+	// only used by lfTokenSetQuery nodes, whose value is the label key
+	setOperation string
+	setValues    []string
+
 	parent    *treeNode
 	leftNode  *treeNode
 	rightNode *treeNode
@@ -142,6 +210,8 @@ func (tn *treeNode) constructLabelFilter(input string) (LabelFilter, error) {
 		return nil, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, tn.location, "Mismatched '(' - could not find matching ')'.")
 	case lfTokenLabel:
 		return matchLabelAction(tn.value), nil
+	case lfTokenSetQuery:
+		return matchLabelSetAction(tn.value, tn.setOperation, tn.setValues), nil
 	case lfTokenRegexp:
 		re, err := regexp.Compile(tn.value)
 		if err != nil {
@@ -188,6 +258,9 @@ func (tn *treeNode) tokenString() string {
 	if tn.value != "" {
 		out += " | " + tn.value
 	}
+	if tn.setOperation != "" {
+		out += fmt.Sprintf(": %s {%s}", tn.setOperation, strings.Join(tn.setValues, ", "))
+	}
 	out += ">"
 	return out
 }
@@ -213,6 +286,12 @@ func tokenize(input string) func() (*treeNode, error) {
 		return false
 	}
 
+	skipSpaces := func() {
+		for i < len(runes) && runes[i] == ' ' {
+			i += 1
+		}
+	}
+
 	consumeUntil := func(cutset string) (string, int) {
 		j := i
 		for ; j < len(runes); j++ {
@@ -223,10 +302,61 @@ func tokenize(input string) func() (*treeNode, error) {
 		return string(runes[i:j]), j - i
 	}
 
-	return func() (*treeNode, error) {
-		for i < len(runes) && runes[i] == ' ' {
-			i += 1
+	// isSetOperationAt returns true if the text following the ':' at j is one of the labelSetOperations.  Any other text following a ':' is part of the label (e.g. "Feature:SCTP")
+	isSetOperationAt := func(j int) bool {
+		for j += 1; j < len(runes) && runes[j] == ' '; j++ {
 		}
+		k := j
+		for ; k < len(runes) && !strings.ContainsRune(" &|!,()/{", runes[k]); k++ {
+		}
+		for _, validOperation := range labelSetOperations {
+			if validOperation == string(runes[j:k]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// tokenizeSetQuery consumes the "operation {value, value, ...}" that follows a "key:" in a set query
+	tokenizeSetQuery := func(node *treeNode, key string) (*treeNode, error) {
+		if key == "" {
+			return &treeNode{}, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, node.location, "Set query is missing a key.")
+		}
+		i += 1
+		skipSpaces()
+		operation, n := consumeUntil(" &|!,()/{")
+		i += n
+		skipSpaces()
+		node.token, node.value, node.setOperation = lfTokenSetQuery, key, operation
+
+		hasSet := i < len(runes) && runes[i] == '{'
+		if node.setOperation == "isEmpty" {
+			if hasSet {
+				return &treeNode{}, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, i, "isEmpty does not take a set of values.")
+			}
+			return node, nil
+		}
+		if !hasSet {
+			return &treeNode{}, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, i, fmt.Sprintf("%s requires a set of values, e.g. {A, B}.", node.setOperation))
+		}
+		openLocation := i
+		i += 1
+		set, n := consumeUntil("}")
+		if i+n >= len(runes) {
+			return &treeNode{}, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, openLocation, "Mismatched '{' - could not find matching '}'.")
+		}
+		i += n + 1
+		node.setValues = []string{}
+		for _, value := range strings.Split(set, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				node.setValues = append(node.setValues, value)
+			}
+		}
+		return node, nil
+	}
+
+	return func() (*treeNode, error) {
+		skipSpaces()
 
 		if i >= len(runes) {
 			return &treeNode{token: lfTokenEOF}, nil
@@ -264,9 +394,19 @@ func tokenize(input string) func() (*treeNode, error) {
 			i += n + 1
 			node.token, node.value = lfTokenRegexp, value
 		default:
-			value, n := consumeUntil("&|!,()/")
-			i += n
-			node.token, node.value = lfTokenLabel, strings.TrimSpace(value)
+			start := i
+			for {
+				_, n := consumeUntil("&|!,()/:")
+				i += n
+				if i >= len(runes) || runes[i] != ':' {
+					break
+				}
+				if isSetOperationAt(i) {
+					return tokenizeSetQuery(node, strings.TrimSpace(string(runes[start:i])))
+				}
+				i += 1
+			}
+			node.token, node.value = lfTokenLabel, cleanupKeyValueLabel(strings.TrimSpace(string(runes[start:i])))
 		}
 		return node, nil
 	}
@@ -307,7 +447,7 @@ LOOP:
 		switch node.token {
 		case lfTokenEOF:
 			break LOOP
-		case lfTokenLabel, lfTokenRegexp:
+		case lfTokenLabel, lfTokenRegexp, lfTokenSetQuery:
 			if current.rightNode != nil {
 				return nil, GinkgoErrors.SyntaxErrorParsingLabelFilter(input, node.location, "Found two adjacent labels.  You need an operator between them.")
 			}
@@ -354,5 +494,28 @@ func ValidateAndCleanupLabel(label string, cl CodeLocation) (string, error) {
 	if strings.ContainsAny(out, "&|!,()/") {
 		return "", GinkgoErrors.InvalidLabel(label, cl)
 	}
-	return out, nil
+	return cleanupKeyValueLabel(out), nil
+}
+
+// cleanupKeyValueLabel trims the whitespace around the key and value of a key:value label.  Labels that aren't of the form key:value are returned unchanged.
+func cleanupKeyValueLabel(label string) string {
+	key, value, isKeyValue := ParseKeyValueLabel(label)
+	if !isKeyValue {
+		return label
+	}
+	return key + ":" + value
+}
+
+/*
+ParseKeyValueLabel splits a key:value label into its key and value.  Whitespace around the key and value is trimmed.
+
+isKeyValue is false if the label does not contain a ':' or if either the key or the value is empty.
+*/
+func ParseKeyValueLabel(label string) (key string, value string, isKeyValue bool) {
+	key, value, found := strings.Cut(label, ":")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !found || key == "" || value == "" {
+		return "", "", false
+	}
+	return key, value, true
 }
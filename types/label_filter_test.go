@@ -40,6 +40,11 @@ var _ = Describe("LabelFilter", func() {
 		Entry(nil, " || B", 1, "Operator '||' missing left hand operand."),
 		Entry(nil, "&&", 0, "Operator '&&' missing left hand operand."),
 		Entry(nil, "&& || B", 0, "Operator '&&' missing left hand operand."),
+		Entry(nil, "A && : isEmpty", 5, "Set query is missing a key."),
+		Entry(nil, "env: isEmpty {a}", 13, "isEmpty does not take a set of values."),
+		Entry(nil, "env: containsAny && A", 17, "containsAny requires a set of values, e.g. {A, B}."),
+		Entry(nil, "env: containsAny {a, b", 17, "Mismatched '{' - could not find matching '}'."),
+		Entry(nil, "env: isEmpty env: isEmpty", 13, "Found two adjacent labels.  You need an operator between them."),
 	)
 
 	type matchingLabels []string
@@ -163,6 +168,45 @@ var _ = Describe("LabelFilter", func() {
 			M("dog", "cat"), M("dog", "cow"), M("cat", "cow", "dog"), M("dog", "orca"),
 			NM("dog"), NM("cow"), NM("cat"), NM("dog", "fruit"), NM("dog", "cup"),
 		),
+		Entry("Set queries: containsAny", "owner: containsAny {payments, billing}",
+			M("owner:payments"), M("owner: billing", "slow"), M("OWNER:Payments"), M("owner:search", "owner:billing"),
+			NM(), NM("owner:search"), NM("payments"), NM("team:payments"),
+		),
+		Entry("Set queries: isEmpty", "env: isEmpty",
+			M(), M("slow"), M("env"), M("environment:staging"),
+			NM("env:staging"), NM("slow", "ENV:prod"),
+		),
+		Entry("Set queries: containsAll", "env: containsAll {staging, prod}",
+			M("env:staging", "env:prod"), M("env:staging", "env:prod", "env:dev"),
+			NM(), NM("env:staging"), NM("staging", "prod"),
+		),
+		Entry("Set queries: consistsOf", "env: consistsOf {staging, prod}",
+			M("env:prod", "env:staging"), M("env:prod", "env:staging", "slow"),
+			NM(), NM("env:staging"), NM("env:staging", "env:prod", "env:dev"),
+		),
+		Entry("Set queries: isSubsetOf", "env:isSubsetOf{staging,prod}",
+			M(), M("env:staging"), M("env:staging", "env:prod"), M("slow"),
+			NM("env:dev"), NM("env:staging", "env:dev"),
+		),
+		Entry("Set queries: an empty set", "env: containsAny {}",
+			NM(), NM("env:staging"),
+		),
+		Entry("Set queries combined with other operators", "owner: containsAny {payments} && !env: containsAny {prod} || slow",
+			M("owner:payments"), M("slow"), M("owner:payments", "env:staging"),
+			NM(), NM("owner:payments", "env:prod"), NM("owner:billing"),
+		),
+		Entry("Labels with colons that aren't set queries", "Feature:SCTP || Feature:IPv6:DualStack || env: staging",
+			M("Feature:SCTP"), M("feature:sctp"), M("Feature:IPv6:DualStack"), M("env:staging"),
+			NM(), NM("Feature"), NM("SCTP"), NM("Feature:IPv6"),
+		),
+		Entry("Labels with colons followed by text that isn't an operation", "env: bogus {a}",
+			M("env:bogus {a}"),
+			NM("env:a"),
+		),
+		Entry("Set queries in groups", "(owner: isEmpty, env: containsAny {prod}) && !slow",
+			M(), M("env:prod", "owner:payments"),
+			NM("slow"), NM("owner:payments"), NM("env:prod", "slow"),
+		),
 	)
 
 	cl := types.NewCodeLocation(0)
@@ -190,6 +234,28 @@ var _ = Describe("LabelFilter", func() {
 		Entry(nil, "cow()", "", types.GinkgoErrors.InvalidLabel("cow()", cl)),
 		Entry(nil, "cow)", "", types.GinkgoErrors.InvalidLabel("cow)", cl)),
 		Entry(nil, "cow/", "", types.GinkgoErrors.InvalidLabel("cow/", cl)),
+		Entry(nil, "owner:payments", "owner:payments", nil),
+		Entry(nil, "  owner : payments team ", "owner:payments team", nil),
+		Entry(nil, "Feature:SCTP", "Feature:SCTP", nil),
+		Entry(nil, " a : b:c ", "a:b:c", nil),
+		Entry(nil, ":payments", ":payments", nil),
+		Entry(nil, "owner: ", "owner:", nil),
+		Entry(nil, "owner:{payments}", "owner:{payments}", nil),
+	)
+
+	DescribeTable("Parsing key:value labels",
+		func(label string, expectedKey string, expectedValue string, expectedIsKeyValue bool) {
+			key, value, isKeyValue := types.ParseKeyValueLabel(label)
+			Ω(key).Should(Equal(expectedKey))
+			Ω(value).Should(Equal(expectedValue))
+			Ω(isKeyValue).Should(Equal(expectedIsKeyValue))
+		},
+		Entry(nil, "owner:payments", "owner", "payments", true),
+		Entry(nil, " owner : payments ", "owner", "payments", true),
+		Entry(nil, "payments", "", "", false),
+		Entry(nil, "owner:", "", "", false),
+		Entry(nil, ":payments", "", "", false),
+		Entry(nil, "a:b:c", "a", "b:c", true),
 	)
 
 	Describe("MustParseLabelFilter", func() {
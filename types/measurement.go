@@ -0,0 +1,178 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type MeasurementType uint
+
+const (
+	MeasurementTypeInvalid MeasurementType = iota
+	MeasurementTypeDuration
+	MeasurementTypeValue
+)
+
+var mtEnumSupport = NewEnumSupport(map[uint]string{
+	uint(MeasurementTypeInvalid):  "INVALID MEASUREMENT TYPE",
+	uint(MeasurementTypeDuration): "duration",
+	uint(MeasurementTypeValue):    "value",
+})
+
+func (mt MeasurementType) String() string {
+	return mtEnumSupport.String(uint(mt))
+}
+func (mt *MeasurementType) UnmarshalJSON(b []byte) error {
+	out, err := mtEnumSupport.UnmarshJSON(b)
+	*mt = MeasurementType(out)
+	return err
+}
+func (mt MeasurementType) MarshalJSON() ([]byte, error) {
+	return mtEnumSupport.MarshJSON(uint(mt))
+}
+
+// Measurement captures a set of samples recorded via SampleDuration or SampleValue along with their statistics
+// Measurements are attached to the spec's report as the value of a ReportEntry
+type Measurement struct {
+	// Type is either MeasurementTypeDuration or MeasurementTypeValue
+	Type MeasurementType
+	// Name is the name of the ReportEntry the Measurement is attached to
+	Name string
+	// Units are the (optional) units of a MeasurementTypeValue measurement
+	Units string
+	// HigherIsBetter is true for MeasurementTypeValue measurements where larger values are improvements (e.g. throughput).  It determines which direction counts as a regression when comparing against a baseline.
+	HigherIsBetter bool `json:",omitempty"`
+	// Samples are the recorded samples.  Durations are recorded in nanoseconds.
+	Samples []float64
+	// Stats are computed from the Samples when the Measurement is recorded
+	Stats MeasurementStats
+}
+
+// MeasurementStats captures summary statistics for a Measurement's samples.  For MeasurementTypeDuration measurements all values are in nanoseconds - use time.Duration(stat) to convert them.
+type MeasurementStats struct {
+	N      int
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	StdDev float64
+	P90    float64
+	P95    float64
+	P99    float64
+}
+
+func NewMeasurement(measurementType MeasurementType, name string, units string, samples []float64) Measurement {
+	return Measurement{
+		Type:    measurementType,
+		Name:    name,
+		Units:   units,
+		Samples: samples,
+		Stats:   ComputeMeasurementStats(samples),
+	}
+}
+
+// ComputeMeasurementStats computes the summary statistics for samples.  The standard deviation is the population standard deviation and percentiles are linearly interpolated between the closest ranks.
+func ComputeMeasurementStats(samples []float64) MeasurementStats {
+	if len(samples) == 0 {
+		return MeasurementStats{}
+	}
+	sorted := append([]float64{}, samples...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, sample := range sorted {
+		sum += sample
+	}
+	mean := sum / float64(len(sorted))
+	variance := 0.0
+	for _, sample := range sorted {
+		variance += (sample - mean) * (sample - mean)
+	}
+	variance = variance / float64(len(sorted))
+
+	return MeasurementStats{
+		N:      len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean,
+		Median: percentile(sorted, 50),
+		StdDev: math.Sqrt(variance),
+		P90:    percentile(sorted, 90),
+		P95:    percentile(sorted, 95),
+		P99:    percentile(sorted, 99),
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of the Measurement's samples
+func (m Measurement) Percentile(p float64) float64 {
+	if len(m.Samples) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, m.Samples...)
+	sort.Float64s(sorted)
+	return percentile(sorted, p)
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower < 0 {
+		return sorted[0]
+	}
+	if upper >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// FormatValue renders a value (e.g. one of the Measurement's Stats) using the Measurement's type and units
+func (m Measurement) FormatValue(value float64) string {
+	if m.Type == MeasurementTypeDuration {
+		d := time.Duration(math.Round(value))
+		switch {
+		case d >= time.Second || d <= -time.Second:
+			d = d.Round(time.Millisecond)
+		case d >= time.Millisecond || d <= -time.Millisecond:
+			d = d.Round(time.Microsecond)
+		}
+		return d.String()
+	}
+	out := fmt.Sprintf("%.6g", value)
+	if m.Units != "" {
+		out += " " + m.Units
+	}
+	return out
+}
+
+func (m Measurement) String() string {
+	return formatMeasurementTable("", "", m)
+}
+
+// ColorableString renders the Measurement as a table of statistics.  This is how the Measurement appears in Ginkgo's console output.
+func (m Measurement) ColorableString() string {
+	return formatMeasurementTable("{{bold}}", "{{/}}", m)
+}
+
+func formatMeasurementTable(headerStyle string, resetStyle string, measurements ...Measurement) string {
+	buf := &strings.Builder{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Name\tN\tMin\tMedian\tMean\tStdDev\tP90\tP95\tP99\tMax")
+	for _, m := range measurements {
+		s := m.Stats
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", m.Name, s.N,
+			m.FormatValue(s.Min), m.FormatValue(s.Median), m.FormatValue(s.Mean), m.FormatValue(s.StdDev),
+			m.FormatValue(s.P90), m.FormatValue(s.P95), m.FormatValue(s.P99), m.FormatValue(s.Max))
+	}
+	w.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	lines[0] = headerStyle + strings.TrimRight(lines[0], " ") + resetStyle
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,187 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+/*
+MeasurementBaseline captures the Measurements recorded by a previous run.  It is keyed by the full text of the spec that recorded the Measurement and then by the Measurement's name.
+
+Baselines are saved via --save-measurement-baseline and compared against via --measurement-baseline.
+*/
+type MeasurementBaseline map[string]map[string]Measurement
+
+// DefaultMeasurementTolerance is the tolerance used when comparing Measurements against a baseline if --measurement-tolerance is not set
+const DefaultMeasurementTolerance = 0.1
+
+// MeasurementFromReportEntry returns the Measurement stored in entry, if any.  This works both for entries recorded in the current process and for entries decoded from a report (e.g. one sent by another parallel process).
+func MeasurementFromReportEntry(entry ReportEntry) (Measurement, bool) {
+	if measurement, ok := entry.Value.GetRawValue().(Measurement); ok {
+		return measurement, true
+	}
+	if entry.Value.AsJSON == "" {
+		return Measurement{}, false
+	}
+	measurement := Measurement{}
+	err := json.Unmarshal([]byte(entry.Value.AsJSON), &measurement)
+	if err != nil || measurement.Type == MeasurementTypeInvalid {
+		return Measurement{}, false
+	}
+	return measurement, true
+}
+
+// NewMeasurementBaseline collects the Measurements recorded by the passing specs in report
+func NewMeasurementBaseline(report Report) MeasurementBaseline {
+	baseline := MeasurementBaseline{}
+	for _, spec := range report.SpecReports.WithLeafNodeType(NodeTypeIt).WithState(SpecStatePassed) {
+		for _, entry := range spec.ReportEntries {
+			measurement, ok := MeasurementFromReportEntry(entry)
+			if !ok {
+				continue
+			}
+			if baseline[spec.FullText()] == nil {
+				baseline[spec.FullText()] = map[string]Measurement{}
+			}
+			baseline[spec.FullText()][measurement.Name] = measurement
+		}
+	}
+	return baseline
+}
+
+// LoadMeasurementBaseline loads a baseline saved by a previous run via --save-measurement-baseline
+func LoadMeasurementBaseline(path string) (MeasurementBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidMeasurementBaseline(path, err)
+	}
+	baseline := MeasurementBaseline{}
+	err = json.Unmarshal(data, &baseline)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidMeasurementBaseline(path, err)
+	}
+	return baseline, nil
+}
+
+// Save writes the baseline to path as JSON
+func (baseline MeasurementBaseline) Save(path string) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0666)
+}
+
+/*
+Compare compares the median of each Measurement recorded by the passing specs in report against the baseline.
+
+A Measurement regresses if its median exceeds the baseline median by more than tolerance (a fraction - e.g. 0.1 allows the median to grow by up to 10%).  For Measurements marked HigherIsBetter the direction is reversed: they regress if their median falls below the baseline median by more than tolerance.  Measurements that do not appear in the baseline are not compared.
+*/
+func (baseline MeasurementBaseline) Compare(report Report, tolerance float64) MeasurementComparisons {
+	comparisons := MeasurementComparisons{}
+	for _, spec := range report.SpecReports.WithLeafNodeType(NodeTypeIt).WithState(SpecStatePassed) {
+		for _, entry := range spec.ReportEntries {
+			current, ok := MeasurementFromReportEntry(entry)
+			if !ok {
+				continue
+			}
+			previous, ok := baseline[spec.FullText()][current.Name]
+			if !ok {
+				continue
+			}
+			comparison := MeasurementComparison{
+				SpecText:       spec.FullText(),
+				Name:           current.Name,
+				Type:           current.Type,
+				Units:          current.Units,
+				Baseline:       previous.Stats.Median,
+				Current:        current.Stats.Median,
+				HigherIsBetter: current.HigherIsBetter,
+			}
+			if comparison.Baseline != 0 {
+				comparison.Change = (comparison.Current - comparison.Baseline) / math.Abs(comparison.Baseline)
+			}
+			if comparison.HigherIsBetter {
+				comparison.Regressed = comparison.Change < -tolerance || (comparison.Baseline == 0 && comparison.Current < 0)
+			} else {
+				comparison.Regressed = comparison.Change > tolerance || (comparison.Baseline == 0 && comparison.Current > 0)
+			}
+			comparisons = append(comparisons, comparison)
+		}
+	}
+	return comparisons
+}
+
+// MeasurementComparison captures the comparison of a Measurement's median against its baseline
+type MeasurementComparison struct {
+	// SpecText is the full text of the spec that recorded the Measurement
+	SpecText string
+	// Name, Type, Units, and HigherIsBetter are copied from the Measurement
+	Name           string
+	Type           MeasurementType
+	Units          string
+	HigherIsBetter bool `json:",omitempty"`
+	// Baseline and Current are the baseline and current medians
+	Baseline float64
+	Current  float64
+	// Change is the fractional change in the median relative to the baseline - e.g. 0.25 means the median grew by 25%.  It is zero if the baseline median is zero.
+	Change float64
+	// Regressed is true if Change exceeded the configured --measurement-tolerance (or, for HigherIsBetter Measurements, fell below its negation)
+	Regressed bool
+}
+
+type MeasurementComparisons []MeasurementComparison
+
+// Regressions returns the comparisons that regressed
+func (comparisons MeasurementComparisons) Regressions() MeasurementComparisons {
+	out := MeasurementComparisons{}
+	for _, comparison := range comparisons {
+		if comparison.Regressed {
+			out = append(out, comparison)
+		}
+	}
+	return out
+}
+
+func (comparisons MeasurementComparisons) String() string {
+	return comparisons.format("", "", "")
+}
+
+// ColorableString renders the comparisons as a table, highlighting regressions.  This is how the comparisons appear in Ginkgo's console output.
+func (comparisons MeasurementComparisons) ColorableString() string {
+	return comparisons.format("{{bold}}", "{{red}}", "{{/}}")
+}
+
+func (comparisons MeasurementComparisons) format(headerStyle string, regressionStyle string, resetStyle string) string {
+	if len(comparisons) == 0 {
+		return ""
+	}
+	buf := &strings.Builder{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Spec\tName\tBaseline\tCurrent\tChange\t")
+	for _, comparison := range comparisons {
+		m := Measurement{Type: comparison.Type, Units: comparison.Units}
+		change, status := fmt.Sprintf("%+.1f%%", comparison.Change*100), ""
+		if comparison.Baseline == 0 {
+			change = "n/a"
+		}
+		if comparison.Regressed {
+			status = "REGRESSED"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", comparison.SpecText, comparison.Name, m.FormatValue(comparison.Baseline), m.FormatValue(comparison.Current), change, status)
+	}
+	w.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	lines[0] = headerStyle + strings.TrimRight(lines[0], " ") + resetStyle
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.TrimRight(lines[i], " ")
+		if comparisons[i-1].Regressed {
+			lines[i] = regressionStyle + lines[i] + resetStyle
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,173 @@
+package types_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MeasurementBaseline", func() {
+	var sorting, memory types.Measurement
+	var report types.Report
+
+	measurementEntry := func(m types.Measurement) types.ReportEntry {
+		return types.ReportEntry{Name: m.Name, Value: types.WrapEntryValue(m)}
+	}
+
+	BeforeEach(func() {
+		sorting = types.NewMeasurement(types.MeasurementTypeDuration, "sorting", "", []float64{float64(time.Millisecond), float64(2 * time.Millisecond), float64(3 * time.Millisecond)})
+		memory = types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{10, 20, 30})
+		report = types.Report{
+			SpecReports: types.SpecReports{
+				{ContainerHierarchyTexts: []string{"A"}, LeafNodeText: "sorts", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePassed,
+					ReportEntries: types.ReportEntries{measurementEntry(sorting), {Name: "not a measurement", Value: types.WrapEntryValue("hello")}}},
+				{ContainerHierarchyTexts: []string{"B"}, LeafNodeText: "fills", LeafNodeType: types.NodeTypeIt, State: types.SpecStatePassed,
+					ReportEntries: types.ReportEntries{measurementEntry(memory)}},
+				{ContainerHierarchyTexts: []string{"C"}, LeafNodeText: "fails", LeafNodeType: types.NodeTypeIt, State: types.SpecStateFailed,
+					ReportEntries: types.ReportEntries{measurementEntry(memory)}},
+				{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed,
+					ReportEntries: types.ReportEntries{measurementEntry(memory)}},
+			},
+		}
+	})
+
+	Describe("MeasurementFromReportEntry", func() {
+		It("returns Measurements recorded in this process", func() {
+			m, ok := types.MeasurementFromReportEntry(measurementEntry(sorting))
+			Ω(ok).Should(BeTrue())
+			Ω(m).Should(Equal(sorting))
+		})
+
+		It("returns Measurements decoded from a report", func() {
+			encoded, err := json.Marshal(measurementEntry(sorting))
+			Ω(err).ShouldNot(HaveOccurred())
+			var decoded types.ReportEntry
+			Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+
+			m, ok := types.MeasurementFromReportEntry(decoded)
+			Ω(ok).Should(BeTrue())
+			Ω(m).Should(Equal(sorting))
+		})
+
+		It("ignores other values", func() {
+			for _, value := range []any{"hello", map[string]any{"Name": "bob"}, nil} {
+				encoded, err := json.Marshal(types.ReportEntry{Value: types.WrapEntryValue(value)})
+				Ω(err).ShouldNot(HaveOccurred())
+				var decoded types.ReportEntry
+				Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+
+				_, ok := types.MeasurementFromReportEntry(decoded)
+				Ω(ok).Should(BeFalse())
+			}
+		})
+	})
+
+	Describe("NewMeasurementBaseline", func() {
+		It("collects the Measurements recorded by passing specs", func() {
+			Ω(types.NewMeasurementBaseline(report)).Should(Equal(types.MeasurementBaseline{
+				"A sorts": {"sorting": sorting},
+				"B fills": {"memory": memory},
+			}))
+		})
+	})
+
+	Describe("saving and loading", func() {
+		It("round-trips the baseline", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "baseline.json")
+			baseline := types.NewMeasurementBaseline(report)
+			Ω(baseline.Save(path)).Should(Succeed())
+
+			loaded, err := types.LoadMeasurementBaseline(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded).Should(Equal(baseline))
+		})
+
+		It("errors if the baseline can't be loaded", func() {
+			_, err := types.LoadMeasurementBaseline("/no/such/baseline.json")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.(types.GinkgoError).DocLink).Should(Equal("comparing-measurements-against-a-baseline"))
+
+			path := filepath.Join(GinkgoT().TempDir(), "baseline.json")
+			Ω(os.WriteFile(path, []byte("[1, 2, 3]"), 0644)).Should(Succeed())
+			_, err = types.LoadMeasurementBaseline(path)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.(types.GinkgoError).Heading).Should(ContainSubstring(path))
+		})
+	})
+
+	Describe("Compare", func() {
+		It("compares the median of each Measurement that appears in the baseline", func() {
+			baseline := types.MeasurementBaseline{
+				"A sorts": {"sorting": types.NewMeasurement(types.MeasurementTypeDuration, "sorting", "", []float64{float64(time.Millisecond)})},
+				"B fills": {"memory": types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{25})},
+				"C fails": {"memory": types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{1})},
+				"D gone":  {"memory": types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{1})},
+			}
+
+			comparisons := baseline.Compare(report, 0.5)
+			Ω(comparisons).Should(Equal(types.MeasurementComparisons{
+				{SpecText: "A sorts", Name: "sorting", Type: types.MeasurementTypeDuration, Baseline: float64(time.Millisecond), Current: float64(2 * time.Millisecond), Change: 1, Regressed: true},
+				{SpecText: "B fills", Name: "memory", Type: types.MeasurementTypeValue, Units: "MB", Baseline: 25, Current: 20, Change: -0.2},
+			}))
+			Ω(comparisons.Regressions()).Should(Equal(comparisons[:1]))
+
+			comparisons = baseline.Compare(report, 1)
+			Ω(comparisons.Regressions()).Should(BeEmpty())
+		})
+
+		It("reverses the direction for Measurements where higher is better", func() {
+			throughput := types.NewMeasurement(types.MeasurementTypeValue, "throughput", "req/s", []float64{80})
+			throughput.HigherIsBetter = true
+			report.SpecReports[1].ReportEntries = append(report.SpecReports[1].ReportEntries, measurementEntry(throughput))
+
+			baseline := types.MeasurementBaseline{
+				"B fills": {
+					"memory":     types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{15}),
+					"throughput": types.NewMeasurement(types.MeasurementTypeValue, "throughput", "req/s", []float64{100}),
+				},
+			}
+			comparisons := baseline.Compare(report, 0.1)
+			Ω(comparisons).Should(Equal(types.MeasurementComparisons{
+				{SpecText: "B fills", Name: "memory", Type: types.MeasurementTypeValue, Units: "MB", Baseline: 15, Current: 20, Change: 1.0 / 3.0, Regressed: true},
+				{SpecText: "B fills", Name: "throughput", Type: types.MeasurementTypeValue, Units: "req/s", HigherIsBetter: true, Baseline: 100, Current: 80, Change: -0.2, Regressed: true},
+			}))
+
+			baseline["B fills"]["throughput"] = types.NewMeasurement(types.MeasurementTypeValue, "throughput", "req/s", []float64{50})
+			comparisons = baseline.Compare(report, 0.1)
+			Ω(comparisons[1].Change).Should(Equal(0.6))
+			Ω(comparisons[1].Regressed).Should(BeFalse())
+
+			baseline["B fills"]["throughput"] = types.NewMeasurement(types.MeasurementTypeValue, "throughput", "req/s", []float64{0})
+			comparisons = baseline.Compare(report, 0.1)
+			Ω(comparisons[1].Regressed).Should(BeFalse())
+		})
+
+		It("handles baselines with a zero median", func() {
+			baseline := types.MeasurementBaseline{
+				"B fills": {"memory": types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{0})},
+			}
+			comparisons := baseline.Compare(report, 0.5)
+			Ω(comparisons).Should(HaveLen(1))
+			Ω(comparisons[0].Change).Should(BeZero())
+			Ω(comparisons[0].Regressed).Should(BeTrue())
+			Ω(comparisons.String()).Should(MatchRegexp(`0 MB\s+20 MB\s+n/a\s+REGRESSED`))
+		})
+	})
+
+	Describe("rendering comparisons", func() {
+		It("renders a table and highlights regressions", func() {
+			comparisons := types.MeasurementComparisons{
+				{SpecText: "A sorts", Name: "sorting", Type: types.MeasurementTypeDuration, Baseline: float64(time.Millisecond), Current: float64(2 * time.Millisecond), Change: 1, Regressed: true},
+				{SpecText: "B fills", Name: "memory", Type: types.MeasurementTypeValue, Units: "MB", Baseline: 25, Current: 20, Change: -0.2},
+			}
+			Ω(comparisons.String()).Should(Equal("Spec     Name     Baseline  Current  Change\nA sorts  sorting  1ms       2ms      +100.0%  REGRESSED\nB fills  memory   25 MB     20 MB    -20.0%"))
+			Ω(comparisons.ColorableString()).Should(Equal("{{bold}}Spec     Name     Baseline  Current  Change{{/}}\n{{red}}A sorts  sorting  1ms       2ms      +100.0%  REGRESSED{{/}}\nB fills  memory   25 MB     20 MB    -20.0%"))
+			Ω(types.MeasurementComparisons{}.String()).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package types_test
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Measurement", func() {
+	Describe("computing statistics", func() {
+		It("computes the summary statistics of the samples", func() {
+			m := types.NewMeasurement(types.MeasurementTypeValue, "values", "", []float64{7, 3, 10, 1, 5, 2, 9, 4, 8, 6})
+			Ω(m.Samples).Should(Equal([]float64{7, 3, 10, 1, 5, 2, 9, 4, 8, 6}), "the samples are not reordered")
+			Ω(m.Stats.N).Should(Equal(10))
+			Ω(m.Stats.Min).Should(Equal(1.0))
+			Ω(m.Stats.Max).Should(Equal(10.0))
+			Ω(m.Stats.Mean).Should(Equal(5.5))
+			Ω(m.Stats.Median).Should(Equal(5.5))
+			Ω(m.Stats.StdDev).Should(BeNumerically("~", math.Sqrt(8.25), 1e-9))
+			Ω(m.Stats.P90).Should(BeNumerically("~", 9.1, 1e-9))
+			Ω(m.Stats.P95).Should(BeNumerically("~", 9.55, 1e-9))
+			Ω(m.Stats.P99).Should(BeNumerically("~", 9.91, 1e-9))
+		})
+
+		It("handles a single sample", func() {
+			stats := types.ComputeMeasurementStats([]float64{3})
+			Ω(stats).Should(Equal(types.MeasurementStats{N: 1, Min: 3, Max: 3, Mean: 3, Median: 3, P90: 3, P95: 3, P99: 3}))
+		})
+
+		It("returns zero statistics when there are no samples", func() {
+			Ω(types.ComputeMeasurementStats(nil)).Should(BeZero())
+			Ω(types.NewMeasurement(types.MeasurementTypeValue, "empty", "", nil).Percentile(50)).Should(BeZero())
+		})
+
+		DescribeTable("computing arbitrary percentiles",
+			func(p float64, expected float64) {
+				m := types.NewMeasurement(types.MeasurementTypeValue, "values", "", []float64{40, 10, 30, 20})
+				Ω(m.Percentile(p)).Should(BeNumerically("~", expected, 1e-9))
+			},
+			Entry(nil, 0.0, 10.0),
+			Entry(nil, 25.0, 17.5),
+			Entry(nil, 50.0, 25.0),
+			Entry(nil, 100.0, 40.0),
+			Entry(nil, -10.0, 10.0),
+			Entry(nil, 110.0, 40.0),
+		)
+	})
+
+	DescribeTable("formatting values",
+		func(m types.Measurement, value float64, expected string) {
+			Ω(m.FormatValue(value)).Should(Equal(expected))
+		},
+		Entry("nanosecond durations", types.Measurement{Type: types.MeasurementTypeDuration}, 123.4, "123ns"),
+		Entry("microsecond durations", types.Measurement{Type: types.MeasurementTypeDuration}, float64(12345*time.Nanosecond), "12.345µs"),
+		Entry("millisecond durations", types.Measurement{Type: types.MeasurementTypeDuration}, float64(12345678*time.Nanosecond), "12.346ms"),
+		Entry("second durations", types.Measurement{Type: types.MeasurementTypeDuration}, float64(1234567890*time.Nanosecond), "1.235s"),
+		Entry("values", types.Measurement{Type: types.MeasurementTypeValue}, 3.14159265, "3.14159"),
+		Entry("values with units", types.Measurement{Type: types.MeasurementTypeValue, Units: "MB"}, 1024.0, "1024 MB"),
+	)
+
+	Describe("rendering", func() {
+		var m types.Measurement
+		BeforeEach(func() {
+			m = types.NewMeasurement(types.MeasurementTypeDuration, "sorting", "", []float64{float64(time.Millisecond), float64(3 * time.Millisecond)})
+		})
+
+		It("renders a table of statistics", func() {
+			Ω(m.String()).Should(Equal("Name     N  Min  Median  Mean  StdDev  P90    P95    P99     Max\nsorting  2  1ms  2ms     2ms   1ms     2.8ms  2.9ms  2.98ms  3ms"))
+		})
+
+		It("emphasizes the header when rendered for the console", func() {
+			Ω(m.ColorableString()).Should(Equal("{{bold}}Name     N  Min  Median  Mean  StdDev  P90    P95    P99     Max{{/}}\nsorting  2  1ms  2ms     2ms   1ms     2.8ms  2.9ms  2.98ms  3ms"))
+		})
+	})
+
+	It("round-trips through JSON", func() {
+		m := types.NewMeasurement(types.MeasurementTypeValue, "memory", "MB", []float64{1, 2, 3})
+		encoded, err := json.Marshal(m)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(encoded)).Should(ContainSubstring(`"Type":"value"`))
+
+		var decoded types.Measurement
+		Ω(json.Unmarshal(encoded, &decoded)).Should(Succeed())
+		Ω(decoded).Should(Equal(m))
+	})
+})
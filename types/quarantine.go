@@ -0,0 +1,106 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const quarantineExpiryFormat = "2006-01-02"
+
+var quarantineFileLineRegExp = regexp.MustCompile(`^(.+):(\d+)$`)
+
+/*
+QuarantineEntry identifies a known-flaky spec listed in a --quarantine-file.
+
+Spec is either the full text of the spec (the text of its containers and its It joined by spaces, as reported by SpecReport.FullText()) or a file:line pointing at the spec's It.  The file can be any trailing portion of the spec's file path (e.g. books_test.go:17 or books/books_test.go:17).
+
+If FlakeAttempts is set the spec is simply retried up to FlakeAttempts times.  Otherwise the spec is quarantined: it runs as normal but any failure is reported as SpecStateQuarantined and does not fail the suite.
+
+Entries with an Expires date (formatted as YYYY-MM-DD) are ignored on and after that date.
+*/
+type QuarantineEntry struct {
+	Spec          string
+	Reason        string
+	Expires       string
+	FlakeAttempts int
+}
+
+// IsZero returns true if the entry is empty
+func (e QuarantineEntry) IsZero() bool {
+	return e.Spec == ""
+}
+
+// IsExpired returns true if the entry has an expiry date and that date has been reached
+func (e QuarantineEntry) IsExpired(now time.Time) bool {
+	if e.Expires == "" {
+		return false
+	}
+	expires, err := time.ParseInLocation(quarantineExpiryFormat, e.Expires, now.Location())
+	if err != nil {
+		return false
+	}
+	return !now.Before(expires)
+}
+
+// Matches returns true if the entry refers to the spec with the passed-in full text and leaf node location
+func (e QuarantineEntry) Matches(fullText string, leafNodeLocation CodeLocation) bool {
+	if e.Spec == fullText {
+		return true
+	}
+	match := quarantineFileLineRegExp.FindStringSubmatch(e.Spec)
+	if match == nil {
+		return false
+	}
+	line, _ := strconv.Atoi(match[2])
+	if line != leafNodeLocation.LineNumber {
+		return false
+	}
+	file, specFile := filepath.ToSlash(match[1]), filepath.ToSlash(leafNodeLocation.FileName)
+	return specFile == file || strings.HasSuffix(specFile, "/"+file)
+}
+
+// Quarantine is the list of QuarantineEntry loaded from a --quarantine-file
+type Quarantine []QuarantineEntry
+
+// LoadQuarantine loads and validates the JSON-formatted quarantine file at path
+func LoadQuarantine(path string) (Quarantine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidQuarantineFile(path, err)
+	}
+	quarantine := Quarantine{}
+	err = json.Unmarshal(data, &quarantine)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidQuarantineFile(path, err)
+	}
+	for _, entry := range quarantine {
+		if entry.Spec == "" {
+			return nil, GinkgoErrors.InvalidQuarantineFile(path, errors.New("every entry must identify a spec"))
+		}
+		if entry.Expires != "" {
+			if _, err := time.Parse(quarantineExpiryFormat, entry.Expires); err != nil {
+				return nil, GinkgoErrors.InvalidQuarantineFile(path, errors.New("invalid expiry date for "+entry.Spec+" - use YYYY-MM-DD"))
+			}
+		}
+		if entry.FlakeAttempts < 0 {
+			return nil, GinkgoErrors.InvalidQuarantineFile(path, errors.New("invalid FlakeAttempts for "+entry.Spec))
+		}
+	}
+	return quarantine, nil
+}
+
+// EntryFor returns the first unexpired entry that matches the spec with the passed-in full text and leaf node location
+func (q Quarantine) EntryFor(fullText string, leafNodeLocation CodeLocation, now time.Time) (QuarantineEntry, bool) {
+	for _, entry := range q {
+		if !entry.IsExpired(now) && entry.Matches(fullText, leafNodeLocation) {
+			return entry, true
+		}
+	}
+	return QuarantineEntry{}, false
+}
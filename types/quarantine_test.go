@@ -0,0 +1,87 @@
+package types_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quarantine", func() {
+	var path string
+	var now time.Time
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "quarantine.json")
+		now = time.Date(2024, time.March, 10, 12, 0, 0, 0, time.Local)
+	})
+
+	write := func(content string) {
+		Ω(os.WriteFile(path, []byte(content), 0644)).Should(Succeed())
+	}
+
+	Describe("LoadQuarantine", func() {
+		It("loads the entries in the file", func() {
+			write(`[{"Spec": "books is long", "Reason": "see #42", "Expires": "2024-04-01"}, {"Spec": "books_test.go:17", "FlakeAttempts": 3}]`)
+			quarantine, err := types.LoadQuarantine(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(quarantine).Should(Equal(types.Quarantine{
+				{Spec: "books is long", Reason: "see #42", Expires: "2024-04-01"},
+				{Spec: "books_test.go:17", FlakeAttempts: 3},
+			}))
+		})
+
+		DescribeTable("invalid quarantine files",
+			func(content string) {
+				if content != "" {
+					write(content)
+				}
+				quarantine, err := types.LoadQuarantine(path)
+				Ω(quarantine).Should(BeNil())
+				Ω(err).Should(HaveOccurred())
+				Ω(err.(types.GinkgoError).DocLink).Should(Equal("quarantining-flaky-specs"))
+			},
+			Entry("missing file", ""),
+			Entry("malformed json", `[{"Spec": `),
+			Entry("missing spec", `[{"Reason": "flaky"}]`),
+			Entry("malformed expiry", `[{"Spec": "A", "Expires": "next tuesday"}]`),
+			Entry("negative flake attempts", `[{"Spec": "A", "FlakeAttempts": -1}]`),
+		)
+	})
+
+	Describe("matching specs", func() {
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			cl = types.CodeLocation{FileName: "/path/to/books/books_test.go", LineNumber: 17}
+		})
+
+		DescribeTable("Matches",
+			func(spec string, expected bool) {
+				Ω(types.QuarantineEntry{Spec: spec}.Matches("books is long", cl)).Should(Equal(expected))
+			},
+			Entry(nil, "books is long", true),
+			Entry(nil, "books is", false),
+			Entry(nil, "books_test.go:17", true),
+			Entry(nil, "books/books_test.go:17", true),
+			Entry(nil, "/path/to/books/books_test.go:17", true),
+			Entry(nil, "ooks_test.go:17", false),
+			Entry(nil, "books_test.go:18", false),
+		)
+
+		It("ignores expired entries", func() {
+			quarantine := types.Quarantine{
+				{Spec: "books is long", Reason: "expired", Expires: "2024-03-10"},
+				{Spec: "books_test.go:17", Reason: "active", Expires: "2024-03-11"},
+			}
+			entry, found := quarantine.EntryFor("books is long", cl, now)
+			Ω(found).Should(BeTrue())
+			Ω(entry.Reason).Should(Equal("active"))
+
+			_, found = quarantine.EntryFor("books is long", cl, now.AddDate(0, 0, 1))
+			Ω(found).Should(BeFalse())
+		})
+	})
+})
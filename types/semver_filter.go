@@ -0,0 +1,52 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// SemVerFilter returns true if every one of the passed-in semantic version constraints is satisfied
+type SemVerFilter func([]string) bool
+
+/*
+ParseSemVerFilter parses the version passed to --sem-ver-filter and returns a SemVerFilter that checks spec constraints against it.
+
+Constraints that fail to parse never match.  Specs with no constraints always match.
+*/
+func ParseSemVerFilter(version string) (SemVerFilter, error) {
+	v, err := semver.NewVersion(strings.TrimSpace(version))
+	if err != nil {
+		return nil, GinkgoErrors.InvalidSemVerFilter(version, err)
+	}
+	return func(constraints []string) bool {
+		for _, constraint := range constraints {
+			c, err := semver.NewConstraint(constraint)
+			if err != nil || !c.Check(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// MustParseSemVerFilter is like ParseSemVerFilter but panics if the version is invalid
+func MustParseSemVerFilter(version string) SemVerFilter {
+	filter, err := ParseSemVerFilter(version)
+	if err != nil {
+		panic(err)
+	}
+	return filter
+}
+
+// ValidateAndCleanupSemVerConstraint trims the passed-in constraint and ensures it is a valid semantic version constraint (e.g. ">= 2.1.0, < 3.0")
+func ValidateAndCleanupSemVerConstraint(constraint string, cl CodeLocation) (string, error) {
+	out := strings.TrimSpace(constraint)
+	if out == "" {
+		return "", GinkgoErrors.InvalidSemVerConstraint(constraint, "constraints cannot be empty", cl)
+	}
+	if _, err := semver.NewConstraint(out); err != nil {
+		return "", GinkgoErrors.InvalidSemVerConstraint(constraint, err.Error(), cl)
+	}
+	return out, nil
+}
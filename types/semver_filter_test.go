@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SemVerFilter", func() {
+	DescribeTable("Matching constraints against a version",
+		func(version string, constraints []string, expected bool) {
+			filter, err := types.ParseSemVerFilter(version)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(filter(constraints)).Should(Equal(expected))
+		},
+		Entry("no constraints", "2.3.1", []string{}, true),
+		Entry("in range", "2.3.1", []string{">= 2.1.0, < 3.0"}, true),
+		Entry("out of range", "3.0.0", []string{">= 2.1.0, < 3.0"}, false),
+		Entry("or-ed ranges", "1.2.7", []string{"~1.2 || ^2"}, true),
+		Entry("all constraints must be satisfied", "2.3.1", []string{"^2", "< 2.3"}, false),
+		Entry("a leading v", "v2.3.1", []string{"~2.3"}, true),
+		Entry("an invalid constraint", "2.3.1", []string{"not a version"}, false),
+	)
+
+	It("errors when the version is invalid", func() {
+		filter, err := types.ParseSemVerFilter("two point three")
+		Ω(filter).Should(BeNil())
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("Invalid SemVer Filter"))
+	})
+
+	Describe("ValidateAndCleanupSemVerConstraint", func() {
+		var cl types.CodeLocation
+		BeforeEach(func() {
+			cl = types.NewCodeLocation(0)
+		})
+
+		It("trims valid constraints", func() {
+			Ω(types.ValidateAndCleanupSemVerConstraint("  >= 2.1.0, < 3.0 ", cl)).Should(Equal(">= 2.1.0, < 3.0"))
+		})
+
+		It("rejects empty constraints", func() {
+			constraint, err := types.ValidateAndCleanupSemVerConstraint("   ", cl)
+			Ω(constraint).Should(BeZero())
+			Ω(err).Should(Equal(types.GinkgoErrors.InvalidSemVerConstraint("   ", "constraints cannot be empty", cl)))
+		})
+
+		It("rejects malformed constraints", func() {
+			constraint, err := types.ValidateAndCleanupSemVerConstraint(">= banana", cl)
+			Ω(constraint).Should(BeZero())
+			Ω(err.Error()).Should(ContainSubstring("'>= banana' is an invalid semantic version constraint"))
+		})
+	})
+})
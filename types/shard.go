@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Shard identifies the (one-indexed) subset of a suite's specs that should run when the suite is split across multiple machines via --shard=i/N
+type Shard struct {
+	Index int
+	Total int
+}
+
+// ParseShard parses a shard of the form "i/N".  An empty string parses to the zero Shard which runs every spec.
+func ParseShard(shard string) (Shard, error) {
+	if shard == "" {
+		return Shard{}, nil
+	}
+	components := strings.Split(shard, "/")
+	if len(components) != 2 {
+		return Shard{}, GinkgoErrors.InvalidShard(shard)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(components[0]))
+	if err != nil {
+		return Shard{}, GinkgoErrors.InvalidShard(shard)
+	}
+	total, err := strconv.Atoi(strings.TrimSpace(components[1]))
+	if err != nil {
+		return Shard{}, GinkgoErrors.InvalidShard(shard)
+	}
+	if total < 1 || index < 1 || index > total {
+		return Shard{}, GinkgoErrors.InvalidShard(shard)
+	}
+	return Shard{Index: index, Total: total}, nil
+}
+
+// IsZero returns true if no shard has been specified
+func (s Shard) IsZero() bool {
+	return s.Total == 0
+}
+
+func (s Shard) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Total)
+}
@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shard", func() {
+	DescribeTable("Parsing valid shards",
+		func(shard string, expected types.Shard) {
+			s, err := types.ParseShard(shard)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(s).Should(Equal(expected))
+			Ω(s.String()).Should(Equal(shard))
+		},
+		Entry(nil, "", types.Shard{}),
+		Entry(nil, "1/1", types.Shard{Index: 1, Total: 1}),
+		Entry(nil, "2/3", types.Shard{Index: 2, Total: 3}),
+		Entry(nil, "3/3", types.Shard{Index: 3, Total: 3}),
+	)
+
+	DescribeTable("Parsing invalid shards",
+		func(shard string) {
+			s, err := types.ParseShard(shard)
+			Ω(s).Should(BeZero())
+			Ω(err).Should(Equal(types.GinkgoErrors.InvalidShard(shard)))
+		},
+		Entry(nil, "1"),
+		Entry(nil, "1/2/3"),
+		Entry(nil, "a/2"),
+		Entry(nil, "1/b"),
+		Entry(nil, "0/2"),
+		Entry(nil, "3/2"),
+		Entry(nil, "1/0"),
+	)
+})
@@ -0,0 +1,54 @@
+package types
+
+import "encoding/json"
+
+/*
+SpecIdentity identifies a single spec by the location and full text of its subject node.  Ginkgo uses these to focus on the specs that failed in a previous run (see --rerun-failed).
+
+SpecIdentities are passed to the test binary via --focus-spec as JSON so that file names that contain a ':' (e.g. Windows paths) are unambiguous.
+*/
+type SpecIdentity struct {
+	FileName   string
+	LineNumber int
+	FullText   string
+}
+
+// SpecIdentityFor returns the identity of the spec described by report
+func SpecIdentityFor(report SpecReport) SpecIdentity {
+	return SpecIdentity{
+		FileName:   report.FileName(),
+		LineNumber: report.LineNumber(),
+		FullText:   report.FullText(),
+	}
+}
+
+// String returns the JSON encoding of the identity that --focus-spec expects
+func (s SpecIdentity) String() string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
+
+type SpecIdentities []SpecIdentity
+
+// ParseSpecIdentities parses the JSON-encoded identities passed to --focus-spec
+func ParseSpecIdentities(identities []string) (SpecIdentities, error) {
+	out := SpecIdentities{}
+	for _, identity := range identities {
+		spec := SpecIdentity{}
+		if err := json.Unmarshal([]byte(identity), &spec); err != nil || spec.FileName == "" {
+			return nil, GinkgoErrors.InvalidSpecIdentity(identity)
+		}
+		out = append(out, spec)
+	}
+	return out, nil
+}
+
+// Matches returns true if any of the identities match the spec whose subject node is at location and whose full text is fullText
+func (s SpecIdentities) Matches(location CodeLocation, fullText string) bool {
+	for _, spec := range s {
+		if spec.FileName == location.FileName && spec.LineNumber == location.LineNumber && spec.FullText == fullText {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecIdentity", func() {
+	It("is computed from a spec report", func() {
+		report := types.SpecReport{
+			ContainerHierarchyTexts: []string{"outer", "inner"},
+			LeafNodeText:            "spec",
+			LeafNodeLocation:        types.CodeLocation{FileName: "/path/to/a_test.go", LineNumber: 12},
+		}
+		Ω(types.SpecIdentityFor(report)).Should(Equal(types.SpecIdentity{FileName: "/path/to/a_test.go", LineNumber: 12, FullText: "outer inner spec"}))
+	})
+
+	It("round-trips through String and ParseSpecIdentities, even when the file name contains a ':'", func() {
+		identities := types.SpecIdentities{
+			{FileName: `C:\path\to\a_test.go`, LineNumber: 12, FullText: "outer: inner spec"},
+			{FileName: "/path/to/b_test.go", LineNumber: 3, FullText: "spec"},
+		}
+		parsed, err := types.ParseSpecIdentities([]string{identities[0].String(), identities[1].String()})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(parsed).Should(Equal(identities))
+
+		Ω(parsed.Matches(types.CodeLocation{FileName: `C:\path\to\a_test.go`, LineNumber: 12}, "outer: inner spec")).Should(BeTrue())
+		Ω(parsed.Matches(types.CodeLocation{FileName: `C:\path\to\a_test.go`, LineNumber: 13}, "outer: inner spec")).Should(BeFalse())
+		Ω(parsed.Matches(types.CodeLocation{FileName: `C:\path\to\a_test.go`, LineNumber: 12}, "outer: inner")).Should(BeFalse())
+		Ω(parsed.Matches(types.CodeLocation{FileName: `/path/to/a_test.go`, LineNumber: 12}, "outer: inner spec")).Should(BeFalse())
+	})
+
+	DescribeTable("Parsing invalid identities",
+		func(identity string) {
+			parsed, err := types.ParseSpecIdentities([]string{identity})
+			Ω(parsed).Should(BeNil())
+			Ω(err).Should(Equal(types.GinkgoErrors.InvalidSpecIdentity(identity)))
+		},
+		Entry(nil, "a_test.go:12"),
+		Entry(nil, `{"LineNumber": 12}`),
+		Entry(nil, `{"FileName": 12}`),
+	)
+})
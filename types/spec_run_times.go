@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SpecRunTimes captures the run times recorded for specs in a previous run.  It is keyed by SpecRunTimeKey.
+type SpecRunTimes map[string]time.Duration
+
+/*
+SpecRunTimeKey returns the key used to identify a spec in SpecRunTimes.
+
+Specs are identified by the name of the file and line containing their leaf node along with their full text.  The directory is deliberately omitted so that run times recorded on one machine (e.g. in CI) can be used on another.
+*/
+func SpecRunTimeKey(leafNodeLocation CodeLocation, containerTexts []string, leafNodeText string) string {
+	texts := append([]string{}, containerTexts...)
+	if leafNodeText != "" {
+		texts = append(texts, leafNodeText)
+	}
+	return fmt.Sprintf("%s:%d %s", filepath.Base(leafNodeLocation.FileName), leafNodeLocation.LineNumber, strings.Join(texts, " "))
+}
+
+// LoadSpecRunTimes loads the run times of all specs that ran in a JSON-formatted report generated via --json-report
+func LoadSpecRunTimes(path string) (SpecRunTimes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidDurationReport(path, err)
+	}
+	reports := []Report{}
+	err = json.Unmarshal(data, &reports)
+	if err != nil {
+		return nil, GinkgoErrors.InvalidDurationReport(path, err)
+	}
+
+	runTimes := SpecRunTimes{}
+	for _, report := range reports {
+		for _, spec := range report.SpecReports.WithLeafNodeType(NodeTypeIt) {
+			if spec.State.Is(SpecStatePending | SpecStateSkipped) {
+				continue
+			}
+			key := SpecRunTimeKey(spec.LeafNodeLocation, spec.ContainerHierarchyTexts, spec.LeafNodeText)
+			if spec.RunTime > runTimes[key] {
+				runTimes[key] = spec.RunTime
+			}
+		}
+	}
+	return runTimes, nil
+}
+
+// Mean returns the mean of all recorded run times
+func (runTimes SpecRunTimes) Mean() time.Duration {
+	if len(runTimes) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, runTime := range runTimes {
+		total += runTime
+	}
+	return total / time.Duration(len(runTimes))
+}
@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecRunTimes", func() {
+	var path string
+
+	spec := func(nodeType types.NodeType, text string, line int, state types.SpecState, runTime time.Duration) types.SpecReport {
+		return types.SpecReport{
+			ContainerHierarchyTexts: []string{"container"},
+			LeafNodeType:            nodeType,
+			LeafNodeText:            text,
+			LeafNodeLocation:        types.CodeLocation{FileName: "/path/to/a_test.go", LineNumber: line},
+			State:                   state,
+			RunTime:                 runTime,
+		}
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "report.json")
+		reports := []types.Report{
+			{SpecReports: types.SpecReports{
+				spec(types.NodeTypeIt, "A", 1, types.SpecStatePassed, time.Second),
+				spec(types.NodeTypeIt, "B", 2, types.SpecStateFailed, 3*time.Second),
+				spec(types.NodeTypeIt, "C", 3, types.SpecStateSkipped, 0),
+				spec(types.NodeTypeBeforeSuite, "", 4, types.SpecStatePassed, time.Minute),
+			}},
+			{SpecReports: types.SpecReports{
+				spec(types.NodeTypeIt, "A", 1, types.SpecStatePassed, 2*time.Second),
+			}},
+		}
+		data, err := json.Marshal(reports)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(os.WriteFile(path, data, 0644)).Should(Succeed())
+	})
+
+	It("loads the longest recorded run time of each spec that ran, keyed by file name, line, and text", func() {
+		runTimes, err := types.LoadSpecRunTimes(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(runTimes).Should(Equal(types.SpecRunTimes{
+			"a_test.go:1 container A": 2 * time.Second,
+			"a_test.go:2 container B": 3 * time.Second,
+		}))
+		Ω(runTimes.Mean()).Should(Equal(2500 * time.Millisecond))
+	})
+
+	It("errors when the report can't be loaded", func() {
+		_, err := types.LoadSpecRunTimes(path + ".missing")
+		Ω(err).Should(HaveOccurred())
+
+		Ω(os.WriteFile(path, []byte("not json"), 0644)).Should(Succeed())
+		_, err = types.LoadSpecRunTimes(path)
+		Ω(err).Should(HaveOccurred())
+	})
+})
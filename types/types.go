@@ -11,6 +11,9 @@ import (
 const GINKGO_FOCUS_EXIT_CODE = 197
 const GINKGO_TIME_FORMAT = "01/02/06 15:04:05.999"
 
+// PARALLEL_TOKEN_ENV_VAR names the environment variable that holds the token workers use to authenticate with a Ginkgo CLI running with --serve
+const PARALLEL_TOKEN_ENV_VAR = "GINKGO_PARALLEL_TOKEN"
+
 // Report captures information about a Ginkgo test run
 type Report struct {
 	//SuitePath captures the absolute path to the test suite
@@ -60,14 +63,22 @@ type Report struct {
 	//SpecReports is a list of all SpecReports generated by this test run
 	//It is empty when the SuiteReport is provided to ReportBeforeSuite
 	SpecReports SpecReports
+
+	//MeasurementComparisons captures the comparison of the suite's Measurements against the baseline
+	//passed in via --measurement-baseline.  It is empty if no baseline was provided.
+	MeasurementComparisons MeasurementComparisons
 }
 
 // PreRunStats contains a set of stats captured before the test run begins.  This is primarily used
 // by Ginkgo's reporter to tell the user how many specs are in the current suite (PreRunStats.TotalSpecs)
 // and how many it intends to run (PreRunStats.SpecsThatWillRun) after applying any relevant focus or skip filters.
+//
+// When the suite is split across machines via --shard, PreRunStats.SpecsInOtherShards counts the specs that were assigned to
+// other shards.  These specs do not appear in Report.SpecReports and are not counted as skipped.
 type PreRunStats struct {
-	TotalSpecs       int
-	SpecsThatWillRun int
+	TotalSpecs         int
+	SpecsThatWillRun   int
+	SpecsInOtherShards int
 }
 
 // Add is used by Ginkgo's parallel aggregation mechanisms to combine test run reports form individual parallel processes
@@ -106,6 +117,9 @@ func (report Report) Add(other Report) Report {
 	}
 
 	report.SpecReports = reports
+	if len(other.MeasurementComparisons) > 0 {
+		report.MeasurementComparisons = append(append(MeasurementComparisons{}, report.MeasurementComparisons...), other.MeasurementComparisons...)
+	}
 	return report
 }
 
@@ -123,6 +137,10 @@ type SpecReport struct {
 	// all Describe/Context/When containers in this spec's hierarchy
 	ContainerHierarchyLabels [][]string
 
+	// ContainerHierarchySemVerConstraints is a slice containing the semantic version constraints of
+	// all Describe/Context/When containers in this spec's hierarchy
+	ContainerHierarchySemVerConstraints [][]string
+
 	// LeafNodeType, LeadNodeLocation, LeafNodeLabels and LeafNodeText capture the NodeType, CodeLocation, and text
 	// of the Ginkgo node being tested (typically an NodeTypeIt node, though this can also be
 	// one of the NodeTypesForSuiteLevelNodes node types)
@@ -131,6 +149,9 @@ type SpecReport struct {
 	LeafNodeLabels   []string
 	LeafNodeText     string
 
+	// LeafNodeSemVerConstraints captures the semantic version constraints attached to the Ginkgo node being tested
+	LeafNodeSemVerConstraints []string
+
 	// State captures whether the spec has passed, failed, etc.
 	State SpecState
 
@@ -140,6 +161,13 @@ type SpecReport struct {
 	// IsInOrderedContainer captures whether the spec appears in an Ordered container
 	IsInOrderedContainer bool
 
+	// IsQuarantined captures whether the spec was quarantined via --quarantine-file.
+	// Failures of quarantined specs are reported as SpecStateQuarantined.
+	IsQuarantined bool
+
+	// QuarantineReason captures the reason provided in the --quarantine-file (if any)
+	QuarantineReason string
+
 	// StartTime and EndTime capture the start and end time of the spec
 	StartTime time.Time
 	EndTime   time.Time
@@ -147,6 +175,12 @@ type SpecReport struct {
 	// RunTime captures the duration of the spec
 	RunTime time.Duration
 
+	// ExclusiveResources captures the resources the spec required exclusive access to via the Exclusive decorator
+	ExclusiveResources []string
+
+	// ExclusiveResourcesWaitTime captures how long the spec waited to acquire its ExclusiveResources.  It is not included in RunTime.
+	ExclusiveResourcesWaitTime time.Duration
+
 	// ParallelProcess captures the parallel process that this spec ran on
 	ParallelProcess int
 
@@ -192,48 +226,60 @@ type SpecReport struct {
 func (report SpecReport) MarshalJSON() ([]byte, error) {
 	//All this to avoid emitting an empty Failure struct in the JSON
 	out := struct {
-		ContainerHierarchyTexts     []string
-		ContainerHierarchyLocations []CodeLocation
-		ContainerHierarchyLabels    [][]string
-		LeafNodeType                NodeType
-		LeafNodeLocation            CodeLocation
-		LeafNodeLabels              []string
-		LeafNodeText                string
-		State                       SpecState
-		StartTime                   time.Time
-		EndTime                     time.Time
-		RunTime                     time.Duration
-		ParallelProcess             int
-		Failure                     *Failure `json:",omitempty"`
-		NumAttempts                 int
-		MaxFlakeAttempts            int
-		MaxMustPassRepeatedly       int
-		CapturedGinkgoWriterOutput  string              `json:",omitempty"`
-		CapturedStdOutErr           string              `json:",omitempty"`
-		ReportEntries               ReportEntries       `json:",omitempty"`
-		ProgressReports             []ProgressReport    `json:",omitempty"`
-		AdditionalFailures          []AdditionalFailure `json:",omitempty"`
-		SpecEvents                  SpecEvents          `json:",omitempty"`
+		ContainerHierarchyTexts             []string
+		ContainerHierarchyLocations         []CodeLocation
+		ContainerHierarchyLabels            [][]string
+		ContainerHierarchySemVerConstraints [][]string `json:",omitempty"`
+		LeafNodeType                        NodeType
+		LeafNodeLocation                    CodeLocation
+		LeafNodeLabels                      []string
+		LeafNodeSemVerConstraints           []string `json:",omitempty"`
+		LeafNodeText                        string
+		State                               SpecState
+		IsQuarantined                       bool   `json:",omitempty"`
+		QuarantineReason                    string `json:",omitempty"`
+		StartTime                           time.Time
+		EndTime                             time.Time
+		RunTime                             time.Duration
+		ExclusiveResources                  []string      `json:",omitempty"`
+		ExclusiveResourcesWaitTime          time.Duration `json:",omitempty"`
+		ParallelProcess                     int
+		Failure                             *Failure `json:",omitempty"`
+		NumAttempts                         int
+		MaxFlakeAttempts                    int
+		MaxMustPassRepeatedly               int
+		CapturedGinkgoWriterOutput          string              `json:",omitempty"`
+		CapturedStdOutErr                   string              `json:",omitempty"`
+		ReportEntries                       ReportEntries       `json:",omitempty"`
+		ProgressReports                     []ProgressReport    `json:",omitempty"`
+		AdditionalFailures                  []AdditionalFailure `json:",omitempty"`
+		SpecEvents                          SpecEvents          `json:",omitempty"`
 	}{
-		ContainerHierarchyTexts:     report.ContainerHierarchyTexts,
-		ContainerHierarchyLocations: report.ContainerHierarchyLocations,
-		ContainerHierarchyLabels:    report.ContainerHierarchyLabels,
-		LeafNodeType:                report.LeafNodeType,
-		LeafNodeLocation:            report.LeafNodeLocation,
-		LeafNodeLabels:              report.LeafNodeLabels,
-		LeafNodeText:                report.LeafNodeText,
-		State:                       report.State,
-		StartTime:                   report.StartTime,
-		EndTime:                     report.EndTime,
-		RunTime:                     report.RunTime,
-		ParallelProcess:             report.ParallelProcess,
-		Failure:                     nil,
-		ReportEntries:               nil,
-		NumAttempts:                 report.NumAttempts,
-		MaxFlakeAttempts:            report.MaxFlakeAttempts,
-		MaxMustPassRepeatedly:       report.MaxMustPassRepeatedly,
-		CapturedGinkgoWriterOutput:  report.CapturedGinkgoWriterOutput,
-		CapturedStdOutErr:           report.CapturedStdOutErr,
+		ContainerHierarchyTexts:             report.ContainerHierarchyTexts,
+		ContainerHierarchyLocations:         report.ContainerHierarchyLocations,
+		ContainerHierarchyLabels:            report.ContainerHierarchyLabels,
+		ContainerHierarchySemVerConstraints: report.ContainerHierarchySemVerConstraints,
+		LeafNodeType:                        report.LeafNodeType,
+		LeafNodeLocation:                    report.LeafNodeLocation,
+		LeafNodeLabels:                      report.LeafNodeLabels,
+		LeafNodeSemVerConstraints:           report.LeafNodeSemVerConstraints,
+		LeafNodeText:                        report.LeafNodeText,
+		State:                               report.State,
+		IsQuarantined:                       report.IsQuarantined,
+		QuarantineReason:                    report.QuarantineReason,
+		StartTime:                           report.StartTime,
+		EndTime:                             report.EndTime,
+		RunTime:                             report.RunTime,
+		ExclusiveResources:                  report.ExclusiveResources,
+		ExclusiveResourcesWaitTime:          report.ExclusiveResourcesWaitTime,
+		ParallelProcess:                     report.ParallelProcess,
+		Failure:                             nil,
+		ReportEntries:                       nil,
+		NumAttempts:                         report.NumAttempts,
+		MaxFlakeAttempts:                    report.MaxFlakeAttempts,
+		MaxMustPassRepeatedly:               report.MaxMustPassRepeatedly,
+		CapturedGinkgoWriterOutput:          report.CapturedGinkgoWriterOutput,
+		CapturedStdOutErr:                   report.CapturedStdOutErr,
 	}
 
 	if !report.Failure.IsZero() {
@@ -306,6 +352,28 @@ func (report SpecReport) Labels() []string {
 	return out
 }
 
+// SemVerConstraints returns a deduped set of all the spec's semantic version constraints.
+func (report SpecReport) SemVerConstraints() []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, constraints := range report.ContainerHierarchySemVerConstraints {
+		for _, constraint := range constraints {
+			if !seen[constraint] {
+				seen[constraint] = true
+				out = append(out, constraint)
+			}
+		}
+	}
+	for _, constraint := range report.LeafNodeSemVerConstraints {
+		if !seen[constraint] {
+			seen[constraint] = true
+			out = append(out, constraint)
+		}
+	}
+
+	return out
+}
+
 // MatchesLabelFilter returns true if the spec satisfies the passed in label filter query
 func (report SpecReport) MatchesLabelFilter(query string) (bool, error) {
 	filter, err := ParseLabelFilter(query)
@@ -587,6 +655,7 @@ const (
 	SpecStatePanicked
 	SpecStateInterrupted
 	SpecStateTimedout
+	SpecStateQuarantined
 )
 
 var ssEnumSupport = NewEnumSupport(map[uint]string{
@@ -599,6 +668,7 @@ var ssEnumSupport = NewEnumSupport(map[uint]string{
 	uint(SpecStatePanicked):    "panicked",
 	uint(SpecStateInterrupted): "interrupted",
 	uint(SpecStateTimedout):    "timedout",
+	uint(SpecStateQuarantined): "quarantined",
 })
 
 func (ss SpecState) String() string {
@@ -642,6 +712,9 @@ type ProgressReport struct {
 	CurrentStepLocation  CodeLocation `json:",omitempty"`
 	CurrentStepStartTime time.Time    `json:",omitempty"`
 
+	WaitingForExclusiveResources    []string  `json:",omitempty"`
+	ExclusiveResourcesWaitStartTime time.Time `json:",omitempty"`
+
 	AdditionalReports []string `json:",omitempty"`
 
 	CapturedGinkgoWriterOutput string           `json:",omitempty"`
@@ -57,6 +57,13 @@ var _ = Describe("Types", func() {
 				}))
 
 			})
+
+			It("concatenates measurement comparisons", func() {
+				reportA := types.Report{MeasurementComparisons: types.MeasurementComparisons{{Name: "A"}}}
+				reportB := types.Report{MeasurementComparisons: types.MeasurementComparisons{{Name: "B", Regressed: true}}}
+				Ω(reportA.Add(reportB).MeasurementComparisons).Should(Equal(types.MeasurementComparisons{{Name: "A"}, {Name: "B", Regressed: true}}))
+				Ω(reportA.MeasurementComparisons).Should(HaveLen(1))
+			})
 		})
 	})
 